@@ -0,0 +1,361 @@
+// Package audit records an append-only trail of the changes made through the
+// HTTP APIs of the fitness center services.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Actions recorded for requests that do not name a more specific action in
+// their route, such as the status in /{resource}/:id/status
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// resourceIDKey is the gin context key handlers can use to name the changed
+// resource when it is not part of the route or the response
+const resourceIDKey = "audit_resource_id"
+
+// sensitiveFields are never written to the audit trail
+var sensitiveFields = []string{"password", "secret", "token", "recovery_codes", "provisioning_uri"}
+
+// Entry is one record of the audit trail
+type Entry struct {
+	ID           int64           `json:"id"`
+	Actor        string          `json:"actor"`
+	ActorID      string          `json:"actor_id"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resource_type"`
+	ResourceID   string          `json:"resource_id"`
+	Before       json.RawMessage `json:"before,omitempty"`
+	After        json.RawMessage `json:"after,omitempty"`
+	Changes      json.RawMessage `json:"changes,omitempty"`
+	Method       string          `json:"method"`
+	Path         string          `json:"path"`
+	IP           string          `json:"ip"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// Filter selects audit entries. Empty fields are ignored.
+type Filter struct {
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	From         *time.Time
+	To           *time.Time
+	Offset       int
+	Limit        int
+}
+
+// Store is the append-only storage of the audit trail
+type Store interface {
+	Record(ctx context.Context, entry *Entry) error
+	List(ctx context.Context, filter Filter) ([]Entry, int, error)
+}
+
+// Change is the old and new value of a changed field
+type Change struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// ActorFunc returns the username and user ID of the caller of a request, or
+// empty strings for anonymous requests
+type ActorFunc func(c *gin.Context) (string, string)
+
+// snapshotKey is the request context key of the snapshot services record
+// the changed resource in
+type snapshotKey struct{}
+
+// snapshot is the state of the changed resource before and after a request
+type snapshot struct {
+	before json.RawMessage
+	after  json.RawMessage
+}
+
+// SetResourceID names the resource a request changed, for handlers whose
+// route and response do not include its ID
+func SetResourceID(c *gin.Context, id string) {
+	c.Set(resourceIDKey, id)
+}
+
+// Before records the state of a resource before the request changes it.
+// Services call it with the record they load for an update or delete; only
+// the first call of a request is kept. It does nothing outside an audited
+// request.
+func Before(ctx context.Context, v interface{}) {
+	if s, ok := ctx.Value(snapshotKey{}).(*snapshot); ok && s.before == nil {
+		s.before = marshal(v)
+	}
+}
+
+// After records the state of a resource after the request changed it. The
+// last call of a request is kept. Without it the response body is recorded.
+func After(ctx context.Context, v interface{}) {
+	if s, ok := ctx.Value(snapshotKey{}).(*snapshot); ok {
+		s.after = marshal(v)
+	}
+}
+
+// Middleware records every successful POST, PUT, PATCH and DELETE request.
+// The state before the change is the one the service passed to Before; the
+// state after it is the one passed to After, or else the response body.
+func Middleware(store Store, actor ActorFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if !isMutating(c.Request.Method) || route == "" {
+			c.Next()
+			return
+		}
+
+		resourceType, action, idParam := describeRoute(route, c.Request.Method)
+
+		state := &snapshot{}
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), snapshotKey{}, state))
+
+		writer := &bodyWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		if writer.Status() < 200 || writer.Status() >= 300 {
+			return
+		}
+
+		after := state.after
+		if after == nil && c.Request.Method != http.MethodDelete {
+			after = responseData(writer.body.Bytes())
+		}
+
+		entry := &Entry{
+			Action:       action,
+			ResourceType: resourceType,
+			Before:       redact(state.before),
+			After:        redact(after),
+			Method:       c.Request.Method,
+			Path:         c.Request.URL.Path,
+			IP:           c.ClientIP(),
+			CreatedAt:    time.Now(),
+		}
+		entry.Changes = diff(entry.Before, entry.After)
+
+		switch {
+		case c.GetString(resourceIDKey) != "":
+			entry.ResourceID = c.GetString(resourceIDKey)
+		case idParam != "":
+			entry.ResourceID = c.Param(idParam)
+		default:
+			entry.ResourceID = idFromData(resourceType, after)
+		}
+
+		if actor != nil {
+			entry.Actor, entry.ActorID = actor(c)
+		}
+
+		if err := store.Record(context.WithoutCancel(c.Request.Context()), entry); err != nil {
+			log.Printf("Warning: failed to record audit entry for %s %s: %v", entry.Method, entry.Path, err)
+		}
+	}
+}
+
+// isMutating reports whether requests with the method change data
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// describeRoute derives the resource type, action and ID parameter from a
+// route such as /api/v1/{resource}/:id/{action}. The ID parameter is the
+// last one, so nested routes such as /admin/sessions/:username/:id name the
+// nested resource rather than its parent.
+func describeRoute(route, method string) (string, string, string) {
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(route, "/api/v1"), "/"), "/")
+
+	var idParam string
+	var rest []string
+	for _, segment := range segments[1:] {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			idParam = segment[1:]
+			continue
+		}
+		rest = append(rest, segment)
+	}
+
+	if len(rest) > 0 {
+		return segments[0], strings.Join(rest, "."), idParam
+	}
+
+	action := ActionUpdate
+	switch method {
+	case http.MethodPost:
+		action = ActionCreate
+	case http.MethodDelete:
+		action = ActionDelete
+	}
+	return segments[0], action, idParam
+}
+
+// marshal encodes a recorded resource. It returns nil for values that cannot
+// be encoded.
+func marshal(v interface{}) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil || string(data) == "null" {
+		return nil
+	}
+	return data
+}
+
+// responseData extracts the resource from a response body, unwrapping the
+// "data" envelope used by list and detail responses
+func responseData(body []byte) json.RawMessage {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil
+	}
+	if data, ok := doc["data"]; ok {
+		return data
+	}
+	if _, ok := doc["message"]; ok && len(doc) == 1 {
+		return nil
+	}
+	return json.RawMessage(body)
+}
+
+// idFromData finds the ID of a created resource in the response, looking for
+// "id" and then for the resource's own ID field, such as booking_id
+func idFromData(resourceType string, data json.RawMessage) string {
+	var doc map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return ""
+	}
+
+	name := strings.ReplaceAll(resourceType, "-", "_")
+	candidates := []string{
+		"id",
+		strings.TrimSuffix(name, "es") + "_id",
+		strings.TrimSuffix(name, "s") + "_id",
+		name + "_id",
+	}
+	for _, key := range candidates {
+		switch v := doc[key].(type) {
+		case json.Number:
+			return v.String()
+		case string:
+			return v
+		}
+	}
+	return ""
+}
+
+// redact replaces the values of sensitive fields
+func redact(data json.RawMessage) json.RawMessage {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil
+	}
+
+	redactValue(doc)
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// redactValue walks a decoded JSON value and redacts sensitive fields in place
+func redactValue(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isSensitive(key) {
+				v[key] = "[REDACTED]"
+				continue
+			}
+			redactValue(value)
+		}
+	case []interface{}:
+		for _, value := range v {
+			redactValue(value)
+		}
+	}
+}
+
+// isSensitive reports whether a field must not be recorded
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, field := range sensitiveFields {
+		if strings.Contains(key, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// diff returns the fields of after whose values differ from before
+func diff(before, after json.RawMessage) json.RawMessage {
+	if len(before) == 0 || len(after) == 0 {
+		return nil
+	}
+
+	var old, updated map[string]interface{}
+	if json.Unmarshal(before, &old) != nil || json.Unmarshal(after, &updated) != nil {
+		return nil
+	}
+
+	changes := make(map[string]Change)
+	for key, value := range updated {
+		if key == "updated_at" {
+			continue
+		}
+		if previous, ok := old[key]; !ok || !reflect.DeepEqual(previous, value) {
+			changes[key] = Change{From: old[key], To: value}
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	out, err := json.Marshal(changes)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// bodyWriter keeps a copy of the response body
+type bodyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
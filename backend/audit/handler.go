@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListHandler serves the audit trail, newest first. It accepts the actor,
+// action, resource_type, resource_id, from and to filters and the usual page
+// and pageSize parameters.
+func ListHandler(store Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter := Filter{
+			Actor:        c.Query("actor"),
+			Action:       c.Query("action"),
+			ResourceType: c.Query("resource_type"),
+			ResourceID:   c.Query("resource_id"),
+		}
+
+		var err error
+		if filter.From, err = parseTime(c.Query("from"), false); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from time, use RFC 3339 or YYYY-MM-DD"})
+			return
+		}
+		if filter.To, err = parseTime(c.Query("to"), true); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to time, use RFC 3339 or YYYY-MM-DD"})
+			return
+		}
+
+		page, pageSize := 1, 10
+		if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+			page = p
+		}
+		if ps, err := strconv.Atoi(c.Query("pageSize")); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		}
+		filter.Offset = (page - 1) * pageSize
+		filter.Limit = pageSize
+
+		entries, total, err := store.List(c.Request.Context(), filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if entries == nil {
+			entries = []Entry{}
+		}
+
+		totalPages := (total + pageSize - 1) / pageSize
+		if totalPages < 1 {
+			totalPages = 1
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data":       entries,
+			"page":       page,
+			"pageSize":   pageSize,
+			"totalItems": total,
+			"totalPages": totalPages,
+		})
+	}
+}
+
+// parseTime parses an RFC 3339 time or a date. A date is read as the start
+// of the day, or its end when endOfDay is set. Empty values return nil.
+func parseTime(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
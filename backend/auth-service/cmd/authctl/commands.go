@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+)
+
+// actor is the audit trail actor of changes made with authctl
+const actor = "authctl"
+
+func createAdmin(a *app, args []string) error {
+	flags := flag.NewFlagSet("create-admin", flag.ExitOnError)
+	email := flags.String("email", "", "email address of the admin")
+	role := flags.String("role", string(model.RoleFrontDesk), "role: owner, manager, front_desk, trainer or accountant")
+	username, err := parseUsername(flags, args)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(*email, "@") {
+		return fmt.Errorf("create-admin needs a valid -email")
+	}
+
+	password, err := readPassword()
+	if err != nil {
+		return err
+	}
+	if err := a.authService.CreateAdmin(username, password, *email, model.Role(*role)); err != nil {
+		return err
+	}
+
+	a.record(audit.ActionCreate, "create-admin", username, nil)
+	return nil
+}
+
+func deactivateAdmin(a *app, args []string) error {
+	username, err := parseUsername(flag.NewFlagSet("deactivate-admin", flag.ExitOnError), args)
+	if err != nil {
+		return err
+	}
+
+	before := a.snapshot(username)
+	if err := a.authService.DeactivateAdmin(context.Background(), actor, username); err != nil {
+		return err
+	}
+
+	a.record(audit.ActionDelete, "deactivate-admin", username, before)
+	return nil
+}
+
+func reactivateAdmin(a *app, args []string) error {
+	username, err := parseUsername(flag.NewFlagSet("reactivate-admin", flag.ExitOnError), args)
+	if err != nil {
+		return err
+	}
+
+	before := a.snapshot(username)
+	if err := a.authService.ReactivateAdmin(context.Background(), username); err != nil {
+		return err
+	}
+
+	a.record(audit.ActionUpdate, "reactivate-admin", username, before)
+	return nil
+}
+
+func resetPassword(a *app, args []string) error {
+	username, err := parseUsername(flag.NewFlagSet("reset-password", flag.ExitOnError), args)
+	if err != nil {
+		return err
+	}
+	if _, err := a.admins.FindByUsername(username); err != nil {
+		return fmt.Errorf("admin %s not found", username)
+	}
+
+	password, err := readPassword()
+	if err != nil {
+		return err
+	}
+
+	before := a.snapshot(username)
+	if err := a.authService.SetPassword(username, password); err != nil {
+		return err
+	}
+
+	a.record(audit.ActionUpdate, "reset-password", username, before)
+	return nil
+}
+
+func rotateKeys(a *app, args []string) error {
+	flags := flag.NewFlagSet("rotate-keys", flag.ExitOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	kid, _, err := a.keyManager.Rotate()
+	if err != nil {
+		return err
+	}
+
+	err = a.auditRepo.Record(context.Background(), &audit.Entry{
+		Actor:        actor,
+		Action:       "rotate",
+		ResourceType: "keys",
+		ResourceID:   kid,
+		Method:       "CLI",
+		Path:         "rotate-keys",
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to record audit entry: %v\n", err)
+	}
+	return nil
+}
+
+func listSessions(a *app, args []string) error {
+	username, err := parseUsername(flag.NewFlagSet("list-sessions", flag.ExitOnError), args)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := a.authService.ListAdminSessions(username)
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		fmt.Printf("No active sessions for user: %s\n", username)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tCREATED\tLAST SEEN\tIP\tUSER AGENT")
+	for _, session := range sessions {
+		lastSeen := "-"
+		if session.LastSeenAt != nil {
+			lastSeen = session.LastSeenAt.Format("2006-01-02T15:04:05Z")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			session.ID,
+			session.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			lastSeen,
+			session.IPAddress,
+			session.UserAgent,
+		)
+	}
+	return w.Flush()
+}
+
+// parseUsername parses the flags of a command followed by a single username
+func parseUsername(flags *flag.FlagSet, args []string) (string, error) {
+	if err := flags.Parse(args); err != nil {
+		return "", err
+	}
+	if flags.NArg() != 1 || strings.TrimSpace(flags.Arg(0)) == "" {
+		return "", fmt.Errorf("%s needs exactly one username", flags.Name())
+	}
+	return strings.TrimSpace(flags.Arg(0)), nil
+}
+
+// readPassword reads a password from the first line of stdin, prompting for
+// it when stdin is a terminal. Passwords are never taken from arguments,
+// which end up in shell history and process listings.
+func readPassword() (string, error) {
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		fmt.Fprint(os.Stderr, "Password: ")
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("error reading password: %v", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("no password given on stdin")
+	}
+	return password, nil
+}
+
+// snapshot returns the audited fields of an admin, or nil if it does not
+// exist
+func (a *app) snapshot(username string) json.RawMessage {
+	admin, err := a.admins.FindByUsername(username)
+	if err != nil {
+		return nil
+	}
+	data, _ := json.Marshal(map[string]interface{}{
+		"username":  admin.Username,
+		"email":     admin.Email,
+		"role":      admin.Role,
+		"is_active": admin.IsActive,
+	})
+	return data
+}
+
+// record adds a change to an admin to the audit trail. Changes made with
+// authctl do not pass the audit middleware of the server.
+func (a *app) record(action, command, username string, before json.RawMessage) {
+	err := a.auditRepo.Record(context.Background(), &audit.Entry{
+		Actor:        actor,
+		Action:       action,
+		ResourceType: "admin",
+		ResourceID:   username,
+		Before:       before,
+		After:        a.snapshot(username),
+		Method:       "CLI",
+		Path:         command,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to record audit entry: %v\n", err)
+	}
+}
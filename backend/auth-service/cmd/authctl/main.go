@@ -0,0 +1,142 @@
+// Command authctl manages admins, signing keys and sessions of the auth
+// service directly in its database, without a running server or an access
+// token. It reads the same environment (and .env file) as the service.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/config"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/database"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/password"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/repository"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/service"
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const usage = `Usage: authctl <command> [flags] [arguments]
+
+Commands:
+  create-admin -email EMAIL [-role ROLE] USERNAME
+                                  create an admin, the password is read from stdin
+  deactivate-admin USERNAME       deactivate an admin and revoke their sessions
+  reactivate-admin USERNAME       reactivate a deactivated admin
+  reset-password USERNAME         set a new password, read from stdin, clear the
+                                  lockout and revoke all sessions
+  rotate-keys                     generate a new token signing key
+  list-sessions USERNAME          list the active sessions of an admin
+
+Configuration is read from the environment and .env, like the auth service.
+`
+
+// app holds what the commands need
+type app struct {
+	admins      *repository.AdminRepository
+	auditRepo   *repository.AuditRepository
+	authService *service.AuthService
+	keyManager  *service.KeyManager
+}
+
+// commands maps command names to their implementation
+var commands = map[string]func(a *app, args []string) error{
+	"create-admin":     createAdmin,
+	"deactivate-admin": deactivateAdmin,
+	"reactivate-admin": reactivateAdmin,
+	"reset-password":   resetPassword,
+	"rotate-keys":      rotateKeys,
+	"list-sessions":    listSessions,
+}
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "help" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	run, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "authctl: unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	// Missing .env is fine, the environment may be set directly
+	_ = godotenv.Load()
+
+	a, err := setup(config.LoadConfig())
+	if err != nil {
+		log.Fatalf("authctl: %v", err)
+	}
+	if err := run(a, os.Args[2:]); err != nil {
+		log.Fatalf("authctl: %v", err)
+	}
+}
+
+// setup connects to the database and builds the auth service the same way
+// the server does
+func setup(cfg *config.Config) (*app, error) {
+	db, err := database.Connect(&cfg.Database)
+	if err != nil {
+		return nil, err
+	}
+	// SQL statements would drown the command output
+	db = db.Session(&gorm.Session{Logger: logger.Default.LogMode(logger.Warn)})
+
+	if err := database.Migrate(db); err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %v", err)
+	}
+
+	adminRepo := repository.NewAdminRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+
+	accessExpiry := time.Duration(cfg.JWT.AccessExpireMinutes) * time.Minute
+	keyManager, err := service.NewKeyManager(repository.NewKeyRepository(db), cfg.JWT.SigningAlgorithm, accessExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize signing keys: %v", err)
+	}
+
+	hasher, err := password.NewHasherFromConfig(&cfg.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize password hasher: %v", err)
+	}
+	policy, err := password.NewPolicy(cfg.Password.MinLength, cfg.Password.HistorySize, cfg.Password.DenylistFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize password policy: %v", err)
+	}
+
+	authService := service.NewAuthService(
+		keyManager,
+		accessExpiry,
+		time.Duration(cfg.JWT.RefreshExpireHours)*time.Hour,
+		adminRepo,
+		repository.NewTokenRepository(db),
+		repository.NewThrottleRepository(db),
+		repository.NewChallengeRepository(db),
+		repository.NewMemberAccountRepository(db),
+		repository.NewClientRepository(db),
+		repository.NewPasswordHistoryRepository(db),
+		auditRepo,
+		hasher,
+		policy,
+		service.LockoutPolicy{
+			MaxFailedAttempts: cfg.Auth.MaxFailedAttempts,
+			LockoutDuration:   time.Duration(cfg.Auth.LockoutMinutes) * time.Minute,
+			BackoffBase:       time.Duration(cfg.Auth.BackoffBaseSeconds) * time.Second,
+			BackoffMax:        time.Duration(cfg.Auth.BackoffMaxSeconds) * time.Second,
+			FailureWindow:     time.Duration(cfg.Auth.FailureWindowMinutes) * time.Minute,
+		},
+		cfg.Auth.MFAIssuer,
+	)
+
+	return &app{
+		admins:      adminRepo,
+		auditRepo:   auditRepo,
+		authService: authService,
+		keyManager:  keyManager,
+	}, nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -9,10 +10,15 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/client"
 	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/config"
 	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/database"
 	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/handler"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/mail"
 	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/middleware"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/password"
 	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/repository"
 	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/service"
 	"github.com/gin-gonic/gin"
@@ -46,12 +52,60 @@ func main() {
 
 	// Initialize repositories
 	adminRepo := repository.NewAdminRepository(db)
+	tokenRepo := repository.NewTokenRepository(db)
+	throttleRepo := repository.NewThrottleRepository(db)
+	challengeRepo := repository.NewChallengeRepository(db)
+	keyRepo := repository.NewKeyRepository(db)
+	resetRepo := repository.NewResetRepository(db)
+	memberRepo := repository.NewMemberAccountRepository(db)
+	clientRepo := repository.NewClientRepository(db)
+	historyRepo := repository.NewPasswordHistoryRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+
+	// Initialize signing keys. Rotated keys stay valid for one access token
+	// lifetime plus the key cache times so rotation does not log anyone out.
+	accessExpiry := time.Duration(cfg.JWT.AccessExpireMinutes) * time.Minute
+	keyManager, err := service.NewKeyManager(keyRepo, cfg.JWT.SigningAlgorithm, accessExpiry)
+	if err != nil {
+		log.Fatalf("Failed to initialize signing keys: %v", err)
+	}
+	if err := keyManager.EnsureSigningKey(); err != nil {
+		log.Fatalf("Failed to initialize signing keys: %v", err)
+	}
+
+	// Password hashing and policy
+	hasher, err := password.NewHasherFromConfig(&cfg.Password)
+	if err != nil {
+		log.Fatalf("Failed to initialize password hasher: %v", err)
+	}
+	policy, err := password.NewPolicy(cfg.Password.MinLength, cfg.Password.HistorySize, cfg.Password.DenylistFile)
+	if err != nil {
+		log.Fatalf("Failed to initialize password policy: %v", err)
+	}
 
 	// Initialize auth service
 	authService := service.NewAuthService(
-		cfg.JWT.Secret,
-		cfg.JWT.ExpireHours,
+		keyManager,
+		accessExpiry,
+		time.Duration(cfg.JWT.RefreshExpireHours)*time.Hour,
 		adminRepo,
+		tokenRepo,
+		throttleRepo,
+		challengeRepo,
+		memberRepo,
+		clientRepo,
+		historyRepo,
+		auditRepo,
+		hasher,
+		policy,
+		service.LockoutPolicy{
+			MaxFailedAttempts: cfg.Auth.MaxFailedAttempts,
+			LockoutDuration:   time.Duration(cfg.Auth.LockoutMinutes) * time.Minute,
+			BackoffBase:       time.Duration(cfg.Auth.BackoffBaseSeconds) * time.Second,
+			BackoffMax:        time.Duration(cfg.Auth.BackoffMaxSeconds) * time.Second,
+			FailureWindow:     time.Duration(cfg.Auth.FailureWindowMinutes) * time.Minute,
+		},
+		cfg.Auth.MFAIssuer,
 	)
 
 	// Create initial admin user from config
@@ -60,16 +114,60 @@ func main() {
 		cfg.Auth.AdminPassword,
 		"admin@fitness-center.local",
 	); err != nil {
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			log.Fatalf("Refusing to create initial admin with a weak ADMIN_PASSWORD: %v", err)
+		}
 		log.Printf("Warning: Failed to create initial admin: %v", err)
 	}
 
+	// Initialize password reset service
+	mailer, err := mail.NewMailer(&cfg.Mail)
+	if err != nil {
+		log.Fatalf("Failed to initialize mailer: %v", err)
+	}
+	passwordResetService := service.NewPasswordResetService(
+		authService,
+		adminRepo,
+		resetRepo,
+		tokenRepo,
+		mailer,
+		time.Duration(cfg.Auth.PasswordResetMinutes)*time.Minute,
+		cfg.Auth.PasswordResetURL,
+	)
+
+	// Initialize member account service. Member IDs are only checked when
+	// the member service address is configured.
+	var memberClient *client.MemberClient
+	if cfg.Members.URL != "" {
+		memberClient = client.NewMemberClient(cfg.Members.URL, time.Duration(cfg.Members.TimeoutSeconds)*time.Second)
+	} else {
+		log.Printf("Warning: MEMBER_SERVICE_URL is empty, member IDs of invitations are not checked")
+	}
+	memberAccountService := service.NewMemberAccountService(
+		authService,
+		memberRepo,
+		memberClient,
+		mailer,
+		time.Duration(cfg.Auth.MemberInviteHours)*time.Hour,
+		cfg.Auth.MemberInviteURL,
+	)
+
 	// Initialize handlers
-	authHandler := handler.NewAuthHandler(authService)
+	authHandler := handler.NewAuthHandler(authService, keyManager)
+	passwordHandler := handler.NewPasswordHandler(passwordResetService)
+	memberHandler := handler.NewMemberHandler(memberAccountService)
 
 	// Setup Gin router
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.Default()
 
+	// Client IPs, which failed logins are throttled by, are only taken from
+	// X-Forwarded-For when the request came through a trusted proxy
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	// CORS middleware
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
@@ -87,21 +185,75 @@ func main() {
 	// Health check endpoint
 	router.GET("/health", authHandler.Health)
 
+	// Public keys for verifying access tokens locally
+	router.GET("/.well-known/jwks.json", authHandler.JWKS)
+
 	// Auth endpoints
 	v1 := router.Group("/api/v1")
+	recordAudit := audit.Middleware(auditRepo, middleware.Actor)
 	{
 		v1.POST("/login", authHandler.Login)
+		v1.POST("/login/mfa", authHandler.LoginMFA)
+		v1.POST("/refresh", authHandler.Refresh)
+		v1.POST("/token", authHandler.ClientToken) // Client credentials grant for service clients
+		v1.POST("/password/forgot", passwordHandler.ForgotPassword)
+		v1.POST("/password/reset", recordAudit, passwordHandler.ResetPassword)
+		v1.POST("/member/register", recordAudit, memberHandler.Register)
+		v1.POST("/member/login", memberHandler.Login)
 		v1.GET("/auth", authHandler.ForwardAuth) // Traefik ForwardAuth endpoint
 
+		// Logout and session endpoints manage the caller's own tokens
+		logout := v1.Group("")
+		logout.Use(middleware.AuthMiddleware(authService))
+		{
+			logout.POST("/logout", authHandler.Logout)
+			logout.POST("/logout-all", authHandler.LogoutAll)
+
+			// Sessions of the caller
+			logout.GET("/sessions", authHandler.ListSessions)
+			logout.DELETE("/sessions/:id", authHandler.RevokeSession)
+		}
+
 		// Protected admin management endpoints
 		admin := v1.Group("/admin")
-		admin.Use(middleware.AuthMiddleware(authService))
+		admin.Use(middleware.AuthMiddleware(authService), middleware.RequireAdmin(), recordAudit)
 		{
-			admin.POST("/create", authHandler.CreateAdmin)
+			admin.POST("/create", middleware.RequirePermission(model.PermAdminsManage), authHandler.CreateAdmin)
 			admin.PUT("/password", authHandler.UpdateAdminPassword)
-			admin.GET("/list", authHandler.ListAdmins)
-			admin.DELETE("/:username", authHandler.DeleteAdmin)
+			admin.GET("/profile", authHandler.GetProfile)
+			admin.PUT("/profile", authHandler.UpdateProfile)
+			admin.GET("/list", middleware.RequirePermission(model.PermAdminsView), authHandler.ListAdmins)
+			admin.GET("/:username", middleware.RequirePermission(model.PermAdminsView), authHandler.GetAdmin)
+			admin.GET("/sessions/:username", middleware.RequirePermission(model.PermSessionsManage), authHandler.ListAdminSessions)
+			admin.DELETE("/sessions/:username/:id", middleware.RequirePermission(model.PermSessionsManage), authHandler.RevokeAdminSession)
+			admin.POST("/keys/rotate", middleware.RequirePermission(model.PermAdminsManage), authHandler.RotateKeys)
+			admin.POST("/members/invite", middleware.RequirePermission(model.PermMembersWrite), memberHandler.InviteMember)
+
+			// Service clients for service-to-service calls
+			admin.GET("/clients", middleware.RequirePermission(model.PermAdminsView), authHandler.ListClients)
+			admin.POST("/clients", middleware.RequirePermission(model.PermAdminsManage), authHandler.CreateClient)
+			admin.GET("/clients/:name", middleware.RequirePermission(model.PermAdminsView), authHandler.GetClient)
+			admin.POST("/clients/:name/rotate", middleware.RequirePermission(model.PermAdminsManage), authHandler.RotateClientSecret)
+			admin.DELETE("/clients/:name", middleware.RequirePermission(model.PermAdminsManage), authHandler.DeactivateClient)
+
+			admin.DELETE("/:username", middleware.RequirePermission(model.PermAdminsManage), authHandler.DeleteAdmin)
+			admin.PUT("/:username", middleware.RequirePermission(model.PermAdminsManage), authHandler.UpdateAdmin)
+			admin.POST("/:username/unlock", middleware.RequirePermission(model.PermAdminsManage), authHandler.UnlockAdmin)
+			admin.POST("/:username/reactivate", middleware.RequirePermission(model.PermAdminsManage), authHandler.ReactivateAdmin)
+
+			// Two-factor authentication for the current admin
+			admin.POST("/mfa/enroll", authHandler.EnrollMFA)
+			admin.POST("/mfa/activate", authHandler.ActivateMFA)
+			admin.POST("/mfa/disable", authHandler.DisableMFA)
 		}
+
+		// Audit log of changes made through the auth service
+		v1.GET("/audit/auth",
+			middleware.AuthMiddleware(authService),
+			middleware.RequireAdmin(),
+			middleware.RequirePermission(model.PermAuditRead),
+			audit.ListHandler(auditRepo),
+		)
 	}
 
 	// Start HTTP server
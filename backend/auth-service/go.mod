@@ -5,6 +5,7 @@ go 1.23
 toolchain go1.24.3
 
 require (
+	github.com/FurkanArikk/fitness-center/backend/audit v0.0.0
 	github.com/gin-gonic/gin v1.9.1
 	github.com/golang-jwt/jwt/v5 v5.2.0
 	github.com/joho/godotenv v1.5.1
@@ -47,3 +48,5 @@ require (
 	google.golang.org/protobuf v1.30.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/FurkanArikk/fitness-center/backend/audit => ../audit
@@ -0,0 +1,78 @@
+// Package client provides an HTTP client for the member service, whose
+// members are linked to member accounts by ID. It calls the member service
+// at its own address, as auth-service has no token for the gateway.
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MemberActive is the status of members who may use the fitness center
+const MemberActive = "active"
+
+// ErrNotFound is returned when the member service has no member with the
+// requested ID
+var ErrNotFound = errors.New("not found")
+
+// ErrUnavailable is returned, wrapped with the cause, when the member service
+// cannot be reached or does not answer as expected
+var ErrUnavailable = errors.New("service unavailable")
+
+// Member is a member as returned by the member service
+type Member struct {
+	ID     uint   `json:"id"`
+	Email  string `json:"email"`
+	Status string `json:"status"`
+}
+
+// MemberClient reads members from the member service
+type MemberClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewMemberClient creates a client for the member service at baseURL
+func NewMemberClient(baseURL string, timeout time.Duration) *MemberClient {
+	return &MemberClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// GetMember returns a member by its ID
+func (c *MemberClient) GetMember(id uint) (Member, error) {
+	path := fmt.Sprintf("/api/v1/members/%d", id)
+
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return Member{}, fmt.Errorf("%w: GET %s: %v", ErrUnavailable, path, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return Member{}, fmt.Errorf("%w: GET %s: %v", ErrUnavailable, path, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		io.Copy(io.Discard, resp.Body)
+		return Member{}, ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		io.Copy(io.Discard, resp.Body)
+		return Member{}, fmt.Errorf("%w: GET %s: unexpected status %d", ErrUnavailable, path, resp.StatusCode)
+	}
+
+	var member Member
+	if err := json.NewDecoder(resp.Body).Decode(&member); err != nil {
+		return Member{}, fmt.Errorf("%w: GET %s: invalid response: %v", ErrUnavailable, path, err)
+	}
+	return member, nil
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 // Config holds all configuration for the application
@@ -11,24 +12,78 @@ type Config struct {
 	JWT      JWTConfig
 	Auth     AuthConfig
 	Database DatabaseConfig
+	Mail     MailConfig
+	Password PasswordConfig
+	Members  MemberServiceConfig
 }
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
 	Host string
 	Port int
+
+	// TrustedProxies are the addresses or CIDRs of the proxies, such as the
+	// gateway, whose X-Forwarded-For header gives the client IP. Empty uses
+	// the address of the connection.
+	TrustedProxies []string
 }
 
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	Secret      string
-	ExpireHours int
+	SigningAlgorithm    string
+	AccessExpireMinutes int
+	RefreshExpireHours  int
 }
 
 // AuthConfig holds authentication configuration
 type AuthConfig struct {
 	AdminUsername string
 	AdminPassword string
+
+	MaxFailedAttempts    int
+	LockoutMinutes       int
+	BackoffBaseSeconds   int
+	BackoffMaxSeconds    int
+	FailureWindowMinutes int
+
+	MFAIssuer string
+
+	PasswordResetMinutes int
+	PasswordResetURL     string
+
+	MemberInviteHours int
+	MemberInviteURL   string
+}
+
+// PasswordConfig holds password hashing and policy configuration
+type PasswordConfig struct {
+	Hasher            string // "argon2id" or "bcrypt"
+	BcryptCost        int
+	Argon2MemoryKB    int
+	Argon2Iterations  int
+	Argon2Parallelism int
+
+	MinLength    int
+	DenylistFile string // optional file of common or breached passwords
+	HistorySize  int    // number of previous passwords that cannot be reused
+}
+
+// MemberServiceConfig holds the address of the member service, which member
+// IDs are checked against before members are invited
+type MemberServiceConfig struct {
+	URL            string // empty turns the check off
+	TimeoutSeconds int
+}
+
+// MailConfig holds outgoing email configuration
+type MailConfig struct {
+	Driver       string // "smtp" or "log"
+	From         string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	LogFile      string // used by the log driver; empty writes to the service log
 }
 
 // DatabaseConfig holds database configuration
@@ -47,14 +102,31 @@ func LoadConfig() *Config {
 		Server: ServerConfig{
 			Host: getEnv("SERVER_HOST", "0.0.0.0"),
 			Port: getEnvAsInt("SERVER_PORT", 8085),
+
+			TrustedProxies: getEnvAsList("TRUSTED_PROXIES"),
 		},
 		JWT: JWTConfig{
-			Secret:      getEnv("JWT_SECRET", "your-super-secret-jwt-key-change-this-in-production"),
-			ExpireHours: getEnvAsInt("JWT_EXPIRE_HOURS", 24),
+			SigningAlgorithm:    getEnv("JWT_SIGNING_ALGORITHM", "RS256"),
+			AccessExpireMinutes: getEnvAsInt("JWT_ACCESS_EXPIRE_MINUTES", 15),
+			RefreshExpireHours:  getEnvAsInt("JWT_REFRESH_EXPIRE_HOURS", 168),
 		},
 		Auth: AuthConfig{
 			AdminUsername: getEnv("ADMIN_USERNAME", "admin"),
-			AdminPassword: getEnv("ADMIN_PASSWORD", "admin"),
+			AdminPassword: getEnv("ADMIN_PASSWORD", ""),
+
+			MaxFailedAttempts:    getEnvAsInt("LOGIN_MAX_FAILED_ATTEMPTS", 5),
+			LockoutMinutes:       getEnvAsInt("LOGIN_LOCKOUT_MINUTES", 15),
+			BackoffBaseSeconds:   getEnvAsInt("LOGIN_BACKOFF_BASE_SECONDS", 1),
+			BackoffMaxSeconds:    getEnvAsInt("LOGIN_BACKOFF_MAX_SECONDS", 300),
+			FailureWindowMinutes: getEnvAsInt("LOGIN_FAILURE_WINDOW_MINUTES", 15),
+
+			MFAIssuer: getEnv("MFA_ISSUER", "Fitness Center"),
+
+			PasswordResetMinutes: getEnvAsInt("PASSWORD_RESET_EXPIRE_MINUTES", 30),
+			PasswordResetURL:     getEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+
+			MemberInviteHours: getEnvAsInt("MEMBER_INVITE_EXPIRE_HOURS", 72),
+			MemberInviteURL:   getEnv("MEMBER_INVITE_URL", "http://localhost:3000/member/register"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -64,6 +136,30 @@ func LoadConfig() *Config {
 			DBName:   getEnv("DB_NAME", "fitness_auth"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
 		},
+		Mail: MailConfig{
+			Driver:       getEnv("MAIL_DRIVER", "log"),
+			From:         getEnv("MAIL_FROM", "no-reply@fitness-center.local"),
+			SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+			SMTPPort:     getEnvAsInt("SMTP_PORT", 587),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			LogFile:      getEnv("MAIL_LOG_FILE", ""),
+		},
+		Password: PasswordConfig{
+			Hasher:            getEnv("PASSWORD_HASHER", "argon2id"),
+			BcryptCost:        getEnvAsInt("PASSWORD_BCRYPT_COST", 14),
+			Argon2MemoryKB:    getEnvAsInt("PASSWORD_ARGON2_MEMORY_KB", 65536),
+			Argon2Iterations:  getEnvAsInt("PASSWORD_ARGON2_ITERATIONS", 3),
+			Argon2Parallelism: getEnvAsInt("PASSWORD_ARGON2_PARALLELISM", 2),
+
+			MinLength:    getEnvAsInt("PASSWORD_MIN_LENGTH", 12),
+			DenylistFile: getEnv("PASSWORD_DENYLIST_FILE", ""),
+			HistorySize:  getEnvAsInt("PASSWORD_HISTORY_SIZE", 5),
+		},
+		Members: MemberServiceConfig{
+			URL:            getEnvAllowEmpty("MEMBER_SERVICE_URL", "http://localhost:8001"),
+			TimeoutSeconds: getEnvAsInt("MEMBER_SERVICE_TIMEOUT_SECONDS", 5),
+		},
 	}
 }
 
@@ -75,6 +171,16 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvAllowEmpty gets environment variable with fallback to default value
+// when it is not set. Unlike getEnv, a variable set to an empty value is
+// returned as is.
+func getEnvAllowEmpty(key, defaultValue string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return defaultValue
+}
+
 // getEnvAsInt gets environment variable as integer with fallback to default value
 func getEnvAsInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
@@ -84,3 +190,15 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvAsList gets a comma separated environment variable as a list,
+// skipping empty items
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -55,11 +55,26 @@ func (d *Database) AutoMigrate() error {
 
 	err := d.DB.AutoMigrate(
 		&model.Admin{},
+		&model.TokenFamily{},
+		&model.RefreshToken{},
+		&model.LoginThrottle{},
+		&model.MFAChallenge{},
+		&model.SigningKey{},
+		&model.PasswordResetToken{},
+		&model.MemberAccount{},
+		&model.MemberInvite{},
+		&model.AuditLog{},
+		&model.ServiceClient{},
+		&model.PasswordHistory{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	if err := protectAuditLog(d.DB); err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+
 	log.Println("Database migrations completed successfully")
 	return nil
 }
@@ -70,15 +85,59 @@ func Migrate(db *gorm.DB) error {
 
 	err := db.AutoMigrate(
 		&model.Admin{},
+		&model.TokenFamily{},
+		&model.RefreshToken{},
+		&model.LoginThrottle{},
+		&model.MFAChallenge{},
+		&model.SigningKey{},
+		&model.PasswordResetToken{},
+		&model.MemberAccount{},
+		&model.MemberInvite{},
+		&model.AuditLog{},
+		&model.ServiceClient{},
+		&model.PasswordHistory{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	if err := protectAuditLog(db); err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+
 	log.Println("Database migrations completed successfully")
 	return nil
 }
 
+// auditLogTriggers make the audit log append-only: rows can be inserted but
+// never changed or removed
+var auditLogTriggers = []string{
+	`CREATE OR REPLACE FUNCTION prevent_audit_log_change()
+	RETURNS TRIGGER AS $$
+	BEGIN
+		RAISE EXCEPTION 'audit_log is append-only';
+	END;
+	$$ LANGUAGE plpgsql`,
+	`DROP TRIGGER IF EXISTS trg_audit_log_append_only ON audit_log`,
+	`CREATE TRIGGER trg_audit_log_append_only
+	BEFORE UPDATE OR DELETE ON audit_log
+	FOR EACH ROW EXECUTE FUNCTION prevent_audit_log_change()`,
+	`DROP TRIGGER IF EXISTS trg_audit_log_no_truncate ON audit_log`,
+	`CREATE TRIGGER trg_audit_log_no_truncate
+	BEFORE TRUNCATE ON audit_log
+	FOR EACH STATEMENT EXECUTE FUNCTION prevent_audit_log_change()`,
+}
+
+// protectAuditLog installs the audit log triggers
+func protectAuditLog(db *gorm.DB) error {
+	for _, statement := range auditLogTriggers {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Close closes the database connection
 func (d *Database) Close() error {
 	sqlDB, err := d.DB.DB()
@@ -1,9 +1,16 @@
 package handler
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/password"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/repository"
 	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/service"
 	"github.com/FurkanArikk/fitness-center/backend/auth-service/pkg/dto"
 	"github.com/gin-gonic/gin"
@@ -12,12 +19,14 @@ import (
 // AuthHandler handles authentication requests
 type AuthHandler struct {
 	authService *service.AuthService
+	keyManager  *service.KeyManager
 }
 
 // NewAuthHandler creates a new auth handler
-func NewAuthHandler(authService *service.AuthService) *AuthHandler {
+func NewAuthHandler(authService *service.AuthService, keyManager *service.KeyManager) *AuthHandler {
 	return &AuthHandler{
 		authService: authService,
+		keyManager:  keyManager,
 	}
 }
 
@@ -31,7 +40,89 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, err := h.authService.Login(req.Username, req.Password)
+	result, err := h.authService.Login(req.Username, req.Password, clientInfo(c))
+	if err != nil {
+		if throttleError(c, err) {
+			return
+		}
+
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if result.Tokens == nil {
+		c.JSON(http.StatusOK, dto.MFAChallengeResponse{
+			MFARequired:    true,
+			ChallengeToken: result.ChallengeToken,
+			ExpiresIn:      result.ChallengeExpiresIn,
+			Message:        "Two-factor authentication code required",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, newLoginResponse(result.Tokens, "Successfully logged in"))
+}
+
+// LoginMFA handles the second login step for admins with two-factor
+// authentication enabled
+func (h *AuthHandler) LoginMFA(c *gin.Context) {
+	var req dto.MFALoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Challenge token and code are required",
+		})
+		return
+	}
+
+	tokens, err := h.authService.VerifyMFALogin(req.ChallengeToken, req.Code, clientInfo(c))
+	if err != nil {
+		if throttleError(c, err) {
+			return
+		}
+
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, newLoginResponse(tokens, "Successfully logged in"))
+}
+
+// throttleError writes the response for a login refused because of failed
+// attempts: 429 with Retry-After while in backoff, 403 while the account is
+// locked. It reports whether err was such an error.
+func throttleError(c *gin.Context, err error) bool {
+	var tooMany *service.TooManyAttemptsError
+	if errors.As(err, &tooMany) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(tooMany.RetryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return true
+	}
+	if errors.Is(err, service.ErrAccountLocked) {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return true
+	}
+	return false
+}
+
+// Refresh handles exchanging a refresh token for a new token pair
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Refresh token is required",
+		})
+		return
+	}
+
+	tokens, err := h.authService.Refresh(req.RefreshToken, clientInfo(c))
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
 			Error: err.Error(),
@@ -39,12 +130,60 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.LoginResponse{
-		Token:   token,
-		Message: "Successfully logged in",
+	c.JSON(http.StatusOK, newLoginResponse(tokens, "Token refreshed successfully"))
+}
+
+// Logout handles revoking the caller's current token family
+func (h *AuthHandler) Logout(c *gin.Context) {
+	claims := c.MustGet("claims").(*service.Claims)
+
+	if err := h.authService.Logout(claims); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrServiceTokenLogout) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Successfully logged out",
+	})
+}
+
+// LogoutAll handles revoking every token family of the caller
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	claims := c.MustGet("claims").(*service.Claims)
+
+	if err := h.authService.LogoutAll(claims); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrServiceTokenLogout) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Successfully logged out of all sessions",
 	})
 }
 
+// newLoginResponse converts a token pair to the login response format
+func newLoginResponse(tokens *service.TokenPair, message string) dto.LoginResponse {
+	return dto.LoginResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+		TokenType:    "Bearer",
+		Message:      message,
+	}
+}
+
 // ForwardAuth handles Traefik ForwardAuth middleware endpoint
 func (h *AuthHandler) ForwardAuth(c *gin.Context) {
 	// Get token from Authorization header
@@ -80,8 +219,43 @@ func (h *AuthHandler) ForwardAuth(c *gin.Context) {
 		return
 	}
 
-	// Successful validation - Add required headers for Traefik
+	// Check the caller's roles against the route being accessed
+	method := c.GetHeader("X-Forwarded-Method")
+	uri := c.GetHeader("X-Forwarded-Uri")
+	if claims.IsMember() {
+		if !service.MemberCanAccess(method, uri, claims.MemberID) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error: "Insufficient permissions",
+			})
+			return
+		}
+	} else if claims.IsService() {
+		// Service clients only reach routes covered by one of their scopes
+		perm, ok := service.RequiredPermission(method, uri)
+		if !ok || !claims.HasPermission(perm) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error: "Insufficient permissions",
+			})
+			return
+		}
+	} else if perm, ok := service.RequiredPermission(method, uri); ok {
+		if !claims.HasPermission(perm) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error: "Insufficient permissions",
+			})
+			return
+		}
+	}
+
+	// Successful validation - Add identity headers for Traefik to forward
+	// to the downstream service
 	c.Header("X-Forwarded-User", claims.Username)
+	c.Header("X-User-ID", claims.Subject)
+	c.Header("X-User-Roles", strings.Join(claims.Roles, ","))
+	c.Header("X-Token-ID", claims.ID)
+	if claims.IsMember() {
+		c.Header("X-Member-ID", strconv.FormatUint(uint64(claims.MemberID), 10))
+	}
 	c.Status(http.StatusOK)
 }
 
@@ -111,10 +285,24 @@ func (h *AuthHandler) CreateAdmin(c *gin.Context) {
 		return
 	}
 
-	err := h.authService.CreateInitialAdmin(req.Username, req.Password, req.Email)
+	// New admins get the least privileged role unless one is requested
+	role := model.RoleFrontDesk
+	if req.Role != "" {
+		role = model.Role(req.Role)
+	}
+
+	err := h.authService.CreateAdmin(req.Username, req.Password, req.Email, role)
 	if err != nil {
 		errorMsg := err.Error()
 
+		var policyErr *password.PolicyError
+		if errors.Is(err, service.ErrInvalidRole) || errors.As(err, &policyErr) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: errorMsg,
+			})
+			return
+		}
+
 		// Check if it's a duplicate key error (user-friendly message)
 		if errorMsg == "username already exists" || errorMsg == "email address already exists" ||
 			errorMsg == "this information already exists in the system" {
@@ -131,6 +319,7 @@ func (h *AuthHandler) CreateAdmin(c *gin.Context) {
 		return
 	}
 
+	audit.SetResourceID(c, req.Username)
 	c.JSON(http.StatusCreated, dto.SuccessResponse{
 		Message: "Admin user created successfully",
 	})
@@ -156,20 +345,69 @@ func (h *AuthHandler) UpdateAdminPassword(c *gin.Context) {
 
 	err := h.authService.UpdatePassword(req.Username, req.CurrentPassword, req.NewPassword)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+		status := http.StatusUnauthorized
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, dto.ErrorResponse{
 			Error: err.Error(),
 		})
 		return
 	}
 
+	audit.SetResourceID(c, req.Username)
 	c.JSON(http.StatusOK, dto.SuccessResponse{
 		Message: "Password updated successfully",
 	})
 }
 
-// ListAdmins handles listing all admin users
+// GetAdmin handles getting a single admin user
+func (h *AuthHandler) GetAdmin(c *gin.Context) {
+	admin, err := h.authService.GetAdmin(c.Param("username"))
+	if err != nil {
+		h.adminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newAdminResponse(admin))
+}
+
+// ListAdmins handles listing admin users. Supports filtering by status
+// (active, inactive or all), role, a username/email search and inactive_days
+// (no login for that many days), with pagination.
 func (h *AuthHandler) ListAdmins(c *gin.Context) {
-	admins, err := h.authService.ListAdmins()
+	filter := repository.AdminFilter{
+		Status: c.DefaultQuery("status", "active"),
+		Role:   c.Query("role"),
+		Search: c.Query("search"),
+	}
+	if filter.Status != "active" && filter.Status != "inactive" && filter.Status != "all" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Status must be active, inactive or all",
+		})
+		return
+	}
+	if value := c.Query("inactive_days"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 1 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "inactive_days must be a positive number",
+			})
+			return
+		}
+		filter.InactiveDays = days
+	}
+
+	page, pageSize := 1, 10
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if ps, err := strconv.Atoi(c.Query("pageSize")); err == nil && ps > 0 && ps <= 100 {
+		pageSize = ps
+	}
+
+	admins, total, err := h.authService.ListAdmins(filter, page, pageSize)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: err.Error(),
@@ -178,28 +416,96 @@ func (h *AuthHandler) ListAdmins(c *gin.Context) {
 	}
 
 	// Convert to response format
-	var adminResponses []dto.AdminResponse
+	adminResponses := make([]dto.AdminResponse, 0, len(admins))
 	for _, admin := range admins {
-		adminResponse := dto.AdminResponse{
-			ID:        admin.ID,
-			Username:  admin.Username,
-			Email:     admin.Email,
-			IsActive:  admin.IsActive,
-			CreatedAt: admin.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		}
-		if admin.LastLoginAt != nil {
-			adminResponse.LastLoginAt = admin.LastLoginAt.Format("2006-01-02T15:04:05Z")
-		}
-		adminResponses = append(adminResponses, adminResponse)
+		adminResponses = append(adminResponses, newAdminResponse(&admin))
+	}
+
+	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
+	if totalPages < 1 {
+		totalPages = 1
 	}
 
 	c.JSON(http.StatusOK, dto.ListAdminsResponse{
-		Admins: adminResponses,
+		Admins:     adminResponses,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
 	})
 }
 
-// DeleteAdmin handles deactivating an admin user
-func (h *AuthHandler) DeleteAdmin(c *gin.Context) {
+// UpdateAdmin handles changing the email and role of an admin user
+func (h *AuthHandler) UpdateAdmin(c *gin.Context) {
+	var req dto.UpdateAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil || (req.Email == nil && req.Role == nil) {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Email or role is required",
+		})
+		return
+	}
+
+	var role *model.Role
+	if req.Role != nil {
+		r := model.Role(*req.Role)
+		role = &r
+	}
+
+	admin, err := h.authService.UpdateAdmin(c.Request.Context(), c.Param("username"), req.Email, role)
+	if err != nil {
+		h.adminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newAdminResponse(admin))
+}
+
+// GetProfile handles getting the current admin's own account
+func (h *AuthHandler) GetProfile(c *gin.Context) {
+	admin, err := h.authService.GetAdmin(c.GetString("username"))
+	if err != nil {
+		h.adminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newAdminResponse(admin))
+}
+
+// UpdateProfile handles the current admin changing their own email
+func (h *AuthHandler) UpdateProfile(c *gin.Context) {
+	var req dto.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Email is required",
+		})
+		return
+	}
+
+	username := c.GetString("username")
+	admin, err := h.authService.UpdateAdmin(c.Request.Context(), username, &req.Email, nil)
+	if err != nil {
+		h.adminError(c, err)
+		return
+	}
+
+	audit.SetResourceID(c, username)
+	c.JSON(http.StatusOK, newAdminResponse(admin))
+}
+
+// ReactivateAdmin handles restoring a deactivated admin user
+func (h *AuthHandler) ReactivateAdmin(c *gin.Context) {
+	if err := h.authService.ReactivateAdmin(c.Request.Context(), c.Param("username")); err != nil {
+		h.adminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Admin user reactivated successfully",
+	})
+}
+
+// UnlockAdmin handles clearing the lockout of an admin user
+func (h *AuthHandler) UnlockAdmin(c *gin.Context) {
 	username := c.Param("username")
 	if username == "" {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
@@ -208,7 +514,7 @@ func (h *AuthHandler) DeleteAdmin(c *gin.Context) {
 		return
 	}
 
-	err := h.authService.DeactivateAdmin(username)
+	err := h.authService.UnlockAdmin(c.Request.Context(), username)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error: err.Error(),
@@ -216,7 +522,73 @@ func (h *AuthHandler) DeleteAdmin(c *gin.Context) {
 		return
 	}
 
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Admin user unlocked successfully",
+	})
+}
+
+// DeleteAdmin handles deactivating an admin user
+func (h *AuthHandler) DeleteAdmin(c *gin.Context) {
+	username := c.Param("username")
+	if username == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Username is required",
+		})
+		return
+	}
+
+	err := h.authService.DeactivateAdmin(c.Request.Context(), c.GetString("username"), username)
+	if err != nil {
+		h.adminError(c, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, dto.SuccessResponse{
 		Message: "Admin user deactivated successfully",
 	})
 }
+
+// adminError writes the response for an admin management error
+func (h *AuthHandler) adminError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrAdminNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidRole), errors.Is(err, service.ErrInvalidEmail),
+		errors.Is(err, service.ErrCannotDeactivateSelf):
+		status = http.StatusBadRequest
+	case errors.Is(err, service.ErrLastOwner), errors.Is(err, service.ErrAdminActive):
+		status = http.StatusConflict
+	case err.Error() == "email address already exists":
+		status = http.StatusConflict
+	}
+	c.JSON(status, dto.ErrorResponse{
+		Error: err.Error(),
+	})
+}
+
+// newAdminResponse converts an admin to its response format
+func newAdminResponse(admin *model.Admin) dto.AdminResponse {
+	adminResponse := dto.AdminResponse{
+		ID:         admin.ID,
+		Username:   admin.Username,
+		Email:      admin.Email,
+		Role:       string(admin.Role),
+		IsActive:   admin.IsActive,
+		MFAEnabled: admin.MFAEnabled,
+		CreatedAt:  admin.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if admin.LastLoginAt != nil {
+		adminResponse.LastLoginAt = admin.LastLoginAt.Format("2006-01-02T15:04:05Z")
+	}
+	adminResponse.FailedLoginAttempts = admin.FailedLoginAttempts
+	adminResponse.LockCount = admin.LockCount
+	adminResponse.IsLocked = admin.IsLocked()
+	if admin.LockedUntil != nil {
+		adminResponse.LockedUntil = admin.LockedUntil.Format("2006-01-02T15:04:05Z")
+	}
+	if admin.LastLockedAt != nil {
+		adminResponse.LastLockedAt = admin.LastLockedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return adminResponse
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/service"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/pkg/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateClient handles registering a new service client
+func (h *AuthHandler) CreateClient(c *gin.Context) {
+	var req dto.CreateClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Name and scopes are required",
+		})
+		return
+	}
+
+	client, secret, err := h.authService.CreateClient(req.Name, req.Description, req.Scopes, c.GetString("username"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrInvalidClientName), errors.Is(err, service.ErrInvalidScope):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrClientExists):
+			status = http.StatusConflict
+		}
+		c.JSON(status, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	audit.SetResourceID(c, client.Name)
+	c.JSON(http.StatusCreated, dto.ClientSecretResponse{
+		ClientID:     client.Name,
+		ClientSecret: secret,
+		Message:      "Service client created successfully, store the secret now as it is not shown again",
+	})
+}
+
+// ListClients handles listing all service clients
+func (h *AuthHandler) ListClients(c *gin.Context) {
+	clients, err := h.authService.ListClients()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	clientResponses := make([]dto.ClientResponse, 0, len(clients))
+	for _, client := range clients {
+		clientResponses = append(clientResponses, newClientResponse(&client))
+	}
+
+	c.JSON(http.StatusOK, dto.ListClientsResponse{
+		Clients: clientResponses,
+	})
+}
+
+// GetClient handles getting a single service client
+func (h *AuthHandler) GetClient(c *gin.Context) {
+	client, err := h.authService.GetClient(c.Param("name"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, newClientResponse(client))
+}
+
+// RotateClientSecret handles replacing the secret of a service client
+func (h *AuthHandler) RotateClientSecret(c *gin.Context) {
+	name := c.Param("name")
+	secret, err := h.authService.RotateClientSecret(c.Request.Context(), name)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrClientNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ClientSecretResponse{
+		ClientID:     name,
+		ClientSecret: secret,
+		Message:      "Client secret rotated successfully, store the secret now as it is not shown again",
+	})
+}
+
+// DeactivateClient handles disabling a service client
+func (h *AuthHandler) DeactivateClient(c *gin.Context) {
+	if err := h.authService.DeactivateClient(c.Request.Context(), c.Param("name")); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrClientNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Service client deactivated successfully",
+	})
+}
+
+// ClientToken handles the client credentials grant. The request may be JSON
+// or form encoded, with the credentials in the body or in a Basic
+// Authorization header.
+func (h *AuthHandler) ClientToken(c *gin.Context) {
+	var req dto.ClientTokenRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "grant_type is required",
+		})
+		return
+	}
+	if req.GrantType != "client_credentials" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Unsupported grant type",
+		})
+		return
+	}
+	if id, secret, ok := c.Request.BasicAuth(); ok {
+		req.ClientID, req.ClientSecret = id, secret
+	}
+
+	token, err := h.authService.IssueClientToken(req.ClientID, req.ClientSecret, req.Scope)
+	if err != nil {
+		var tooMany *service.TooManyAttemptsError
+		switch {
+		case errors.As(err, &tooMany):
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(tooMany.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, dto.ErrorResponse{
+				Error: err.Error(),
+			})
+		case errors.Is(err, service.ErrInvalidScope):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: err.Error(),
+			})
+		case errors.Is(err, service.ErrInvalidClient):
+			c.Header("WWW-Authenticate", `Basic realm="fitness-center"`)
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+				Error: err.Error(),
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, dto.ClientTokenResponse{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   token.ExpiresIn,
+		Scope:       token.Scope,
+	})
+}
+
+// newClientResponse converts a service client to its response format
+func newClientResponse(client *model.ServiceClient) dto.ClientResponse {
+	clientResponse := dto.ClientResponse{
+		Name:        client.Name,
+		Description: client.Description,
+		Scopes:      client.ScopeList(),
+		IsActive:    client.IsActive,
+		CreatedBy:   client.CreatedBy,
+		CreatedAt:   client.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if client.LastUsedAt != nil {
+		clientResponse.LastUsedAt = client.LastUsedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return clientResponse
+}
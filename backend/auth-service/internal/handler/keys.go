@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/service"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/pkg/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// JWKS handles publishing the token verification keys
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	keys, err := h.keyManager.JWKS()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(service.JWKSMaxAge.Seconds())))
+	c.JSON(http.StatusOK, gin.H{
+		"keys": keys,
+	})
+}
+
+// RotateKeys handles generating a new signing key, which is published right
+// away and signs new tokens once every cached JWKS includes it
+func (h *AuthHandler) RotateKeys(c *gin.Context) {
+	kid, activatesAt, err := h.keyManager.Rotate()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	audit.SetResourceID(c, kid)
+	c.JSON(http.StatusOK, dto.RotateKeysResponse{
+		KID:         kid,
+		ActivatesAt: activatesAt,
+		Message:     "Signing key published, it signs new tokens from activates_at",
+	})
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/password"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/service"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/pkg/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// MemberHandler handles member account requests
+type MemberHandler struct {
+	memberService *service.MemberAccountService
+}
+
+// NewMemberHandler creates a new member handler
+func NewMemberHandler(memberService *service.MemberAccountService) *MemberHandler {
+	return &MemberHandler{
+		memberService: memberService,
+	}
+}
+
+// InviteMember handles sending an account invitation to a member
+func (h *MemberHandler) InviteMember(c *gin.Context) {
+	var req dto.InviteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Member ID and email are required",
+		})
+		return
+	}
+
+	err := h.memberService.InviteMember(req.MemberID, req.Email, c.GetString("username"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrInvalidMemberRequest), errors.Is(err, service.ErrMemberNotActive):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrMemberNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrMemberAccountExists):
+			status = http.StatusConflict
+		case errors.Is(err, service.ErrMemberServiceDown):
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	audit.SetResourceID(c, strconv.FormatUint(uint64(req.MemberID), 10))
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: "Member invitation sent successfully",
+	})
+}
+
+// Register handles creating a member account from an invitation
+func (h *MemberHandler) Register(c *gin.Context) {
+	var req dto.MemberRegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Token and password are required",
+		})
+		return
+	}
+
+	account, err := h.memberService.Register(req.Token, req.Password)
+	if err != nil {
+		status := http.StatusInternalServerError
+		var policyErr *password.PolicyError
+		switch {
+		case errors.Is(err, service.ErrInvalidInvite), errors.As(err, &policyErr):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrMemberAccountExists):
+			status = http.StatusConflict
+		}
+		c.JSON(status, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.MemberAccountResponse{
+		ID:       account.ID,
+		MemberID: account.MemberID,
+		Email:    account.Email,
+	})
+}
+
+// Login handles member login
+func (h *MemberHandler) Login(c *gin.Context) {
+	var req dto.MemberLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Invalid request format",
+		})
+		return
+	}
+
+	tokens, err := h.memberService.Login(req.Email, req.Password, clientInfo(c))
+	if err != nil {
+		var tooMany *service.TooManyAttemptsError
+		if errors.As(err, &tooMany) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(tooMany.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, dto.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, newLoginResponse(tokens, "Successfully logged in"))
+}
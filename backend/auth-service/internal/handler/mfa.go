@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/service"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/pkg/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// EnrollMFA handles starting two-factor enrolment for the current admin
+func (h *AuthHandler) EnrollMFA(c *gin.Context) {
+	enrolment, err := h.authService.EnrollMFA(c.GetString("username"))
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	audit.SetResourceID(c, c.GetString("username"))
+	c.JSON(http.StatusOK, dto.MFAEnrollResponse{
+		Secret:          enrolment.Secret,
+		ProvisioningURI: enrolment.ProvisioningURI,
+	})
+}
+
+// ActivateMFA handles confirming two-factor enrolment with a code
+func (h *AuthHandler) ActivateMFA(c *gin.Context) {
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Code is required",
+		})
+		return
+	}
+
+	codes, err := h.authService.ActivateMFA(c.GetString("username"), req.Code)
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	audit.SetResourceID(c, c.GetString("username"))
+	c.JSON(http.StatusOK, dto.MFARecoveryCodesResponse{
+		RecoveryCodes: codes,
+		Message:       "Two-factor authentication enabled. Store the recovery codes in a safe place, they are shown only once",
+	})
+}
+
+// DisableMFA handles turning off two-factor authentication for the current admin
+func (h *AuthHandler) DisableMFA(c *gin.Context) {
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Code is required",
+		})
+		return
+	}
+
+	if err := h.authService.DisableMFA(c.GetString("username"), req.Code); err != nil {
+		c.JSON(mfaErrorStatus(err), dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	audit.SetResourceID(c, c.GetString("username"))
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Two-factor authentication disabled",
+	})
+}
+
+// mfaErrorStatus maps MFA errors to HTTP status codes
+func mfaErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidMFACode):
+		return http.StatusUnauthorized
+	case errors.Is(err, service.ErrMFAAlreadyEnabled),
+		errors.Is(err, service.ErrMFANotEnrolled),
+		errors.Is(err, service.ErrMFANotEnabled):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/password"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/service"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/pkg/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordHandler handles forgot/reset password requests
+type PasswordHandler struct {
+	resetService *service.PasswordResetService
+}
+
+// NewPasswordHandler creates a new password handler
+func NewPasswordHandler(resetService *service.PasswordResetService) *PasswordHandler {
+	return &PasswordHandler{
+		resetService: resetService,
+	}
+}
+
+// ForgotPassword handles sending a password reset link
+func (h *PasswordHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Email is required",
+		})
+		return
+	}
+
+	if err := h.resetService.RequestReset(req.Email, c.ClientIP()); err != nil {
+		var tooMany *service.TooManyAttemptsError
+		if errors.As(err, &tooMany) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(tooMany.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, dto.ErrorResponse{
+				Error: "Too many password reset requests, try again later",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	// Same response whether or not the address belongs to an account; the
+	// email is sent in the background
+	c.JSON(http.StatusAccepted, dto.SuccessResponse{
+		Message: "If an account with that email exists, a password reset link will be sent",
+	})
+}
+
+// ResetPassword handles setting a new password with a reset token
+func (h *PasswordHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Token and new password are required",
+		})
+		return
+	}
+
+	err := h.resetService.ResetPassword(req.Token, req.NewPassword)
+	if err != nil {
+		status := http.StatusInternalServerError
+		var policyErr *password.PolicyError
+		if errors.Is(err, service.ErrInvalidResetToken) || errors.As(err, &policyErr) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Password has been reset successfully",
+	})
+}
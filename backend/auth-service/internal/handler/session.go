@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/service"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/pkg/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// ListSessions handles listing the caller's own active sessions
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	claims := c.MustGet("claims").(*service.Claims)
+
+	sessions, err := h.authService.ListSessions(claims)
+	if err != nil {
+		sessionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newSessionsResponse(sessions, claims.FamilyID))
+}
+
+// RevokeSession handles revoking one of the caller's own sessions
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	claims := c.MustGet("claims").(*service.Claims)
+
+	if err := h.authService.RevokeSession(claims, c.Param("id")); err != nil {
+		sessionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Session revoked successfully",
+	})
+}
+
+// ListAdminSessions handles listing the active sessions of another admin
+func (h *AuthHandler) ListAdminSessions(c *gin.Context) {
+	claims := c.MustGet("claims").(*service.Claims)
+
+	sessions, err := h.authService.ListAdminSessions(c.Param("username"))
+	if err != nil {
+		sessionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newSessionsResponse(sessions, claims.FamilyID))
+}
+
+// RevokeAdminSession handles revoking a session of another admin
+func (h *AuthHandler) RevokeAdminSession(c *gin.Context) {
+	claims := c.MustGet("claims").(*service.Claims)
+
+	if err := h.authService.RevokeAdminSession(c.Request.Context(), claims, c.Param("username"), c.Param("id")); err != nil {
+		sessionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Session revoked successfully",
+	})
+}
+
+// sessionError writes the response for a session management error
+func sessionError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrSessionNotFound), errors.Is(err, service.ErrAdminNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrNoSessions):
+		status = http.StatusBadRequest
+	case errors.Is(err, service.ErrOutranked):
+		status = http.StatusForbidden
+	}
+	c.JSON(status, dto.ErrorResponse{
+		Error: err.Error(),
+	})
+}
+
+// newSessionsResponse converts token families to the session response
+// format. currentID is the session of the caller's own token.
+func newSessionsResponse(families []model.TokenFamily, currentID string) dto.ListSessionsResponse {
+	sessions := make([]dto.SessionResponse, 0, len(families))
+	for _, family := range families {
+		session := dto.SessionResponse{
+			ID:        family.ID,
+			UserAgent: family.UserAgent,
+			IPAddress: family.IPAddress,
+			CreatedAt: family.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			Current:   family.ID == currentID,
+		}
+		if family.LastSeenAt != nil {
+			session.LastSeenAt = family.LastSeenAt.Format("2006-01-02T15:04:05Z")
+		}
+		sessions = append(sessions, session)
+	}
+	return dto.ListSessionsResponse{Sessions: sessions}
+}
+
+// clientInfo returns the client a request came from
+func clientInfo(c *gin.Context) service.ClientInfo {
+	return service.ClientInfo{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
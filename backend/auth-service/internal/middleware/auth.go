@@ -4,6 +4,7 @@ import (
     "net/http"
     "strings"
 
+    "github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
     "github.com/FurkanArikk/fitness-center/backend/auth-service/internal/service"
     "github.com/FurkanArikk/fitness-center/backend/auth-service/pkg/dto"
     "github.com/gin-gonic/gin"
@@ -46,7 +47,49 @@ func AuthMiddleware(authService *service.AuthService) gin.HandlerFunc {
         }
 
         // Store user info in context
+        c.Set("claims", claims)
         c.Set("username", claims.Username)
+        c.Set("user_id", claims.Subject)
+        c.Set("roles", claims.Roles)
         c.Next()
     }
 }
+
+// RequireAdmin creates a middleware that rejects member and service tokens.
+// It must run after AuthMiddleware.
+func RequireAdmin() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        claims, ok := c.Get("claims")
+        if !ok || claims.(*service.Claims).IsMember() || claims.(*service.Claims).IsService() {
+            c.JSON(http.StatusForbidden, dto.ErrorResponse{
+                Error: "Admin access required",
+            })
+            c.Abort()
+            return
+        }
+        c.Next()
+    }
+}
+
+// RequirePermission creates a middleware that only lets requests through when
+// one of the caller's roles, or a service token's scopes, grant the
+// permission. It must run after AuthMiddleware.
+func RequirePermission(perm model.Permission) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        claims, ok := c.Get("claims")
+        if !ok || !claims.(*service.Claims).HasPermission(perm) {
+            c.JSON(http.StatusForbidden, dto.ErrorResponse{
+                Error: "Insufficient permissions",
+            })
+            c.Abort()
+            return
+        }
+        c.Next()
+    }
+}
+
+// Actor returns the username and user ID set by AuthMiddleware for the audit
+// trail
+func Actor(c *gin.Context) (string, string) {
+    return c.GetString("username"), c.GetString("user_id")
+}
@@ -12,14 +12,33 @@ type Admin struct {
 	Username    string         `gorm:"uniqueIndex;not null" json:"username"`
 	Password    string         `gorm:"not null" json:"-"`
 	Email       string         `gorm:"uniqueIndex" json:"email"`
+	Role        Role           `gorm:"size:32;not null;default:owner" json:"role"`
 	IsActive    bool           `gorm:"default:true" json:"is_active"`
 	LastLoginAt *time.Time     `json:"last_login_at"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Lockout tracking
+	FailedLoginAttempts int        `gorm:"default:0" json:"failed_login_attempts"`
+	LastFailedLoginAt   *time.Time `json:"last_failed_login_at"`
+	LockedUntil         *time.Time `json:"locked_until"`
+	LastLockedAt        *time.Time `json:"last_locked_at"`
+	LockCount           int        `gorm:"default:0" json:"lock_count"`
+
+	// Two-factor authentication
+	MFAEnabled       bool   `gorm:"default:false" json:"mfa_enabled"`
+	MFASecret        string `json:"-"`
+	MFARecoveryCodes string `json:"-"` // comma separated SHA-256 hashes of unused recovery codes
+	MFALastUsedStep  int64  `gorm:"default:0" json:"-"`
 }
 
 // TableName specifies the table name for GORM
 func (Admin) TableName() string {
 	return "admins"
 }
+
+// IsLocked reports whether the account is currently locked
+func (a *Admin) IsLocked() bool {
+	return a.LockedUntil != nil && a.LockedUntil.After(time.Now())
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// AuditLog is a row of the append-only audit log. The JSON columns hold the
+// state of the resource before and after the change and the changed fields.
+type AuditLog struct {
+	ID           int64     `gorm:"column:audit_id;primaryKey;autoIncrement" json:"id"`
+	Actor        string    `gorm:"size:255;index;not null;default:''" json:"actor"`
+	ActorID      string    `gorm:"size:64;not null;default:''" json:"actor_id"`
+	Action       string    `gorm:"size:100;not null" json:"action"`
+	ResourceType string    `gorm:"size:100;not null;index:idx_audit_log_resource" json:"resource_type"`
+	ResourceID   string    `gorm:"size:100;not null;default:'';index:idx_audit_log_resource" json:"resource_id"`
+	BeforeState  *string   `gorm:"type:jsonb" json:"before_state"`
+	AfterState   *string   `gorm:"type:jsonb" json:"after_state"`
+	Changes      *string   `gorm:"type:jsonb" json:"changes"`
+	Method       string    `gorm:"size:10;not null" json:"method"`
+	Path         string    `gorm:"size:255;not null" json:"path"`
+	IPAddress    string    `gorm:"size:45;not null;default:''" json:"ip_address"`
+	CreatedAt    time.Time `gorm:"index;not null" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
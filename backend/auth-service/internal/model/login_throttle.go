@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// LoginThrottle tracks failed login attempts for a username or client IP.
+// Keys are prefixed with their kind, e.g. "ip:10.0.0.1" or "user:admin".
+type LoginThrottle struct {
+	Key           string     `gorm:"primaryKey;size:255" json:"key"`
+	Failures      int        `gorm:"default:0" json:"failures"`
+	LastFailureAt *time.Time `json:"last_failure_at"`
+	BlockedUntil  *time.Time `json:"blocked_until"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (LoginThrottle) TableName() string {
+	return "login_throttles"
+}
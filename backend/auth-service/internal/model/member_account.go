@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MemberAccount is the login identity of a gym member. MemberID references
+// the member record in member-service.
+type MemberAccount struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	MemberID    uint           `gorm:"uniqueIndex;not null" json:"member_id"`
+	Email       string         `gorm:"uniqueIndex;not null" json:"email"`
+	Password    string         `gorm:"not null" json:"-"`
+	IsActive    bool           `gorm:"default:true" json:"is_active"`
+	LastLoginAt *time.Time     `json:"last_login_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName specifies the table name for GORM
+func (MemberAccount) TableName() string {
+	return "member_accounts"
+}
+
+// MemberInvite is a single-use invitation for a member to create an account.
+// Only the SHA-256 hash of the token is stored.
+type MemberInvite struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	TokenHash string     `gorm:"uniqueIndex;size:64;not null" json:"-"`
+	MemberID  uint       `gorm:"index;not null" json:"member_id"`
+	Email     string     `gorm:"not null" json:"email"`
+	InvitedBy string     `json:"invited_by"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (MemberInvite) TableName() string {
+	return "member_invites"
+}
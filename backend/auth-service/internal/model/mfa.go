@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// MFAChallenge is issued by the first login step when the admin has two-factor
+// authentication enabled. It is exchanged for tokens together with a valid
+// code and can be used only once.
+type MFAChallenge struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	TokenHash string     `gorm:"uniqueIndex;size:64;not null" json:"-"`
+	AdminID   uint       `gorm:"index;not null" json:"admin_id"`
+	Attempts  int        `gorm:"default:0" json:"attempts"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (MFAChallenge) TableName() string {
+	return "mfa_challenges"
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// PasswordHistory is a previous password hash of an admin or member account,
+// kept to prevent passwords from being reused
+type PasswordHistory struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	AdminID         uint      `gorm:"index" json:"admin_id"`
+	MemberAccountID uint      `gorm:"index" json:"member_account_id"`
+	PasswordHash    string    `gorm:"not null" json:"-"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (PasswordHistory) TableName() string {
+	return "password_history"
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// PasswordResetToken is a single-use token sent by email to reset a
+// forgotten password. Only the SHA-256 hash of the token is stored.
+type PasswordResetToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	TokenHash string     `gorm:"uniqueIndex;size:64;not null" json:"-"`
+	AdminID   uint       `gorm:"index;not null" json:"admin_id"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
@@ -0,0 +1,167 @@
+package model
+
+// Role represents the role assigned to an admin account
+type Role string
+
+// Available admin roles
+const (
+	RoleOwner      Role = "owner"
+	RoleManager    Role = "manager"
+	RoleFrontDesk  Role = "front_desk"
+	RoleTrainer    Role = "trainer"
+	RoleAccountant Role = "accountant"
+
+	// RoleMember is carried by member tokens and cannot be assigned to admins
+	RoleMember Role = "member"
+
+	// RoleService is carried by service client tokens, whose permissions come
+	// from their scopes instead of a role
+	RoleService Role = "service"
+)
+
+// Permission represents a single action that can be granted to a role
+type Permission string
+
+// Available permissions, grouped by resource
+const (
+	PermAdminsView   Permission = "admins:view"
+	PermAdminsManage Permission = "admins:manage"
+
+	PermMembersRead   Permission = "members:read"
+	PermMembersWrite  Permission = "members:write"
+	PermMembersDelete Permission = "members:delete"
+
+	PermClassesRead  Permission = "classes:read"
+	PermClassesWrite Permission = "classes:write"
+
+	PermBookingsRead  Permission = "bookings:read"
+	PermBookingsWrite Permission = "bookings:write"
+
+	PermPaymentsRead   Permission = "payments:read"
+	PermPaymentsWrite  Permission = "payments:write"
+	PermPaymentsDelete Permission = "payments:delete"
+
+	PermStaffRead  Permission = "staff:read"
+	PermStaffWrite Permission = "staff:write"
+
+	// PermRatingsSync lets class-service push trainer ratings to the staff
+	// service. It is a service scope only; no admin role is granted it.
+	PermRatingsSync Permission = "ratings:sync"
+
+	PermFacilitiesRead  Permission = "facilities:read"
+	PermFacilitiesWrite Permission = "facilities:write"
+
+	PermAuditRead Permission = "audit:read"
+
+	PermSessionsManage Permission = "sessions:manage"
+)
+
+// rolePermissions is the permission matrix. Owners are handled separately
+// and always have every permission.
+var rolePermissions = map[Role][]Permission{
+	RoleManager: {
+		PermAdminsView,
+		PermMembersRead, PermMembersWrite, PermMembersDelete,
+		PermClassesRead, PermClassesWrite,
+		PermBookingsRead, PermBookingsWrite,
+		PermPaymentsRead, PermPaymentsWrite,
+		PermStaffRead, PermStaffWrite,
+		PermFacilitiesRead, PermFacilitiesWrite,
+		PermAuditRead,
+		PermSessionsManage,
+	},
+	RoleFrontDesk: {
+		PermMembersRead, PermMembersWrite,
+		PermClassesRead,
+		PermBookingsRead, PermBookingsWrite,
+		PermPaymentsRead, PermPaymentsWrite,
+		PermStaffRead,
+		PermFacilitiesRead,
+	},
+	RoleTrainer: {
+		PermMembersRead,
+		PermClassesRead, PermClassesWrite,
+		PermBookingsRead, PermBookingsWrite,
+		PermStaffRead,
+		PermFacilitiesRead,
+	},
+	RoleAccountant: {
+		PermMembersRead,
+		PermPaymentsRead, PermPaymentsWrite, PermPaymentsDelete,
+	},
+}
+
+// serviceScopes are the permissions that can be granted to service clients.
+// Admin management stays with human admins.
+var serviceScopes = []Permission{
+	PermMembersRead, PermMembersWrite, PermMembersDelete,
+	PermClassesRead, PermClassesWrite,
+	PermBookingsRead, PermBookingsWrite,
+	PermPaymentsRead, PermPaymentsWrite, PermPaymentsDelete,
+	PermStaffRead, PermStaffWrite,
+	PermRatingsSync,
+	PermFacilitiesRead, PermFacilitiesWrite,
+	PermAuditRead,
+}
+
+// IsServiceScope reports whether the permission can be granted to a service
+// client
+func (p Permission) IsServiceScope() bool {
+	for _, s := range serviceScopes {
+		if s == p {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid reports whether the role is one of the known admin roles
+func (r Role) IsValid() bool {
+	if r == RoleOwner {
+		return true
+	}
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Can reports whether the role has been granted the given permission
+func (r Role) Can(perm Permission) bool {
+	if r == RoleOwner {
+		return true
+	}
+	for _, p := range rolePermissions[r] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// roleRanks orders the admin roles for managing other admins. Roles that are
+// not listed rank lowest.
+var roleRanks = map[Role]int{
+	RoleOwner:   2,
+	RoleManager: 1,
+}
+
+// AnyRanksAtLeast reports whether at least one of the given roles ranks at
+// least as high as target, so that its holder may manage admins with the
+// target role
+func AnyRanksAtLeast(roles []string, target Role) bool {
+	for _, r := range roles {
+		if roleRanks[Role(r)] >= roleRanks[target] {
+			return true
+		}
+	}
+	return false
+}
+
+// AnyCan reports whether at least one of the given roles has the permission
+func AnyCan(roles []string, perm Permission) bool {
+	for _, r := range roles {
+		if Role(r).Can(perm) {
+			return true
+		}
+	}
+	return false
+}
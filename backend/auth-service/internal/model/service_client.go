@@ -0,0 +1,33 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// ServiceClient is a machine identity used by another service to obtain
+// access tokens with the client credentials grant. Only the SHA-256 hash of
+// the secret is stored.
+type ServiceClient struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	Name            string     `gorm:"uniqueIndex;not null" json:"name"`
+	Description     string     `json:"description"`
+	SecretHash      string     `gorm:"size:64;not null" json:"-"`
+	Scopes          string     `gorm:"not null" json:"scopes"`
+	IsActive        bool       `gorm:"default:true" json:"is_active"`
+	CreatedBy       string     `json:"created_by"`
+	SecretRotatedAt time.Time  `gorm:"not null" json:"secret_rotated_at"`
+	LastUsedAt      *time.Time `json:"last_used_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ServiceClient) TableName() string {
+	return "service_clients"
+}
+
+// ScopeList returns the scopes granted to the client
+func (c *ServiceClient) ScopeList() []string {
+	return strings.Fields(c.Scopes)
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// SigningKey is an asymmetric key used to sign access tokens. A new key is
+// published from its creation and signs new tokens from ActivatesAt until it
+// is rotated out at RotatedAt; rotated keys are still published for
+// verification until the tokens they signed have expired.
+type SigningKey struct {
+	KID         string     `gorm:"primaryKey;size:64" json:"kid"`
+	Algorithm   string     `gorm:"size:16;not null" json:"algorithm"`
+	PrivateKey  string     `gorm:"type:text;not null" json:"-"` // PKCS#8 PEM
+	PublicKey   string     `gorm:"type:text;not null" json:"public_key"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ActivatesAt time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP" json:"activates_at"`
+	RotatedAt   *time.Time `gorm:"index" json:"rotated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (SigningKey) TableName() string {
+	return "signing_keys"
+}
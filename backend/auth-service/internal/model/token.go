@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// TokenFamily groups an access token and the chain of refresh tokens rotated
+// from the same login, and is shown to users as a session. Revoking a family
+// invalidates all of its tokens. A family belongs either to an admin or to a
+// member account; the other ID is zero.
+type TokenFamily struct {
+	ID              string     `gorm:"primaryKey;size:64" json:"id"`
+	AdminID         uint       `gorm:"index;not null" json:"admin_id"`
+	MemberAccountID uint       `gorm:"index;not null;default:0" json:"member_account_id"`
+	UserAgent       string     `gorm:"size:255" json:"user_agent"`
+	IPAddress       string     `gorm:"size:64" json:"ip_address"`
+	LastSeenAt      *time.Time `json:"last_seen_at"`
+	RevokedAt       *time.Time `json:"revoked_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (TokenFamily) TableName() string {
+	return "token_families"
+}
+
+// IsRevoked reports whether the family has been revoked
+func (f *TokenFamily) IsRevoked() bool {
+	return f.RevokedAt != nil
+}
+
+// RefreshToken represents a single-use refresh token. Only the SHA-256 hash of
+// the token is stored.
+type RefreshToken struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	TokenHash       string     `gorm:"uniqueIndex;size:64;not null" json:"-"`
+	FamilyID        string     `gorm:"index;size:64;not null" json:"family_id"`
+	AdminID         uint       `gorm:"index;not null" json:"admin_id"`
+	MemberAccountID uint       `gorm:"index;not null;default:0" json:"member_account_id"`
+	ExpiresAt       time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt          *time.Time `json:"used_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
@@ -0,0 +1,73 @@
+package password
+
+import (
+	"fmt"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/config"
+)
+
+// Algorithm hashes and verifies passwords with a single hashing scheme
+type Algorithm interface {
+	// Hash returns an encoded hash of the password
+	Hash(password string) (string, error)
+	// Verify reports whether the password matches an encoded hash
+	Verify(password, hash string) bool
+	// Identifies reports whether the encoded hash belongs to this algorithm
+	Identifies(hash string) bool
+	// NeedsRehash reports whether a hash of this algorithm was created with
+	// weaker parameters than the current ones
+	NeedsRehash(hash string) bool
+}
+
+// Hasher hashes new passwords with the preferred algorithm and verifies
+// hashes created by any of the known algorithms, so stored hashes can be
+// upgraded as users log in
+type Hasher struct {
+	preferred Algorithm
+	known     []Algorithm
+}
+
+// NewHasher creates a hasher that hashes with preferred and also verifies
+// hashes of the legacy algorithms
+func NewHasher(preferred Algorithm, legacy ...Algorithm) *Hasher {
+	return &Hasher{
+		preferred: preferred,
+		known:     append([]Algorithm{preferred}, legacy...),
+	}
+}
+
+// NewHasherFromConfig creates the hasher selected by the configuration. Both
+// bcrypt and argon2id hashes are always accepted.
+func NewHasherFromConfig(cfg *config.PasswordConfig) (*Hasher, error) {
+	bcryptAlg := NewBcrypt(cfg.BcryptCost)
+	argon2Alg := NewArgon2id(uint32(cfg.Argon2MemoryKB), uint32(cfg.Argon2Iterations), uint8(cfg.Argon2Parallelism))
+
+	switch cfg.Hasher {
+	case "argon2id", "":
+		return NewHasher(argon2Alg, bcryptAlg), nil
+	case "bcrypt":
+		return NewHasher(bcryptAlg, argon2Alg), nil
+	default:
+		return nil, fmt.Errorf("unsupported password hasher: %s", cfg.Hasher)
+	}
+}
+
+// Hash hashes a password with the preferred algorithm
+func (h *Hasher) Hash(password string) (string, error) {
+	return h.preferred.Hash(password)
+}
+
+// Verify checks a password against an encoded hash. rehash is true when the
+// password matched but the hash should be replaced by one from Hash.
+func (h *Hasher) Verify(password, hash string) (ok bool, rehash bool) {
+	for _, alg := range h.known {
+		if !alg.Identifies(hash) {
+			continue
+		}
+		if !alg.Verify(password, hash) {
+			return false, false
+		}
+		return true, alg != h.preferred || alg.NeedsRehash(hash)
+	}
+	return false, false
+}
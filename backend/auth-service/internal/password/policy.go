@@ -0,0 +1,83 @@
+package password
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// PolicyError is returned when a password does not meet the policy
+type PolicyError struct {
+	Reason string
+}
+
+func (e *PolicyError) Error() string {
+	return "password " + e.Reason
+}
+
+// Policy defines the rules new passwords must follow. Reuse of previous
+// passwords is checked by the caller against the stored history, since it
+// needs the hashes; HistorySize is how many of them are kept.
+type Policy struct {
+	MinLength   int
+	HistorySize int
+	denylist    map[string]struct{}
+}
+
+// NewPolicy creates a password policy. denylistFile is an optional file of
+// breached or common passwords, one per line; lines starting with # are
+// ignored and matching is case insensitive.
+func NewPolicy(minLength, historySize int, denylistFile string) (*Policy, error) {
+	policy := &Policy{
+		MinLength:   minLength,
+		HistorySize: historySize,
+		denylist:    make(map[string]struct{}),
+	}
+	if denylistFile == "" {
+		return policy, nil
+	}
+
+	file, err := os.Open(denylistFile)
+	if err != nil {
+		return nil, fmt.Errorf("error opening password denylist: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		policy.denylist[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading password denylist: %v", err)
+	}
+
+	return policy, nil
+}
+
+// Check validates a new password for the account with the given username or
+// email
+func (p *Policy) Check(password, username string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return &PolicyError{Reason: fmt.Sprintf("must be at least %d characters", p.MinLength)}
+	}
+	// bcrypt ignores everything after 72 bytes
+	if len(password) > 72 {
+		return &PolicyError{Reason: "must be at most 72 bytes"}
+	}
+
+	lower := strings.ToLower(password)
+	if _, ok := p.denylist[lower]; ok {
+		return &PolicyError{Reason: "is too common or has appeared in a data breach"}
+	}
+	if len(username) >= 3 && strings.Contains(lower, strings.ToLower(username)) {
+		return &PolicyError{Reason: "must not contain the username"}
+	}
+
+	return nil
+}
@@ -1,12 +1,25 @@
 package repository
 
 import (
+	"errors"
 	"time"
 
 	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrLastOwner is returned when a change would leave no active owner
+var ErrLastOwner = errors.New("at least one active owner is required")
+
+// AdminFilter narrows down the admins returned by ListPaginated
+type AdminFilter struct {
+	Status       string // "active" (default), "inactive" or "all"
+	Role         string
+	Search       string // matched against username and email
+	InactiveDays int    // only admins who have not logged in for this many days
+}
+
 // AdminRepository handles admin database operations
 type AdminRepository struct {
 	db *gorm.DB
@@ -17,7 +30,7 @@ func NewAdminRepository(db *gorm.DB) *AdminRepository {
 	return &AdminRepository{db: db}
 }
 
-// GetByUsername finds an admin by username
+// GetByUsername finds an active admin by username
 func (r *AdminRepository) GetByUsername(username string) (*model.Admin, error) {
 	var admin model.Admin
 	err := r.db.Where("username = ? AND is_active = ?", username, true).First(&admin).Error
@@ -27,6 +40,26 @@ func (r *AdminRepository) GetByUsername(username string) (*model.Admin, error) {
 	return &admin, nil
 }
 
+// FindByUsername finds an admin by username, whether active or not
+func (r *AdminRepository) FindByUsername(username string) (*model.Admin, error) {
+	var admin model.Admin
+	err := r.db.Where("username = ?", username).First(&admin).Error
+	if err != nil {
+		return nil, err
+	}
+	return &admin, nil
+}
+
+// GetByEmail finds an active admin by email
+func (r *AdminRepository) GetByEmail(email string) (*model.Admin, error) {
+	var admin model.Admin
+	err := r.db.Where("LOWER(email) = LOWER(?) AND is_active = ?", email, true).First(&admin).Error
+	if err != nil {
+		return nil, err
+	}
+	return &admin, nil
+}
+
 // GetByID finds an admin by ID
 func (r *AdminRepository) GetByID(id uint) (*model.Admin, error) {
 	var admin model.Admin
@@ -47,6 +80,86 @@ func (r *AdminRepository) Update(admin *model.Admin) error {
 	return r.db.Save(admin).Error
 }
 
+// UpdatePasswordHash replaces the stored password hash without touching
+// other columns
+func (r *AdminRepository) UpdatePasswordHash(id uint, hash string) error {
+	return r.db.Model(&model.Admin{}).Where("id = ?", id).Update("password", hash).Error
+}
+
+// ResetPassword replaces the stored password hash and clears the lockout
+// without touching other columns
+func (r *AdminRepository) ResetPassword(id uint, hash string) error {
+	return r.db.Model(&model.Admin{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"password":              hash,
+		"failed_login_attempts": 0,
+		"locked_until":          nil,
+	}).Error
+}
+
+// RecordFailedLogin counts a failed login of an admin. Failures before
+// resetBefore are forgotten. When the count reaches maxAttempts the account
+// is locked until lockedUntil, which is reported by the returned bool. The
+// row stays locked until the count is settled, so concurrent failures are
+// all counted and lock the account once.
+func (r *AdminRepository) RecordFailedLogin(id uint, now, resetBefore time.Time, maxAttempts int, lockedUntil time.Time) (bool, error) {
+	locked := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var attempts int
+		err := tx.Raw(`UPDATE admins SET
+				failed_login_attempts = CASE WHEN last_failed_login_at < ? THEN 1 ELSE failed_login_attempts + 1 END,
+				last_failed_login_at = ?
+			WHERE id = ?
+			RETURNING failed_login_attempts`, resetBefore, now, id).Scan(&attempts).Error
+		if err != nil {
+			return err
+		}
+		if maxAttempts <= 0 || attempts < maxAttempts {
+			return nil
+		}
+
+		locked = true
+		return tx.Model(&model.Admin{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+			"failed_login_attempts": 0,
+			"locked_until":          lockedUntil,
+			"last_locked_at":        now,
+			"lock_count":            gorm.Expr("lock_count + 1"),
+		}).Error
+	})
+	return locked, err
+}
+
+// ClearLockout resets the failed login count and lock of an admin
+func (r *AdminRepository) ClearLockout(id uint) error {
+	return r.db.Model(&model.Admin{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"failed_login_attempts": 0,
+		"locked_until":          nil,
+	}).Error
+}
+
+// UseMFAStep records the TOTP time step of an accepted code. It returns false
+// when the same or a later step has already been used.
+func (r *AdminRepository) UseMFAStep(id uint, step int64) (bool, error) {
+	result := r.db.Model(&model.Admin{}).
+		Where("id = ? AND mfa_last_used_step < ?", id, step).
+		Update("mfa_last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReplaceRecoveryCodes replaces the recovery code hashes of an admin if they
+// still are old. It returns false when they were changed in the meantime.
+func (r *AdminRepository) ReplaceRecoveryCodes(id uint, old, codes string) (bool, error) {
+	result := r.db.Model(&model.Admin{}).
+		Where("id = ? AND mfa_recovery_codes = ?", id, old).
+		Update("mfa_recovery_codes", codes)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
 // UpdateLastLogin updates the last login time
 func (r *AdminRepository) UpdateLastLogin(id uint) error {
 	now := time.Now()
@@ -59,3 +172,63 @@ func (r *AdminRepository) List() ([]model.Admin, error) {
 	err := r.db.Where("is_active = ?", true).Find(&admins).Error
 	return admins, err
 }
+
+// ListPaginated returns a page of admins matching the filter, ordered by
+// username, and the total number of matches
+func (r *AdminRepository) ListPaginated(filter AdminFilter, offset, limit int) ([]model.Admin, int64, error) {
+	query := r.db.Model(&model.Admin{})
+	switch filter.Status {
+	case "inactive":
+		query = query.Where("is_active = ?", false)
+	case "all":
+	default:
+		query = query.Where("is_active = ?", true)
+	}
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	if filter.Search != "" {
+		pattern := "%" + filter.Search + "%"
+		query = query.Where("username ILIKE ? OR email ILIKE ?", pattern, pattern)
+	}
+	if filter.InactiveDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -filter.InactiveDays)
+		query = query.Where("last_login_at IS NULL OR last_login_at < ?", cutoff)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var admins []model.Admin
+	err := query.Order("username").Offset(offset).Limit(limit).Find(&admins).Error
+	return admins, total, err
+}
+
+// SaveKeepingOwner saves an admin unless the change would leave no active
+// owner. The active owners are locked while checking, so two concurrent
+// changes cannot both remove the last one.
+func (r *AdminRepository) SaveKeepingOwner(admin *model.Admin) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if !admin.IsActive || admin.Role != model.RoleOwner {
+			var owners []model.Admin
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("role = ? AND is_active = ? AND id <> ?", model.RoleOwner, true, admin.ID).
+				Find(&owners).Error
+			if err != nil {
+				return err
+			}
+			if len(owners) == 0 {
+				var current model.Admin
+				if err := tx.First(&current, admin.ID).Error; err != nil {
+					return err
+				}
+				if current.IsActive && current.Role == model.RoleOwner {
+					return ErrLastOwner
+				}
+			}
+		}
+		return tx.Save(admin).Error
+	})
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// AuditRepository stores the audit log. It implements audit.Store and only
+// ever inserts rows.
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new audit repository
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Record appends an entry to the audit log
+func (r *AuditRepository) Record(ctx context.Context, entry *audit.Entry) error {
+	row := model.AuditLog{
+		Actor:        entry.Actor,
+		ActorID:      entry.ActorID,
+		Action:       entry.Action,
+		ResourceType: entry.ResourceType,
+		ResourceID:   entry.ResourceID,
+		BeforeState:  jsonColumn(entry.Before),
+		AfterState:   jsonColumn(entry.After),
+		Changes:      jsonColumn(entry.Changes),
+		Method:       entry.Method,
+		Path:         entry.Path,
+		IPAddress:    entry.IP,
+		CreatedAt:    entry.CreatedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(&row).Error; err != nil {
+		return err
+	}
+	entry.ID = row.ID
+	return nil
+}
+
+// List returns audit entries matching the filter, newest first, with the
+// total count
+func (r *AuditRepository) List(ctx context.Context, filter audit.Filter) ([]audit.Entry, int, error) {
+	var total int64
+	if err := r.filtered(ctx, filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []model.AuditLog
+	err := r.filtered(ctx, filter).
+		Order("created_at DESC, audit_id DESC").
+		Limit(filter.Limit).Offset(filter.Offset).
+		Find(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]audit.Entry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, audit.Entry{
+			ID:           row.ID,
+			Actor:        row.Actor,
+			ActorID:      row.ActorID,
+			Action:       row.Action,
+			ResourceType: row.ResourceType,
+			ResourceID:   row.ResourceID,
+			Before:       jsonValue(row.BeforeState),
+			After:        jsonValue(row.AfterState),
+			Changes:      jsonValue(row.Changes),
+			Method:       row.Method,
+			Path:         row.Path,
+			IP:           row.IPAddress,
+			CreatedAt:    row.CreatedAt,
+		})
+	}
+	return entries, int(total), nil
+}
+
+// filtered returns a query on the audit log with the filter applied
+func (r *AuditRepository) filtered(ctx context.Context, filter audit.Filter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&model.AuditLog{})
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.ResourceType != "" {
+		query = query.Where("resource_type = ?", filter.ResourceType)
+	}
+	if filter.ResourceID != "" {
+		query = query.Where("resource_id = ?", filter.ResourceID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+	return query
+}
+
+// jsonColumn converts a JSON document to a nullable column value
+func jsonColumn(data json.RawMessage) *string {
+	if len(data) == 0 {
+		return nil
+	}
+	s := string(data)
+	return &s
+}
+
+// jsonValue converts a nullable column value to a JSON document
+func jsonValue(s *string) json.RawMessage {
+	if s == nil {
+		return nil
+	}
+	return json.RawMessage(*s)
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// ChallengeRepository handles MFA challenge database operations
+type ChallengeRepository struct {
+	db *gorm.DB
+}
+
+// NewChallengeRepository creates a new challenge repository
+func NewChallengeRepository(db *gorm.DB) *ChallengeRepository {
+	return &ChallengeRepository{db: db}
+}
+
+// Create stores a new challenge
+func (r *ChallengeRepository) Create(challenge *model.MFAChallenge) error {
+	return r.db.Create(challenge).Error
+}
+
+// GetByHash finds a challenge by its token hash
+func (r *ChallengeRepository) GetByHash(hash string) (*model.MFAChallenge, error) {
+	var challenge model.MFAChallenge
+	err := r.db.Where("token_hash = ?", hash).First(&challenge).Error
+	if err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// IncrementAttempts records a failed code for a challenge
+func (r *ChallengeRepository) IncrementAttempts(id uint) error {
+	return r.db.Model(&model.MFAChallenge{}).
+		Where("id = ?", id).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+// MarkUsed marks a challenge as used. It returns false when the challenge had
+// already been used.
+func (r *ChallengeRepository) MarkUsed(id uint) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&model.MFAChallenge{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", &now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
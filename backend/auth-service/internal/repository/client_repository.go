@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// ClientRepository handles service client database operations
+type ClientRepository struct {
+	db *gorm.DB
+}
+
+// NewClientRepository creates a new service client repository
+func NewClientRepository(db *gorm.DB) *ClientRepository {
+	return &ClientRepository{db: db}
+}
+
+// Create creates a new service client
+func (r *ClientRepository) Create(client *model.ServiceClient) error {
+	return r.db.Create(client).Error
+}
+
+// GetByName finds a service client by name
+func (r *ClientRepository) GetByName(name string) (*model.ServiceClient, error) {
+	var client model.ServiceClient
+	err := r.db.Where("name = ?", name).First(&client).Error
+	if err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+// List returns all service clients
+func (r *ClientRepository) List() ([]model.ServiceClient, error) {
+	var clients []model.ServiceClient
+	err := r.db.Order("name").Find(&clients).Error
+	return clients, err
+}
+
+// Update updates a service client
+func (r *ClientRepository) Update(client *model.ServiceClient) error {
+	return r.db.Save(client).Error
+}
+
+// UpdateLastUsed updates the time the client last obtained a token
+func (r *ClientRepository) UpdateLastUsed(id uint) error {
+	now := time.Now()
+	return r.db.Model(&model.ServiceClient{}).Where("id = ?", id).Update("last_used_at", &now).Error
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// KeyRepository handles signing key database operations
+type KeyRepository struct {
+	db *gorm.DB
+}
+
+// NewKeyRepository creates a new key repository
+func NewKeyRepository(db *gorm.DB) *KeyRepository {
+	return &KeyRepository{db: db}
+}
+
+// ListUsable returns the keys that are signing, waiting to sign or were
+// rotated out after the given time, newest first
+func (r *KeyRepository) ListUsable(rotatedAfter time.Time) ([]model.SigningKey, error) {
+	var keys []model.SigningKey
+	err := r.db.Where("rotated_at IS NULL OR rotated_at > ?", rotatedAfter).
+		Order("created_at DESC").
+		Find(&keys).Error
+	return keys, err
+}
+
+// Rotate stores a new signing key and marks all previous signing keys as
+// rotated out when the new key starts signing, in a single transaction
+func (r *KeyRepository) Rotate(key *model.SigningKey) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.SigningKey{}).
+			Where("rotated_at IS NULL").
+			Update("rotated_at", key.ActivatesAt).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(key).Error
+	})
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// MemberAccountRepository handles member account and invite database operations
+type MemberAccountRepository struct {
+	db *gorm.DB
+}
+
+// NewMemberAccountRepository creates a new member account repository
+func NewMemberAccountRepository(db *gorm.DB) *MemberAccountRepository {
+	return &MemberAccountRepository{db: db}
+}
+
+// GetByEmail finds an active member account by email
+func (r *MemberAccountRepository) GetByEmail(email string) (*model.MemberAccount, error) {
+	var account model.MemberAccount
+	err := r.db.Where("LOWER(email) = LOWER(?) AND is_active = ?", email, true).First(&account).Error
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// GetByID finds a member account by ID
+func (r *MemberAccountRepository) GetByID(id uint) (*model.MemberAccount, error) {
+	var account model.MemberAccount
+	err := r.db.First(&account, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// GetByMemberID finds a member account by member ID
+func (r *MemberAccountRepository) GetByMemberID(memberID uint) (*model.MemberAccount, error) {
+	var account model.MemberAccount
+	err := r.db.Where("member_id = ?", memberID).First(&account).Error
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Create creates a new member account
+func (r *MemberAccountRepository) Create(account *model.MemberAccount) error {
+	return r.db.Create(account).Error
+}
+
+// Update updates a member account
+func (r *MemberAccountRepository) Update(account *model.MemberAccount) error {
+	return r.db.Save(account).Error
+}
+
+// UpdatePasswordHash replaces the stored password hash without touching
+// other columns
+func (r *MemberAccountRepository) UpdatePasswordHash(id uint, hash string) error {
+	return r.db.Model(&model.MemberAccount{}).Where("id = ?", id).Update("password", hash).Error
+}
+
+// UpdateLastLogin updates the last login time
+func (r *MemberAccountRepository) UpdateLastLogin(id uint) error {
+	now := time.Now()
+	return r.db.Model(&model.MemberAccount{}).Where("id = ?", id).Update("last_login_at", &now).Error
+}
+
+// CreateInvite stores a new invite, invalidating earlier unused invites for
+// the same member
+func (r *MemberAccountRepository) CreateInvite(invite *model.MemberInvite) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Model(&model.MemberInvite{}).
+			Where("member_id = ? AND used_at IS NULL", invite.MemberID).
+			Update("used_at", &now).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(invite).Error
+	})
+}
+
+// GetInviteByHash finds an invite by its token hash
+func (r *MemberAccountRepository) GetInviteByHash(hash string) (*model.MemberInvite, error) {
+	var invite model.MemberInvite
+	err := r.db.Where("token_hash = ?", hash).First(&invite).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// AcceptInvite marks the invite as used and creates the account in a single
+// transaction. It returns false when the invite had already been used.
+func (r *MemberAccountRepository) AcceptInvite(inviteID uint, account *model.MemberAccount) (bool, error) {
+	accepted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&model.MemberInvite{}).
+			Where("id = ? AND used_at IS NULL", inviteID).
+			Update("used_at", &now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return nil
+		}
+		if err := tx.Create(account).Error; err != nil {
+			return err
+		}
+		accepted = true
+		return nil
+	})
+	return accepted, err
+}
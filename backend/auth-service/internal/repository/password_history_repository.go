@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// PasswordHistoryRepository handles previous password database operations
+type PasswordHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordHistoryRepository creates a new password history repository
+func NewPasswordHistoryRepository(db *gorm.DB) *PasswordHistoryRepository {
+	return &PasswordHistoryRepository{db: db}
+}
+
+// Recent returns the most recent previous password hashes of an admin or
+// member account, newest first
+func (r *PasswordHistoryRepository) Recent(adminID, memberAccountID uint, limit int) ([]model.PasswordHistory, error) {
+	var entries []model.PasswordHistory
+	err := r.db.Where("admin_id = ? AND member_account_id = ?", adminID, memberAccountID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&entries).Error
+	return entries, err
+}
+
+// Add stores a previous password hash and removes entries beyond the most
+// recent keep
+func (r *PasswordHistoryRepository) Add(entry *model.PasswordHistory, keep int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+
+		var keepIDs []uint
+		err := tx.Model(&model.PasswordHistory{}).
+			Where("admin_id = ? AND member_account_id = ?", entry.AdminID, entry.MemberAccountID).
+			Order("created_at DESC, id DESC").
+			Limit(keep).
+			Pluck("id", &keepIDs).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("admin_id = ? AND member_account_id = ? AND id NOT IN ?", entry.AdminID, entry.MemberAccountID, keepIDs).
+			Delete(&model.PasswordHistory{}).Error
+	})
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// ResetRepository handles password reset token database operations
+type ResetRepository struct {
+	db *gorm.DB
+}
+
+// NewResetRepository creates a new reset repository
+func NewResetRepository(db *gorm.DB) *ResetRepository {
+	return &ResetRepository{db: db}
+}
+
+// Create stores a new reset token
+func (r *ResetRepository) Create(token *model.PasswordResetToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetByHash finds a reset token by its hash
+func (r *ResetRepository) GetByHash(hash string) (*model.PasswordResetToken, error) {
+	var token model.PasswordResetToken
+	err := r.db.Where("token_hash = ?", hash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed marks a reset token as used. It returns false when the token had
+// already been used.
+func (r *ResetRepository) MarkUsed(id uint) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&model.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", &now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// InvalidateForAdmin marks all unused reset tokens of an admin as used
+func (r *ResetRepository) InvalidateForAdmin(adminID uint) error {
+	now := time.Now()
+	return r.db.Model(&model.PasswordResetToken{}).
+		Where("admin_id = ? AND used_at IS NULL", adminID).
+		Update("used_at", &now).Error
+}
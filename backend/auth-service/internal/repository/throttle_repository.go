@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// ThrottleRepository handles login throttle database operations
+type ThrottleRepository struct {
+	db *gorm.DB
+}
+
+// NewThrottleRepository creates a new throttle repository
+func NewThrottleRepository(db *gorm.DB) *ThrottleRepository {
+	return &ThrottleRepository{db: db}
+}
+
+// Get finds the throttle for a key. A zero throttle is returned when the key
+// has no recorded failures.
+func (r *ThrottleRepository) Get(key string) (*model.LoginThrottle, error) {
+	var throttle model.LoginThrottle
+	err := r.db.Where("key = ?", key).First(&throttle).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &model.LoginThrottle{Key: key}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+// AddFailure counts a failure against a key and returns its consecutive
+// failures. Failures before resetBefore are forgotten. The count is
+// incremented in a single statement, so concurrent failures are all counted.
+func (r *ThrottleRepository) AddFailure(key string, now, resetBefore time.Time) (int, error) {
+	var failures int
+	err := r.db.Raw(`INSERT INTO login_throttles (key, failures, last_failure_at, updated_at)
+		VALUES (?, 1, ?, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_throttles.last_failure_at < ? THEN 1 ELSE login_throttles.failures + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at,
+			updated_at = EXCLUDED.updated_at
+		RETURNING failures`, key, now, now, resetBefore).Scan(&failures).Error
+	return failures, err
+}
+
+// Block blocks a key until the given time, unless it is already blocked for
+// longer
+func (r *ThrottleRepository) Block(key string, until time.Time) error {
+	return r.db.Model(&model.LoginThrottle{}).
+		Where("key = ? AND (blocked_until IS NULL OR blocked_until < ?)", key, until).
+		Update("blocked_until", until).Error
+}
+
+// Delete removes the throttle for a key
+func (r *ThrottleRepository) Delete(key string) error {
+	return r.db.Where("key = ?", key).Delete(&model.LoginThrottle{}).Error
+}
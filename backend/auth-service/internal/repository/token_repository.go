@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// TokenRepository handles token family and refresh token database operations
+type TokenRepository struct {
+	db *gorm.DB
+}
+
+// NewTokenRepository creates a new token repository
+func NewTokenRepository(db *gorm.DB) *TokenRepository {
+	return &TokenRepository{db: db}
+}
+
+// CreateFamily creates a new token family
+func (r *TokenRepository) CreateFamily(family *model.TokenFamily) error {
+	return r.db.Create(family).Error
+}
+
+// GetFamily finds a token family by ID
+func (r *TokenRepository) GetFamily(id string) (*model.TokenFamily, error) {
+	var family model.TokenFamily
+	err := r.db.Where("id = ?", id).First(&family).Error
+	if err != nil {
+		return nil, err
+	}
+	return &family, nil
+}
+
+// ListActiveFamilies returns the token families of an admin or member account
+// that are not revoked and still have an unused, unexpired refresh token,
+// most recently used first
+func (r *TokenRepository) ListActiveFamilies(adminID, memberAccountID uint) ([]model.TokenFamily, error) {
+	var families []model.TokenFamily
+	err := r.db.Where("admin_id = ? AND member_account_id = ? AND revoked_at IS NULL", adminID, memberAccountID).
+		Where("EXISTS (SELECT 1 FROM refresh_tokens rt WHERE rt.family_id = token_families.id AND rt.used_at IS NULL AND rt.expires_at > ?)", time.Now()).
+		Order("COALESCE(last_seen_at, created_at) DESC").
+		Find(&families).Error
+	return families, err
+}
+
+// TouchFamily records that a token family was used
+func (r *TokenRepository) TouchFamily(id, ipAddress string) error {
+	updates := map[string]interface{}{"last_seen_at": time.Now()}
+	if ipAddress != "" {
+		updates["ip_address"] = ipAddress
+	}
+	return r.db.Model(&model.TokenFamily{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// RevokeFamily revokes a single token family
+func (r *TokenRepository) RevokeFamily(id string) error {
+	now := time.Now()
+	return r.db.Model(&model.TokenFamily{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", &now).Error
+}
+
+// RevokeAllFamilies revokes every token family of an admin
+func (r *TokenRepository) RevokeAllFamilies(adminID uint) error {
+	now := time.Now()
+	return r.db.Model(&model.TokenFamily{}).
+		Where("admin_id = ? AND revoked_at IS NULL", adminID).
+		Update("revoked_at", &now).Error
+}
+
+// RevokeAllMemberFamilies revokes every token family of a member account
+func (r *TokenRepository) RevokeAllMemberFamilies(accountID uint) error {
+	now := time.Now()
+	return r.db.Model(&model.TokenFamily{}).
+		Where("member_account_id = ? AND revoked_at IS NULL", accountID).
+		Update("revoked_at", &now).Error
+}
+
+// CreateRefreshToken stores a new refresh token
+func (r *TokenRepository) CreateRefreshToken(token *model.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetRefreshTokenByHash finds a refresh token by its hash
+func (r *TokenRepository) GetRefreshTokenByHash(hash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	err := r.db.Where("token_hash = ?", hash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkRefreshTokenUsed marks a refresh token as used. It returns false when the
+// token had already been used, so concurrent rotations cannot both succeed.
+func (r *TokenRepository) MarkRefreshTokenUsed(id uint) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&model.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", &now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+)
+
+// routeRule maps a path prefix behind the gateway to the permissions needed
+// to read from and write to it. Delete falls back to the write permission
+// when no dedicated delete permission is set. A rule with a suffix only
+// matches paths below the prefix that end with it.
+type routeRule struct {
+	prefix string
+	suffix string
+	read   model.Permission
+	write  model.Permission
+	delete model.Permission
+}
+
+// routeRules lists the protected API prefixes served behind the gateway.
+// Rules are matched in order, so more specific prefixes come first. A rule
+// without permissions only requires a valid token.
+var routeRules = []routeRule{
+	{prefix: "/api/v1/admin/password"},
+	{prefix: "/api/v1/admin/mfa"},
+	{prefix: "/api/v1/admin/profile"},
+	{prefix: "/api/v1/admin/sessions", read: model.PermSessionsManage, write: model.PermSessionsManage},
+	{prefix: "/api/v1/admin/members", read: model.PermMembersRead, write: model.PermMembersWrite},
+	{prefix: "/api/v1/admin", read: model.PermAdminsView, write: model.PermAdminsManage},
+
+	{prefix: "/api/v1/member-memberships", read: model.PermMembersRead, write: model.PermMembersWrite, delete: model.PermMembersDelete},
+	{prefix: "/api/v1/members", read: model.PermMembersRead, write: model.PermMembersWrite, delete: model.PermMembersDelete},
+	{prefix: "/api/v1/memberships", read: model.PermMembersRead, write: model.PermMembersWrite, delete: model.PermMembersDelete},
+	{prefix: "/api/v1/benefits", read: model.PermMembersRead, write: model.PermMembersWrite, delete: model.PermMembersDelete},
+	{prefix: "/api/v1/assessments", read: model.PermMembersRead, write: model.PermMembersWrite, delete: model.PermMembersDelete},
+
+	{prefix: "/api/v1/classes", read: model.PermClassesRead, write: model.PermClassesWrite},
+	{prefix: "/api/v1/schedules", read: model.PermClassesRead, write: model.PermClassesWrite},
+	{prefix: "/api/v1/occurrences", read: model.PermClassesRead, write: model.PermClassesWrite},
+	{prefix: "/api/v1/closures", read: model.PermClassesRead, write: model.PermClassesWrite},
+	{prefix: "/api/v1/bookings", read: model.PermBookingsRead, write: model.PermBookingsWrite},
+
+	{prefix: "/api/v1/payment-types", read: model.PermPaymentsRead, write: model.PermPaymentsWrite, delete: model.PermPaymentsDelete},
+	{prefix: "/api/v1/payments", read: model.PermPaymentsRead, write: model.PermPaymentsWrite, delete: model.PermPaymentsDelete},
+	{prefix: "/api/v1/transactions", read: model.PermPaymentsRead, write: model.PermPaymentsWrite, delete: model.PermPaymentsDelete},
+
+	{prefix: "/api/v1/staff", read: model.PermStaffRead, write: model.PermStaffWrite},
+	{prefix: "/api/v1/trainers", suffix: "/rating", read: model.PermStaffRead, write: model.PermRatingsSync},
+	{prefix: "/api/v1/trainers", read: model.PermStaffRead, write: model.PermStaffWrite},
+	{prefix: "/api/v1/qualifications", read: model.PermStaffRead, write: model.PermStaffWrite},
+	{prefix: "/api/v1/training-sessions", read: model.PermStaffRead, write: model.PermStaffWrite},
+
+	{prefix: "/api/v1/facilities", read: model.PermFacilitiesRead, write: model.PermFacilitiesWrite},
+	{prefix: "/api/v1/equipment", read: model.PermFacilitiesRead, write: model.PermFacilitiesWrite},
+	{prefix: "/api/v1/attendance", read: model.PermFacilitiesRead, write: model.PermFacilitiesWrite},
+
+	// The audit log of every service is read-only
+	{prefix: "/api/v1/audit", read: model.PermAuditRead, write: model.PermAuditRead},
+}
+
+// RequiredPermission returns the permission needed for a request forwarded by
+// the gateway. The second return value is false when the route has no rule.
+func RequiredPermission(method, uri string) (model.Permission, bool) {
+	path := uri
+	if u, err := url.ParseRequestURI(uri); err == nil {
+		path = u.Path
+	}
+
+	for _, rule := range routeRules {
+		if path != rule.prefix && !strings.HasPrefix(path, rule.prefix+"/") {
+			continue
+		}
+		if rule.suffix != "" && (path == rule.prefix || !strings.HasSuffix(path, rule.suffix)) {
+			continue
+		}
+
+		var perm model.Permission
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			perm = rule.read
+		case http.MethodDelete:
+			perm = rule.delete
+			if perm == "" {
+				perm = rule.write
+			}
+		default:
+			perm = rule.write
+		}
+		return perm, perm != ""
+	}
+
+	return "", false
+}
+
+// MemberCanAccess reports whether a member token may call a route forwarded by
+// the gateway. Members can read the class timetable, manage bookings (which
+// class-service scopes to the caller) and read their own member and payment
+// records.
+func MemberCanAccess(method, uri string, memberID uint) bool {
+	path := uri
+	if u, err := url.ParseRequestURI(uri); err == nil {
+		path = u.Path
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 3 || segments[0] != "api" || segments[1] != "v1" {
+		return false
+	}
+
+	own := strconv.FormatUint(uint64(memberID), 10)
+	isRead := method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+
+	switch segments[2] {
+	case "classes", "schedules", "occurrences":
+		return isRead
+	case "bookings":
+		return true
+	case "members":
+		// /api/v1/members/{id} and its sub-resources
+		return isRead && len(segments) >= 4 && segments[3] == own
+	case "payments":
+		// /api/v1/payments/member/{id}
+		return isRead && len(segments) == 5 && segments[3] == "member" && segments[4] == own
+	default:
+		return false
+	}
+}
@@ -1,26 +1,57 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/password"
 	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/repository"
 	"github.com/golang-jwt/jwt/v5"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // AuthService handles authentication operations
 type AuthService struct {
-	jwtSecret      []byte
-	jwtExpireHours int
-	adminRepo      *repository.AdminRepository
+	keys          *KeyManager
+	accessExpiry  time.Duration
+	refreshExpiry time.Duration
+	adminRepo     *repository.AdminRepository
+	tokenRepo     *repository.TokenRepository
+	throttleRepo  *repository.ThrottleRepository
+	challengeRepo *repository.ChallengeRepository
+	memberRepo    *repository.MemberAccountRepository
+	clientRepo    *repository.ClientRepository
+	historyRepo   *repository.PasswordHistoryRepository
+	auditStore    audit.Store
+	hasher        *password.Hasher
+	policy        *password.Policy
+	lockout       LockoutPolicy
+	mfaIssuer     string
 }
 
+// Admin management errors
+var (
+	ErrInvalidRole          = errors.New("invalid role")
+	ErrInvalidEmail         = errors.New("invalid email address")
+	ErrAdminNotFound        = errors.New("admin not found")
+	ErrAdminActive          = errors.New("admin is already active")
+	ErrCannotDeactivateSelf = errors.New("admins cannot deactivate their own account")
+	ErrLastOwner            = repository.ErrLastOwner
+)
+
 // Claims represents JWT claims
 type Claims struct {
-	Username string `json:"username"`
+	Username string   `json:"username"`
+	Roles    []string `json:"roles"`
+	MemberID uint     `json:"member_id,omitempty"`
+	FamilyID string   `json:"fid,omitempty"`
+	ClientID string   `json:"client_id,omitempty"`
+	Scope    string   `json:"scope,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -45,27 +76,65 @@ func handleDatabaseError(err error) error {
 }
 
 // NewAuthService creates a new auth service instance
-func NewAuthService(jwtSecret string, jwtExpireHours int, adminRepo *repository.AdminRepository) *AuthService {
+func NewAuthService(keys *KeyManager, accessExpiry, refreshExpiry time.Duration, adminRepo *repository.AdminRepository, tokenRepo *repository.TokenRepository, throttleRepo *repository.ThrottleRepository, challengeRepo *repository.ChallengeRepository, memberRepo *repository.MemberAccountRepository, clientRepo *repository.ClientRepository, historyRepo *repository.PasswordHistoryRepository, auditStore audit.Store, hasher *password.Hasher, policy *password.Policy, lockout LockoutPolicy, mfaIssuer string) *AuthService {
 	return &AuthService{
-		jwtSecret:      []byte(jwtSecret),
-		jwtExpireHours: jwtExpireHours,
-		adminRepo:      adminRepo,
+		keys:          keys,
+		accessExpiry:  accessExpiry,
+		refreshExpiry: refreshExpiry,
+		adminRepo:     adminRepo,
+		tokenRepo:     tokenRepo,
+		throttleRepo:  throttleRepo,
+		challengeRepo: challengeRepo,
+		memberRepo:    memberRepo,
+		clientRepo:    clientRepo,
+		historyRepo:   historyRepo,
+		auditStore:    auditStore,
+		hasher:        hasher,
+		policy:        policy,
+		lockout:       lockout,
+		mfaIssuer:     mfaIssuer,
 	}
 }
 
-// Login validates user credentials and generates JWT token
-func (s *AuthService) Login(username, password string) (string, error) {
+// Login validates user credentials and starts a new token family. Failed
+// attempts are tracked per username and client IP.
+func (s *AuthService) Login(username, password string, client ClientInfo) (*LoginResult, error) {
+	keys := throttleKeys(username, client.IP)
+	if err := s.checkThrottle(keys); err != nil {
+		return nil, err
+	}
+
 	// Get admin user from database
 	admin, err := s.adminRepo.GetByUsername(username)
 	if err != nil {
-		return "", fmt.Errorf("invalid username or password")
+		s.recordFailure(keys, nil)
+		return nil, fmt.Errorf("invalid username or password")
 	}
 
-	// Check password
-	if !s.CheckPasswordHash(password, admin.Password) {
-		return "", fmt.Errorf("invalid username or password")
+	// Check password. A locked account is reported like a wrong password,
+	// after the same work, so it does not tell that the username exists.
+	ok, rehash := s.hasher.Verify(password, admin.Password)
+	if admin.IsLocked() {
+		s.recordAttempt(keys)
+		return nil, fmt.Errorf("invalid username or password")
+	}
+	if !ok {
+		s.recordFailure(keys, admin)
+		return nil, fmt.Errorf("invalid username or password")
+	}
+	if rehash {
+		s.rehashAdminPassword(admin, password)
 	}
 
+	// Admins with two-factor authentication get a challenge instead of
+	// tokens. Their failure counters are only cleared once the code is
+	// verified, so logging in again does not reset guessing of the code.
+	if admin.MFAEnabled {
+		return s.createChallenge(admin)
+	}
+
+	s.recordSuccess(username, admin)
+
 	// Update last login
 	err = s.adminRepo.UpdateLastLogin(admin.ID)
 	if err != nil {
@@ -73,59 +142,120 @@ func (s *AuthService) Login(username, password string) (string, error) {
 		fmt.Printf("Warning: Failed to update last login for user %s: %v\n", username, err)
 	}
 
-	// Create JWT token
-	claims := Claims{
-		Username: username,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(s.jwtExpireHours) * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "fitness-center-auth",
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(s.jwtSecret)
+	tokens, err := s.startFamily(adminSubject(admin), client)
 	if err != nil {
-		return "", fmt.Errorf("error creating token: %v", err)
+		return nil, err
 	}
-
-	return tokenString, nil
+	return &LoginResult{Tokens: tokens}, nil
 }
 
 // ValidateToken validates JWT token
 func (s *AuthService) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.jwtSecret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, s.keys.Keyfunc,
+		jwt.WithValidMethods([]string{AlgorithmRS256, AlgorithmEdDSA}))
 
 	if err != nil {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	// Service tokens have no family; they are checked against their client
+	if claims.IsService() {
+		if err := s.validateClientToken(claims); err != nil {
+			return nil, err
+		}
 		return claims, nil
 	}
 
-	return nil, fmt.Errorf("invalid token")
+	// Reject tokens whose family has been revoked by logout or token reuse
+	family, err := s.tokenRepo.GetFamily(claims.FamilyID)
+	if err != nil || family.IsRevoked() {
+		return nil, ErrTokenRevoked
+	}
+	if family.LastSeenAt == nil || time.Since(*family.LastSeenAt) > sessionTouchInterval {
+		if err := s.tokenRepo.TouchFamily(family.ID, ""); err != nil {
+			fmt.Printf("Warning: Failed to update session %s: %v\n", family.ID, err)
+		}
+	}
+
+	return claims, nil
+}
+
+// HasPermission reports whether the token claims grant the given permission.
+// Service tokens are limited to their scopes.
+func (c *Claims) HasPermission(perm model.Permission) bool {
+	if c.IsService() {
+		return containsString(strings.Fields(c.Scope), string(perm))
+	}
+	return model.AnyCan(c.Roles, perm)
 }
 
-// HashPassword hashes password
+// IsMember reports whether the token was issued to a member account
+func (c *Claims) IsMember() bool {
+	return c.MemberID != 0
+}
+
+// IsService reports whether the token was issued to a service client
+func (c *Claims) IsService() bool {
+	return c.ClientID != ""
+}
+
+// HashPassword hashes password with the preferred algorithm
 func (s *AuthService) HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
-	return string(bytes), err
+	return s.hasher.Hash(password)
 }
 
 // CheckPasswordHash compares password with hash
 func (s *AuthService) CheckPasswordHash(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+	ok, _ := s.hasher.Verify(password, hash)
+	return ok
 }
 
-// CreateInitialAdmin creates the initial admin user if it doesn't exist
+// CreateInitialAdmin creates the initial admin user if it doesn't exist.
+// The initial admin is always an owner.
 func (s *AuthService) CreateInitialAdmin(username, password, email string) error {
+	if _, err := s.adminRepo.FindByUsername(username); err == nil {
+		return nil
+	}
+
+	if err := s.CreateAdmin(username, password, email, model.RoleOwner); err != nil {
+		return err
+	}
+
+	// Not created through the API, so the audit middleware does not see it
+	after, _ := json.Marshal(map[string]interface{}{
+		"username":  username,
+		"email":     email,
+		"role":      model.RoleOwner,
+		"is_active": true,
+	})
+	err := s.auditStore.Record(context.Background(), &audit.Entry{
+		Actor:        "system",
+		Action:       audit.ActionCreate,
+		ResourceType: "admin",
+		ResourceID:   username,
+		After:        after,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		fmt.Printf("Warning: Failed to record initial admin creation: %v\n", err)
+	}
+	return nil
+}
+
+// CreateAdmin creates a new admin user with the given role
+func (s *AuthService) CreateAdmin(username, password, email string, role model.Role) error {
+	if !role.IsValid() {
+		return ErrInvalidRole
+	}
+	if err := s.policy.Check(password, username); err != nil {
+		return err
+	}
+
 	// Hash password
 	hashedPassword, err := s.HashPassword(password)
 	if err != nil {
@@ -137,6 +267,7 @@ func (s *AuthService) CreateInitialAdmin(username, password, email string) error
 		Username:  username,
 		Password:  hashedPassword,
 		Email:     email,
+		Role:      role,
 		IsActive:  true,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
@@ -149,7 +280,7 @@ func (s *AuthService) CreateInitialAdmin(username, password, email string) error
 		return handleDatabaseError(err)
 	}
 
-	fmt.Printf("Admin user created: %s\n", username)
+	fmt.Printf("Admin user created: %s (%s)\n", username, role)
 	return nil
 }
 
@@ -166,6 +297,10 @@ func (s *AuthService) UpdatePassword(username, currentPassword, newPassword stri
 		return fmt.Errorf("current password is incorrect")
 	}
 
+	if err := s.checkNewPassword(newPassword, admin.Username, admin.Password, admin.ID, 0); err != nil {
+		return err
+	}
+
 	// Hash new password
 	hashedPassword, err := s.HashPassword(newPassword)
 	if err != nil {
@@ -173,6 +308,7 @@ func (s *AuthService) UpdatePassword(username, currentPassword, newPassword stri
 	}
 
 	// Update password
+	previous := admin.Password
 	admin.Password = hashedPassword
 	admin.UpdatedAt = time.Now()
 
@@ -180,31 +316,156 @@ func (s *AuthService) UpdatePassword(username, currentPassword, newPassword stri
 	if err != nil {
 		return fmt.Errorf("error updating password: %v", err)
 	}
+	s.rememberPassword(previous, admin.ID, 0)
 
 	fmt.Printf("Password updated for user: %s\n", username)
 	return nil
 }
 
-// ListAdmins returns all active admin users
-func (s *AuthService) ListAdmins() ([]model.Admin, error) {
-	return s.adminRepo.List()
+// SetPassword sets a new password for an admin without asking for the
+// current one, for recovering locked-out accounts. The lockout and the login
+// throttle of the username are cleared and all sessions of the admin are
+// revoked.
+func (s *AuthService) SetPassword(username, newPassword string) error {
+	admin, err := s.adminRepo.FindByUsername(username)
+	if err != nil {
+		return ErrAdminNotFound
+	}
+
+	if err := s.checkNewPassword(newPassword, admin.Username, admin.Password, admin.ID, 0); err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("error hashing new password: %v", err)
+	}
+
+	if err := s.adminRepo.ResetPassword(admin.ID, hashedPassword); err != nil {
+		return fmt.Errorf("error updating password: %v", err)
+	}
+	s.rememberPassword(admin.Password, admin.ID, 0)
+
+	if err := s.throttleRepo.Delete("user:" + strings.ToLower(admin.Username)); err != nil {
+		fmt.Printf("Warning: Failed to reset login throttle for user %s: %v\n", username, err)
+	}
+	if err := s.tokenRepo.RevokeAllFamilies(admin.ID); err != nil {
+		fmt.Printf("Warning: Failed to revoke sessions for user %s: %v\n", username, err)
+	}
+
+	fmt.Printf("Password set for user: %s\n", username)
+	return nil
+}
+
+// GetAdmin returns an admin user, whether active or not
+func (s *AuthService) GetAdmin(username string) (*model.Admin, error) {
+	admin, err := s.adminRepo.FindByUsername(username)
+	if err != nil {
+		return nil, ErrAdminNotFound
+	}
+	return admin, nil
+}
+
+// ListAdmins returns a page of admin users matching the filter and the total
+// number of matches
+func (s *AuthService) ListAdmins(filter repository.AdminFilter, page, pageSize int) ([]model.Admin, int64, error) {
+	admins, total, err := s.adminRepo.ListPaginated(filter, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error listing admins: %v", err)
+	}
+	return admins, total, nil
 }
 
-// DeactivateAdmin deactivates an admin user
-func (s *AuthService) DeactivateAdmin(username string) error {
+// UpdateAdmin changes the email and/or role of an admin. Nil fields are left
+// unchanged. The last active owner cannot be demoted.
+func (s *AuthService) UpdateAdmin(ctx context.Context, username string, email *string, role *model.Role) (*model.Admin, error) {
+	admin, err := s.adminRepo.FindByUsername(username)
+	if err != nil {
+		return nil, ErrAdminNotFound
+	}
+	audit.Before(ctx, admin)
+
+	if email != nil {
+		if !strings.Contains(*email, "@") {
+			return nil, ErrInvalidEmail
+		}
+		admin.Email = strings.TrimSpace(*email)
+	}
+	if role != nil {
+		if !role.IsValid() {
+			return nil, ErrInvalidRole
+		}
+		admin.Role = *role
+	}
+	admin.UpdatedAt = time.Now()
+
+	if err := s.adminRepo.SaveKeepingOwner(admin); err != nil {
+		if errors.Is(err, repository.ErrLastOwner) {
+			return nil, ErrLastOwner
+		}
+		return nil, handleDatabaseError(err)
+	}
+	audit.After(ctx, admin)
+
+	fmt.Printf("Admin updated: %s\n", username)
+	return admin, nil
+}
+
+// DeactivateAdmin deactivates an admin user and revokes their sessions.
+// Admins cannot deactivate themselves or the last active owner.
+func (s *AuthService) DeactivateAdmin(ctx context.Context, actor, username string) error {
+	if actor == username {
+		return ErrCannotDeactivateSelf
+	}
+
 	admin, err := s.adminRepo.GetByUsername(username)
 	if err != nil {
-		return fmt.Errorf("admin not found")
+		return ErrAdminNotFound
 	}
+	audit.Before(ctx, admin)
 
 	admin.IsActive = false
 	admin.UpdatedAt = time.Now()
 
-	err = s.adminRepo.Update(admin)
+	err = s.adminRepo.SaveKeepingOwner(admin)
 	if err != nil {
+		if errors.Is(err, repository.ErrLastOwner) {
+			return ErrLastOwner
+		}
 		return fmt.Errorf("error deactivating admin: %v", err)
 	}
+	audit.After(ctx, admin)
+
+	if err := s.tokenRepo.RevokeAllFamilies(admin.ID); err != nil {
+		fmt.Printf("Warning: Failed to revoke sessions for user %s: %v\n", username, err)
+	}
 
 	fmt.Printf("Admin deactivated: %s\n", username)
 	return nil
 }
+
+// ReactivateAdmin restores a deactivated admin user. Lockout counters are
+// cleared so the admin can log in straight away.
+func (s *AuthService) ReactivateAdmin(ctx context.Context, username string) error {
+	admin, err := s.adminRepo.FindByUsername(username)
+	if err != nil {
+		return ErrAdminNotFound
+	}
+	if admin.IsActive {
+		return ErrAdminActive
+	}
+	audit.Before(ctx, admin)
+
+	admin.IsActive = true
+	admin.FailedLoginAttempts = 0
+	admin.LockedUntil = nil
+	admin.UpdatedAt = time.Now()
+
+	if err := s.adminRepo.Update(admin); err != nil {
+		return fmt.Errorf("error reactivating admin: %v", err)
+	}
+	audit.After(ctx, admin)
+
+	fmt.Printf("Admin reactivated: %s\n", username)
+	return nil
+}
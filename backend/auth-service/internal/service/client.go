@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Service client errors
+var (
+	ErrInvalidClientName  = errors.New("client name must be 3-50 lowercase letters, digits or dashes")
+	ErrInvalidScope       = errors.New("invalid scope")
+	ErrClientExists       = errors.New("client already exists")
+	ErrClientNotFound     = errors.New("client not found")
+	ErrInvalidClient      = errors.New("invalid client credentials")
+	ErrServiceTokenLogout = errors.New("service tokens cannot be logged out")
+)
+
+var clientNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{2,49}$`)
+
+// ClientToken is the result of a successful client credentials grant. Service
+// tokens have no refresh token; clients request a new one when it expires.
+type ClientToken struct {
+	AccessToken string
+	ExpiresIn   int
+	Scope       string
+}
+
+// CreateClient registers a new service client and returns it together with
+// its secret. The secret is only available at this point.
+func (s *AuthService) CreateClient(name, description string, scopes []string, createdBy string) (*model.ServiceClient, string, error) {
+	name = strings.TrimSpace(name)
+	if !clientNamePattern.MatchString(name) {
+		return nil, "", ErrInvalidClientName
+	}
+	scope, err := normalizeScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err := s.clientRepo.GetByName(name); err == nil {
+		return nil, "", ErrClientExists
+	}
+
+	secret, err := generateToken(32)
+	if err != nil {
+		return nil, "", fmt.Errorf("error creating client secret: %v", err)
+	}
+
+	now := time.Now()
+	client := &model.ServiceClient{
+		Name:            name,
+		Description:     description,
+		SecretHash:      hashToken(secret),
+		Scopes:          scope,
+		IsActive:        true,
+		CreatedBy:       createdBy,
+		SecretRotatedAt: now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.clientRepo.Create(client); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "duplicate key") {
+			return nil, "", ErrClientExists
+		}
+		return nil, "", fmt.Errorf("error creating client: %v", err)
+	}
+
+	fmt.Printf("Service client %s created by %s\n", name, createdBy)
+	return client, secret, nil
+}
+
+// GetClient returns a service client by name
+func (s *AuthService) GetClient(name string) (*model.ServiceClient, error) {
+	client, err := s.clientRepo.GetByName(name)
+	if err != nil {
+		return nil, ErrClientNotFound
+	}
+	return client, nil
+}
+
+// ListClients returns all service clients
+func (s *AuthService) ListClients() ([]model.ServiceClient, error) {
+	return s.clientRepo.List()
+}
+
+// RotateClientSecret replaces the secret of a service client. Tokens issued
+// with the previous secret stop working immediately.
+func (s *AuthService) RotateClientSecret(ctx context.Context, name string) (string, error) {
+	client, err := s.clientRepo.GetByName(name)
+	if err != nil {
+		return "", ErrClientNotFound
+	}
+	audit.Before(ctx, client)
+
+	secret, err := generateToken(32)
+	if err != nil {
+		return "", fmt.Errorf("error creating client secret: %v", err)
+	}
+
+	client.SecretHash = hashToken(secret)
+	client.SecretRotatedAt = time.Now()
+	client.UpdatedAt = time.Now()
+	if err := s.clientRepo.Update(client); err != nil {
+		return "", fmt.Errorf("error rotating client secret: %v", err)
+	}
+	audit.After(ctx, client)
+
+	fmt.Printf("Secret rotated for service client %s\n", name)
+	return secret, nil
+}
+
+// DeactivateClient disables a service client and the tokens issued to it
+func (s *AuthService) DeactivateClient(ctx context.Context, name string) error {
+	client, err := s.clientRepo.GetByName(name)
+	if err != nil {
+		return ErrClientNotFound
+	}
+	audit.Before(ctx, client)
+
+	client.IsActive = false
+	client.UpdatedAt = time.Now()
+	if err := s.clientRepo.Update(client); err != nil {
+		return fmt.Errorf("error deactivating client: %v", err)
+	}
+	audit.After(ctx, client)
+
+	fmt.Printf("Service client %s deactivated\n", name)
+	return nil
+}
+
+// IssueClientToken performs the client credentials grant. The requested
+// scope must be a subset of the client's scopes; an empty scope requests all
+// of them. Failed attempts are throttled per client.
+func (s *AuthService) IssueClientToken(name, secret, scope string) (*ClientToken, error) {
+	keys := []string{"client:" + strings.ToLower(name)}
+	if err := s.checkThrottle(keys); err != nil {
+		return nil, err
+	}
+
+	client, err := s.clientRepo.GetByName(name)
+	if err != nil || !client.IsActive ||
+		subtle.ConstantTimeCompare([]byte(hashToken(secret)), []byte(client.SecretHash)) != 1 {
+		s.recordFailure(keys, nil)
+		return nil, ErrInvalidClient
+	}
+
+	granted := client.ScopeList()
+	if requested := strings.Fields(scope); len(requested) > 0 {
+		for _, r := range requested {
+			if !containsString(granted, r) {
+				return nil, ErrInvalidScope
+			}
+		}
+		granted = requested
+	}
+
+	if err := s.throttleRepo.Delete(keys[0]); err != nil {
+		fmt.Printf("Warning: Failed to reset throttle for client %s: %v\n", name, err)
+	}
+	if err := s.clientRepo.UpdateLastUsed(client.ID); err != nil {
+		fmt.Printf("Warning: Failed to update last use of client %s: %v\n", name, err)
+	}
+
+	tokenID, err := generateToken(16)
+	if err != nil {
+		return nil, fmt.Errorf("error creating token: %v", err)
+	}
+
+	now := time.Now()
+	claims := Claims{
+		Username: client.Name,
+		Roles:    []string{string(model.RoleService)},
+		ClientID: client.Name,
+		Scope:    strings.Join(granted, " "),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			Subject:   client.Name,
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.accessExpiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    "fitness-center-auth",
+		},
+	}
+
+	accessToken, err := s.keys.Sign(claims)
+	if err != nil {
+		return nil, fmt.Errorf("error creating token: %v", err)
+	}
+
+	return &ClientToken{
+		AccessToken: accessToken,
+		ExpiresIn:   int(s.accessExpiry.Seconds()),
+		Scope:       claims.Scope,
+	}, nil
+}
+
+// validateClientToken rejects service tokens of deactivated clients and
+// tokens issued before the client's secret was last rotated
+func (s *AuthService) validateClientToken(claims *Claims) error {
+	client, err := s.clientRepo.GetByName(claims.ClientID)
+	if err != nil || !client.IsActive {
+		return ErrTokenRevoked
+	}
+	if claims.IssuedAt == nil || claims.IssuedAt.Time.Before(client.SecretRotatedAt.Truncate(time.Second)) {
+		return ErrTokenRevoked
+	}
+	return nil
+}
+
+// normalizeScopes validates requested client scopes and returns them as a
+// space separated list without duplicates
+func normalizeScopes(scopes []string) (string, error) {
+	var valid []string
+	for _, scope := range scopes {
+		for _, s := range strings.Fields(scope) {
+			if !model.Permission(s).IsServiceScope() {
+				return "", fmt.Errorf("%w: %s", ErrInvalidScope, s)
+			}
+			if !containsString(valid, s) {
+				valid = append(valid, s)
+			}
+		}
+	}
+	if len(valid) == 0 {
+		return "", fmt.Errorf("%w: at least one scope is required", ErrInvalidScope)
+	}
+	return strings.Join(valid, " "), nil
+}
+
+// containsString reports whether the slice contains the value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,377 @@
+package service
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/repository"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// keyCacheTTL is how long loaded keys are used before the database is checked
+// for keys rotated by another replica
+const keyCacheTTL = time.Minute
+
+// JWKSMaxAge is how long clients may cache the JWKS
+const JWKSMaxAge = 5 * time.Minute
+
+// keyPublishDelay is how long a new key is published before it signs tokens,
+// so every replica and every cached JWKS knows it by then
+const keyPublishDelay = JWKSMaxAge + keyCacheTTL
+
+// ErrUnknownKey is returned when a token references a key that is not known
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// signingKey is a parsed signing key
+type signingKey struct {
+	kid         string
+	alg         string
+	private     crypto.Signer
+	public      crypto.PublicKey
+	activatesAt time.Time
+	rotatedAt   *time.Time
+}
+
+// signs reports whether the key signs new tokens at the given time
+func (k *signingKey) signs(at time.Time) bool {
+	return !k.activatesAt.After(at) && (k.rotatedAt == nil || k.rotatedAt.After(at))
+}
+
+// method returns the JWT signing method of the key
+func (k *signingKey) method() jwt.SigningMethod {
+	if k.alg == AlgorithmEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// JWK is a public key in JSON Web Key format
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// KeyManager keeps the signing keys shared by all auth service replicas
+type KeyManager struct {
+	repo      *repository.KeyRepository
+	algorithm string
+	grace     time.Duration
+
+	mu       sync.RWMutex
+	keys     []*signingKey
+	loadedAt time.Time
+}
+
+// NewKeyManager creates a new key manager. Rotated keys stay published until
+// the last token they signed has expired: one access token lifetime, plus
+// the time a replica may keep signing with a cached key, plus the time a
+// client may cache the JWKS.
+func NewKeyManager(repo *repository.KeyRepository, algorithm string, accessExpiry time.Duration) (*KeyManager, error) {
+	if algorithm != AlgorithmRS256 && algorithm != AlgorithmEdDSA {
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", algorithm)
+	}
+	return &KeyManager{
+		repo:      repo,
+		algorithm: algorithm,
+		grace:     accessExpiry + keyCacheTTL + JWKSMaxAge,
+	}, nil
+}
+
+// EnsureSigningKey creates a first signing key when none exists, or rotates
+// when the current key uses a different algorithm than configured. A first
+// key signs immediately, as no token can reference an earlier one.
+func (m *KeyManager) EnsureSigningKey() error {
+	if err := m.reload(); err != nil {
+		return err
+	}
+
+	current, err := m.current()
+	if err != nil {
+		_, _, err = m.rotate(0)
+		return err
+	}
+	if current.alg == m.algorithm {
+		return nil
+	}
+	if next := m.next(); next != nil && next.alg == m.algorithm {
+		return nil
+	}
+
+	_, _, err = m.Rotate()
+	return err
+}
+
+// Rotate generates a new signing key and returns its kid and the time it
+// starts signing. The new key is published in the JWKS first, while the
+// current key keeps signing; previous keys stay published until tokens
+// signed with them have expired.
+func (m *KeyManager) Rotate() (string, time.Time, error) {
+	return m.rotate(keyPublishDelay)
+}
+
+// rotate generates a new signing key that starts signing after delay
+func (m *KeyManager) rotate(delay time.Duration) (string, time.Time, error) {
+	key, err := generateSigningKey(m.algorithm, time.Now().Add(delay))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("error generating signing key: %v", err)
+	}
+
+	if err := m.repo.Rotate(key); err != nil {
+		return "", time.Time{}, fmt.Errorf("error storing signing key: %v", err)
+	}
+
+	if err := m.reload(); err != nil {
+		return "", time.Time{}, err
+	}
+
+	fmt.Printf("Signing key rotated, new key: %s (%s), signing from %s\n", key.KID, key.Algorithm, key.ActivatesAt.Format(time.RFC3339))
+	return key.KID, key.ActivatesAt, nil
+}
+
+// Sign signs the claims with the current signing key
+func (m *KeyManager) Sign(claims jwt.Claims) (string, error) {
+	key, err := m.current()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.method(), claims)
+	token.Header["kid"] = key.kid
+	return token.SignedString(key.private)
+}
+
+// Keyfunc resolves the verification key of a token from its kid header
+func (m *KeyManager) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, ErrUnknownKey
+	}
+
+	key, err := m.lookup(kid)
+	if err != nil {
+		return nil, err
+	}
+	if token.Method.Alg() != key.alg {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.public, nil
+}
+
+// JWKS returns all published verification keys
+func (m *KeyManager) JWKS() ([]JWK, error) {
+	if err := m.reloadIfStale(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	jwks := make([]JWK, 0, len(m.keys))
+	for _, key := range m.keys {
+		jwk := JWK{Kid: key.kid, Alg: key.alg, Use: "sig"}
+		switch pub := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		jwks = append(jwks, jwk)
+	}
+	return jwks, nil
+}
+
+// current returns the key used to sign new tokens
+func (m *KeyManager) current() (*signingKey, error) {
+	if err := m.reloadIfStale(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+	for _, key := range m.keys {
+		if key.signs(now) {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("no signing key available")
+}
+
+// next returns the published key that has not started signing yet, if any
+func (m *KeyManager) next() *signingKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+	for _, key := range m.keys {
+		if key.activatesAt.After(now) {
+			return key
+		}
+	}
+	return nil
+}
+
+// lookup finds a verification key by kid, reloading once on a miss so keys
+// rotated by another replica are picked up
+func (m *KeyManager) lookup(kid string) (*signingKey, error) {
+	if err := m.reloadIfStale(); err != nil {
+		return nil, err
+	}
+	if key := m.find(kid); key != nil {
+		return key, nil
+	}
+
+	if err := m.reload(); err != nil {
+		return nil, err
+	}
+	if key := m.find(kid); key != nil {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+// find returns the cached key with the given kid
+func (m *KeyManager) find(kid string) *signingKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, key := range m.keys {
+		if key.kid == kid {
+			return key
+		}
+	}
+	return nil
+}
+
+// reloadIfStale reloads the keys when the cache is older than keyCacheTTL
+func (m *KeyManager) reloadIfStale() error {
+	m.mu.RLock()
+	stale := time.Since(m.loadedAt) > keyCacheTTL
+	m.mu.RUnlock()
+
+	if !stale {
+		return nil
+	}
+	return m.reload()
+}
+
+// reload loads all usable keys from the database
+func (m *KeyManager) reload() error {
+	stored, err := m.repo.ListUsable(time.Now().Add(-m.grace))
+	if err != nil {
+		return fmt.Errorf("error loading signing keys: %v", err)
+	}
+
+	keys := make([]*signingKey, 0, len(stored))
+	for _, k := range stored {
+		key, err := parseSigningKey(k)
+		if err != nil {
+			fmt.Printf("Warning: Skipping signing key %s: %v\n", k.KID, err)
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	m.mu.Lock()
+	m.keys = keys
+	m.loadedAt = time.Now()
+	m.mu.Unlock()
+	return nil
+}
+
+// generateSigningKey creates a new key pair for the algorithm that starts
+// signing at activatesAt
+func generateSigningKey(algorithm string, activatesAt time.Time) (*model.SigningKey, error) {
+	var private crypto.Signer
+	var err error
+
+	switch algorithm {
+	case AlgorithmRS256:
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	case AlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	kid, err := generateToken(12)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SigningKey{
+		KID:         kid,
+		Algorithm:   algorithm,
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})),
+		PublicKey:   string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})),
+		CreatedAt:   time.Now(),
+		ActivatesAt: activatesAt,
+	}, nil
+}
+
+// parseSigningKey decodes a stored key
+func parseSigningKey(k model.SigningKey) (*signingKey, error) {
+	block, _ := pem.Decode([]byte(k.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("invalid private key PEM")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	private, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type")
+	}
+
+	return &signingKey{
+		kid:         k.KID,
+		alg:         k.Algorithm,
+		private:     private,
+		public:      private.Public(),
+		activatesAt: k.ActivatesAt,
+		rotatedAt:   k.RotatedAt,
+	}, nil
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+)
+
+// Lockout errors
+var (
+	ErrAccountLocked = errors.New("account is temporarily locked due to too many failed login attempts")
+)
+
+// TooManyAttemptsError is returned when a username or client IP is in its
+// backoff window after failed logins
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, retry in %d seconds", int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+// LockoutPolicy configures failed login tracking
+type LockoutPolicy struct {
+	// MaxFailedAttempts is the number of consecutive failures that locks an account
+	MaxFailedAttempts int
+	// LockoutDuration is how long an account stays locked
+	LockoutDuration time.Duration
+	// BackoffBase is the delay after the first failure; it doubles with each
+	// further failure up to BackoffMax
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// FailureWindow is how long failures are remembered. A failure more than
+	// FailureWindow after the previous one starts counting from one again.
+	FailureWindow time.Duration
+}
+
+// throttleKeys returns the throttle keys for a login attempt
+func throttleKeys(username, ip string) []string {
+	keys := []string{"user:" + strings.ToLower(username)}
+	if ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	return keys
+}
+
+// checkThrottle returns an error when any of the keys is still in backoff
+func (s *AuthService) checkThrottle(keys []string) error {
+	now := time.Now()
+	for _, key := range keys {
+		throttle, err := s.throttleRepo.Get(key)
+		if err != nil {
+			return fmt.Errorf("error checking login attempts: %v", err)
+		}
+		if throttle.BlockedUntil != nil && throttle.BlockedUntil.After(now) {
+			return &TooManyAttemptsError{RetryAfter: throttle.BlockedUntil.Sub(now)}
+		}
+	}
+	return nil
+}
+
+// failureCutoff returns the time before which failures have decayed. A
+// failure after an earlier one from before the cutoff counts as the first.
+func (s *AuthService) failureCutoff(now time.Time) time.Time {
+	if s.lockout.FailureWindow <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-s.lockout.FailureWindow)
+}
+
+// backoff returns the delay after the given number of consecutive failures
+func (s *AuthService) backoff(failures int) time.Duration {
+	delay := s.lockout.BackoffBase
+	for i := 1; i < failures && delay < s.lockout.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > s.lockout.BackoffMax {
+		delay = s.lockout.BackoffMax
+	}
+	return delay
+}
+
+// recordFailure registers a failed login for the throttle keys and, when the
+// account exists, counts towards its lockout. The counters are incremented
+// in the database, so concurrent failures are all counted.
+func (s *AuthService) recordFailure(keys []string, admin *model.Admin) {
+	s.recordAttempt(keys)
+
+	if admin == nil {
+		return
+	}
+
+	now := time.Now()
+	lockedUntil := now.Add(s.lockout.LockoutDuration)
+	locked, err := s.adminRepo.RecordFailedLogin(admin.ID, now, s.failureCutoff(now), s.lockout.MaxFailedAttempts, lockedUntil)
+	if err != nil {
+		fmt.Printf("Warning: Failed to record failed login for user %s: %v\n", admin.Username, err)
+		return
+	}
+	if locked {
+		fmt.Printf("Admin locked until %s: %s\n", lockedUntil.Format(time.RFC3339), admin.Username)
+	}
+}
+
+// recordAttempt counts an attempt against each throttle key and blocks the
+// key for its backoff
+func (s *AuthService) recordAttempt(keys []string) {
+	now := time.Now()
+	for _, key := range keys {
+		failures, err := s.throttleRepo.AddFailure(key, now, s.failureCutoff(now))
+		if err != nil {
+			fmt.Printf("Warning: Failed to save login throttle %s: %v\n", key, err)
+			continue
+		}
+		if err := s.throttleRepo.Block(key, now.Add(s.backoff(failures))); err != nil {
+			fmt.Printf("Warning: Failed to save login throttle %s: %v\n", key, err)
+		}
+	}
+}
+
+// recordSuccess clears the failure counters of the username after a
+// successful login. The IP throttle is left alone so that one valid account
+// cannot be used to reset guessing against others from the same address.
+func (s *AuthService) recordSuccess(username string, admin *model.Admin) {
+	if err := s.throttleRepo.Delete("user:" + strings.ToLower(username)); err != nil {
+		fmt.Printf("Warning: Failed to reset login throttle for user %s: %v\n", username, err)
+	}
+
+	if admin.FailedLoginAttempts == 0 && admin.LockedUntil == nil {
+		return
+	}
+	if err := s.adminRepo.ClearLockout(admin.ID); err != nil {
+		fmt.Printf("Warning: Failed to reset failed logins for user %s: %v\n", username, err)
+	}
+}
+
+// UnlockAdmin clears the lock and failure counters of an admin account
+func (s *AuthService) UnlockAdmin(ctx context.Context, username string) error {
+	admin, err := s.adminRepo.GetByUsername(username)
+	if err != nil {
+		return fmt.Errorf("admin not found")
+	}
+	audit.Before(ctx, admin)
+
+	if err := s.adminRepo.ClearLockout(admin.ID); err != nil {
+		return fmt.Errorf("error unlocking admin: %v", err)
+	}
+	admin.FailedLoginAttempts = 0
+	admin.LockedUntil = nil
+	admin.UpdatedAt = time.Now()
+	if err := s.throttleRepo.Delete("user:" + strings.ToLower(username)); err != nil {
+		return fmt.Errorf("error unlocking admin: %v", err)
+	}
+	audit.After(ctx, admin)
+
+	fmt.Printf("Admin unlocked: %s\n", username)
+	return nil
+}
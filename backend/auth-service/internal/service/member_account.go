@@ -0,0 +1,211 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/client"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/mail"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/repository"
+)
+
+// Member account errors
+var (
+	ErrInvalidInvite        = errors.New("invalid or expired invitation")
+	ErrMemberAccountExists  = errors.New("member already has an account")
+	ErrInvalidMemberRequest = errors.New("member ID and email are required")
+	ErrMemberNotFound       = errors.New("member not found")
+	ErrMemberNotActive      = errors.New("member is not active")
+	ErrMemberServiceDown    = errors.New("member service is unavailable")
+)
+
+// MemberAccountService handles member invitations, registration and login
+type MemberAccountService struct {
+	authService  *AuthService
+	memberRepo   *repository.MemberAccountRepository
+	members      *client.MemberClient
+	mailer       mail.Mailer
+	inviteExpiry time.Duration
+	inviteURL    string
+}
+
+// NewMemberAccountService creates a new member account service. inviteURL is
+// the frontend registration page; the token is appended as the "token" query
+// parameter. Member IDs are checked against the member service through
+// members, unless it is nil.
+func NewMemberAccountService(authService *AuthService, memberRepo *repository.MemberAccountRepository, members *client.MemberClient, mailer mail.Mailer, inviteExpiry time.Duration, inviteURL string) *MemberAccountService {
+	return &MemberAccountService{
+		authService:  authService,
+		memberRepo:   memberRepo,
+		members:      members,
+		mailer:       mailer,
+		inviteExpiry: inviteExpiry,
+		inviteURL:    inviteURL,
+	}
+}
+
+// InviteMember emails a registration link to a member. The member must be
+// an active member of the member service. Earlier unused invitations for the
+// same member stop working.
+func (s *MemberAccountService) InviteMember(memberID uint, email, invitedBy string) error {
+	email = strings.TrimSpace(email)
+	if memberID == 0 || email == "" {
+		return ErrInvalidMemberRequest
+	}
+
+	if err := s.checkMember(memberID); err != nil {
+		return err
+	}
+
+	if _, err := s.memberRepo.GetByMemberID(memberID); err == nil {
+		return ErrMemberAccountExists
+	}
+
+	token, err := generateToken(32)
+	if err != nil {
+		return fmt.Errorf("error creating invitation: %v", err)
+	}
+
+	err = s.memberRepo.CreateInvite(&model.MemberInvite{
+		TokenHash: hashToken(token),
+		MemberID:  memberID,
+		Email:     email,
+		InvitedBy: invitedBy,
+		ExpiresAt: time.Now().Add(s.inviteExpiry),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating invitation: %v", err)
+	}
+
+	u, err := url.Parse(s.inviteURL)
+	if err != nil {
+		return fmt.Errorf("invalid member invite URL: %v", err)
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+
+	body := fmt.Sprintf(`Hello,
+
+You have been invited to create your Fitness Center member account, where you
+can see your bookings and payments. Open the link below to choose a password.
+The link expires in %d hours.
+
+%s
+`, int(s.inviteExpiry.Hours()), u.String())
+
+	err = s.mailer.Send(mail.Message{
+		To:      email,
+		Subject: "Create your Fitness Center member account",
+		Body:    body,
+	})
+	if err != nil {
+		return fmt.Errorf("error sending invitation: %v", err)
+	}
+
+	fmt.Printf("Member %d invited by %s\n", memberID, invitedBy)
+	return nil
+}
+
+// checkMember makes sure the member service knows the member and that the
+// member is active
+func (s *MemberAccountService) checkMember(memberID uint) error {
+	if s.members == nil {
+		return nil
+	}
+
+	member, err := s.members.GetMember(memberID)
+	switch {
+	case errors.Is(err, client.ErrNotFound):
+		return ErrMemberNotFound
+	case err != nil:
+		fmt.Printf("Warning: Failed to check member %d with the member service: %v\n", memberID, err)
+		return ErrMemberServiceDown
+	}
+	if member.Status != client.MemberActive {
+		return ErrMemberNotActive
+	}
+	return nil
+}
+
+// Register creates a member account from an invitation
+func (s *MemberAccountService) Register(token, password string) (*model.MemberAccount, error) {
+	invite, err := s.memberRepo.GetInviteByHash(hashToken(token))
+	if err != nil {
+		return nil, ErrInvalidInvite
+	}
+	if invite.UsedAt != nil || time.Now().After(invite.ExpiresAt) {
+		return nil, ErrInvalidInvite
+	}
+	if err := s.authService.policy.Check(password, invite.Email); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := s.authService.HashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("error hashing password: %v", err)
+	}
+
+	account := &model.MemberAccount{
+		MemberID:  invite.MemberID,
+		Email:     invite.Email,
+		Password:  hashedPassword,
+		IsActive:  true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	accepted, err := s.memberRepo.AcceptInvite(invite.ID, account)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "duplicate key") {
+			return nil, ErrMemberAccountExists
+		}
+		return nil, fmt.Errorf("error creating member account: %v", err)
+	}
+	if !accepted {
+		return nil, ErrInvalidInvite
+	}
+
+	fmt.Printf("Member account created for member %d\n", account.MemberID)
+	return account, nil
+}
+
+// Login validates member credentials and starts a new token family. Failed
+// attempts share the throttling used for admin logins.
+func (s *MemberAccountService) Login(email, password string, client ClientInfo) (*TokenPair, error) {
+	keys := []string{"member:" + strings.ToLower(email)}
+	if client.IP != "" {
+		keys = append(keys, "ip:"+client.IP)
+	}
+	if err := s.authService.checkThrottle(keys); err != nil {
+		return nil, err
+	}
+
+	account, err := s.memberRepo.GetByEmail(email)
+	if err != nil {
+		s.authService.recordFailure(keys, nil)
+		return nil, fmt.Errorf("invalid email or password")
+	}
+	ok, rehash := s.authService.hasher.Verify(password, account.Password)
+	if !ok {
+		s.authService.recordFailure(keys, nil)
+		return nil, fmt.Errorf("invalid email or password")
+	}
+	if rehash {
+		s.authService.rehashMemberPassword(account, password)
+	}
+
+	if err := s.authService.throttleRepo.Delete(keys[0]); err != nil {
+		fmt.Printf("Warning: Failed to reset login throttle for member %s: %v\n", email, err)
+	}
+	if err := s.memberRepo.UpdateLastLogin(account.ID); err != nil {
+		fmt.Printf("Warning: Failed to update last login for member %s: %v\n", email, err)
+	}
+
+	return s.authService.startFamily(memberSubject(account), client)
+}
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+)
+
+const (
+	mfaChallengeExpiry      = 5 * time.Minute
+	mfaChallengeMaxAttempts = 5
+	recoveryCodeCount       = 10
+)
+
+// MFA errors
+var (
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnrolled    = errors.New("two-factor authentication enrolment has not been started")
+	ErrMFANotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrInvalidMFACode    = errors.New("invalid two-factor authentication code")
+	ErrInvalidChallenge  = errors.New("invalid or expired login challenge")
+)
+
+// LoginResult is the result of the first login step. Either Tokens is set, or
+// the admin has two-factor authentication enabled and ChallengeToken must be
+// exchanged for tokens with a valid code.
+type LoginResult struct {
+	Tokens             *TokenPair
+	ChallengeToken     string
+	ChallengeExpiresIn int
+}
+
+// MFAEnrolment holds the secret shown to the admin while enrolling
+type MFAEnrolment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// createChallenge issues a single-use MFA challenge for the admin
+func (s *AuthService) createChallenge(admin *model.Admin) (*LoginResult, error) {
+	token, err := generateToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("error creating login challenge: %v", err)
+	}
+
+	err = s.challengeRepo.Create(&model.MFAChallenge{
+		TokenHash: hashToken(token),
+		AdminID:   admin.ID,
+		ExpiresAt: time.Now().Add(mfaChallengeExpiry),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating login challenge: %v", err)
+	}
+
+	return &LoginResult{
+		ChallengeToken:     token,
+		ChallengeExpiresIn: int(mfaChallengeExpiry.Seconds()),
+	}, nil
+}
+
+// VerifyMFALogin completes a two-step login by exchanging a challenge and a
+// TOTP or recovery code for tokens. Wrong codes count as failed logins of the
+// admin, so the throttle and lockout also limit guessing of codes.
+func (s *AuthService) VerifyMFALogin(challengeToken, code string, client ClientInfo) (*TokenPair, error) {
+	challenge, err := s.challengeRepo.GetByHash(hashToken(challengeToken))
+	if err != nil {
+		return nil, ErrInvalidChallenge
+	}
+	if challenge.UsedAt != nil || time.Now().After(challenge.ExpiresAt) ||
+		challenge.Attempts >= mfaChallengeMaxAttempts {
+		return nil, ErrInvalidChallenge
+	}
+
+	admin, err := s.adminRepo.GetByID(challenge.AdminID)
+	if err != nil || !admin.IsActive || !admin.MFAEnabled {
+		return nil, ErrInvalidChallenge
+	}
+
+	keys := throttleKeys(admin.Username, client.IP)
+	if err := s.checkThrottle(keys); err != nil {
+		return nil, err
+	}
+	if admin.IsLocked() {
+		return nil, ErrAccountLocked
+	}
+
+	if !s.consumeMFACode(admin, code) {
+		if err := s.challengeRepo.IncrementAttempts(challenge.ID); err != nil {
+			fmt.Printf("Warning: Failed to record MFA attempt for user %s: %v\n", admin.Username, err)
+		}
+		s.recordFailure(keys, admin)
+		return nil, ErrInvalidMFACode
+	}
+
+	marked, err := s.challengeRepo.MarkUsed(challenge.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error completing login: %v", err)
+	}
+	if !marked {
+		return nil, ErrInvalidChallenge
+	}
+
+	s.recordSuccess(admin.Username, admin)
+
+	if err := s.adminRepo.UpdateLastLogin(admin.ID); err != nil {
+		fmt.Printf("Warning: Failed to update last login for user %s: %v\n", admin.Username, err)
+	}
+
+	return s.startFamily(adminSubject(admin), client)
+}
+
+// EnrollMFA generates a new TOTP secret for the admin. The secret only takes
+// effect once it is confirmed with ActivateMFA.
+func (s *AuthService) EnrollMFA(username string) (*MFAEnrolment, error) {
+	admin, err := s.adminRepo.GetByUsername(username)
+	if err != nil {
+		return nil, fmt.Errorf("admin not found")
+	}
+	if admin.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("error generating secret: %v", err)
+	}
+
+	admin.MFASecret = secret
+	admin.UpdatedAt = time.Now()
+	if err := s.adminRepo.Update(admin); err != nil {
+		return nil, fmt.Errorf("error saving secret: %v", err)
+	}
+
+	return &MFAEnrolment{
+		Secret:          secret,
+		ProvisioningURI: totpProvisioningURI(s.mfaIssuer, admin.Username, secret),
+	}, nil
+}
+
+// ActivateMFA confirms enrolment with a code from the authenticator app and
+// returns a fresh set of recovery codes
+func (s *AuthService) ActivateMFA(username, code string) ([]string, error) {
+	admin, err := s.adminRepo.GetByUsername(username)
+	if err != nil {
+		return nil, fmt.Errorf("admin not found")
+	}
+	if admin.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if admin.MFASecret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+
+	step, ok := verifyTOTP(admin.MFASecret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("error generating recovery codes: %v", err)
+	}
+
+	admin.MFAEnabled = true
+	admin.MFALastUsedStep = step
+	admin.MFARecoveryCodes = strings.Join(hashes, ",")
+	admin.UpdatedAt = time.Now()
+	if err := s.adminRepo.Update(admin); err != nil {
+		return nil, fmt.Errorf("error enabling two-factor authentication: %v", err)
+	}
+
+	fmt.Printf("Two-factor authentication enabled for user: %s\n", username)
+	return codes, nil
+}
+
+// DisableMFA turns off two-factor authentication after checking a current code
+func (s *AuthService) DisableMFA(username, code string) error {
+	admin, err := s.adminRepo.GetByUsername(username)
+	if err != nil {
+		return fmt.Errorf("admin not found")
+	}
+	if !admin.MFAEnabled {
+		return ErrMFANotEnabled
+	}
+	if !s.consumeMFACode(admin, code) {
+		return ErrInvalidMFACode
+	}
+
+	admin.MFAEnabled = false
+	admin.MFASecret = ""
+	admin.MFARecoveryCodes = ""
+	admin.MFALastUsedStep = 0
+	admin.UpdatedAt = time.Now()
+	if err := s.adminRepo.Update(admin); err != nil {
+		return fmt.Errorf("error disabling two-factor authentication: %v", err)
+	}
+
+	fmt.Printf("Two-factor authentication disabled for user: %s\n", username)
+	return nil
+}
+
+// consumeMFACode checks a TOTP or recovery code for the admin and persists
+// the result, so a TOTP code cannot be replayed and a recovery code is only
+// accepted once. The stored state is only changed if no concurrent request
+// used a code first, so of two requests with the same code one fails.
+func (s *AuthService) consumeMFACode(admin *model.Admin, code string) bool {
+	if step, ok := verifyTOTP(admin.MFASecret, code, time.Now()); ok {
+		if step <= admin.MFALastUsedStep {
+			return false
+		}
+		used, err := s.adminRepo.UseMFAStep(admin.ID, step)
+		if err != nil {
+			fmt.Printf("Warning: Failed to record MFA code use for user %s: %v\n", admin.Username, err)
+			return false
+		}
+		if !used {
+			return false
+		}
+		admin.MFALastUsedStep = step
+		return true
+	}
+
+	remaining, ok := removeRecoveryCode(admin.MFARecoveryCodes, code)
+	if !ok {
+		return false
+	}
+	used, err := s.adminRepo.ReplaceRecoveryCodes(admin.ID, admin.MFARecoveryCodes, remaining)
+	if err != nil {
+		fmt.Printf("Warning: Failed to record MFA code use for user %s: %v\n", admin.Username, err)
+		return false
+	}
+	if !used {
+		return false
+	}
+	admin.MFARecoveryCodes = remaining
+	return true
+}
+
+// removeRecoveryCode returns the recovery codes without the one matching
+// code, and whether one matched
+func removeRecoveryCode(codes, code string) (string, bool) {
+	if codes == "" {
+		return codes, false
+	}
+
+	hash := hashToken(normalizeRecoveryCode(code))
+	hashes := strings.Split(codes, ",")
+	for i, h := range hashes {
+		if h == hash {
+			hashes = append(hashes[:i], hashes[i+1:]...)
+			return strings.Join(hashes, ","), true
+		}
+	}
+	return codes, false
+}
+
+// generateRecoveryCodes returns new recovery codes and their hashes
+func generateRecoveryCodes() ([]string, []string, error) {
+	const alphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		for j := range b {
+			b[j] = alphabet[int(b[j])%len(alphabet)]
+		}
+		code := string(b[:5]) + "-" + string(b[5:])
+		codes = append(codes, code)
+		hashes = append(hashes, hashToken(normalizeRecoveryCode(code)))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode makes recovery codes case and dash insensitive
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
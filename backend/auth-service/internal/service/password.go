@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/password"
+)
+
+// ErrPasswordReused is returned when a new password matches the current one
+// or one of the remembered previous passwords
+var ErrPasswordReused = &password.PolicyError{Reason: "was used recently, choose a different one"}
+
+// checkNewPassword validates a new password against the policy and the
+// password history of an admin or member account
+func (s *AuthService) checkNewPassword(newPassword, username, currentHash string, adminID, memberAccountID uint) error {
+	if err := s.policy.Check(newPassword, username); err != nil {
+		return err
+	}
+	if s.policy.HistorySize <= 0 {
+		return nil
+	}
+
+	if s.CheckPasswordHash(newPassword, currentHash) {
+		return ErrPasswordReused
+	}
+
+	history, err := s.historyRepo.Recent(adminID, memberAccountID, s.policy.HistorySize)
+	if err != nil {
+		return fmt.Errorf("error checking password history: %v", err)
+	}
+	for _, entry := range history {
+		if s.CheckPasswordHash(newPassword, entry.PasswordHash) {
+			return ErrPasswordReused
+		}
+	}
+	return nil
+}
+
+// rememberPassword adds a replaced password hash to the history
+func (s *AuthService) rememberPassword(previousHash string, adminID, memberAccountID uint) {
+	if s.policy.HistorySize <= 0 || previousHash == "" {
+		return
+	}
+
+	err := s.historyRepo.Add(&model.PasswordHistory{
+		AdminID:         adminID,
+		MemberAccountID: memberAccountID,
+		PasswordHash:    previousHash,
+		CreatedAt:       time.Now(),
+	}, s.policy.HistorySize)
+	if err != nil {
+		fmt.Printf("Warning: Failed to record password history: %v\n", err)
+	}
+}
+
+// rehashAdminPassword replaces an admin's password hash created with an older
+// algorithm or weaker parameters. It runs after a successful login, when the
+// plain password is known.
+func (s *AuthService) rehashAdminPassword(admin *model.Admin, plain string) {
+	hash, err := s.HashPassword(plain)
+	if err != nil {
+		fmt.Printf("Warning: Failed to rehash password for user %s: %v\n", admin.Username, err)
+		return
+	}
+	if err := s.adminRepo.UpdatePasswordHash(admin.ID, hash); err != nil {
+		fmt.Printf("Warning: Failed to rehash password for user %s: %v\n", admin.Username, err)
+		return
+	}
+	admin.Password = hash
+	fmt.Printf("Password hash upgraded for user: %s\n", admin.Username)
+}
+
+// rehashMemberPassword replaces a member account's outdated password hash
+// after a successful login
+func (s *AuthService) rehashMemberPassword(account *model.MemberAccount, plain string) {
+	hash, err := s.HashPassword(plain)
+	if err != nil {
+		fmt.Printf("Warning: Failed to rehash password for member %s: %v\n", account.Email, err)
+		return
+	}
+	if err := s.memberRepo.UpdatePasswordHash(account.ID, hash); err != nil {
+		fmt.Printf("Warning: Failed to rehash password for member %s: %v\n", account.Email, err)
+		return
+	}
+	account.Password = hash
+}
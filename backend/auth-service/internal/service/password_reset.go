@@ -0,0 +1,182 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/mail"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/repository"
+)
+
+// ErrInvalidResetToken is returned for unknown, used or expired reset tokens
+var ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+
+// PasswordResetService handles the forgot/reset password flow
+type PasswordResetService struct {
+	authService *AuthService
+	adminRepo   *repository.AdminRepository
+	resetRepo   *repository.ResetRepository
+	tokenRepo   *repository.TokenRepository
+	mailer      mail.Mailer
+	expiry      time.Duration
+	resetURL    string
+}
+
+// NewPasswordResetService creates a new password reset service. resetURL is
+// the frontend page the emailed link points to; the token is appended as the
+// "token" query parameter.
+func NewPasswordResetService(authService *AuthService, adminRepo *repository.AdminRepository, resetRepo *repository.ResetRepository, tokenRepo *repository.TokenRepository, mailer mail.Mailer, expiry time.Duration, resetURL string) *PasswordResetService {
+	return &PasswordResetService{
+		authService: authService,
+		adminRepo:   adminRepo,
+		resetRepo:   resetRepo,
+		tokenRepo:   tokenRepo,
+		mailer:      mailer,
+		expiry:      expiry,
+		resetURL:    resetURL,
+	}
+}
+
+// RequestReset emails a reset link to the admin with the given address. The
+// lookup and the email happen in the background, and unknown addresses are
+// accepted the same way, so neither the result nor the time it takes shows
+// which emails have accounts. Requests are throttled per address and client
+// IP with the login backoff; a throttled request returns a
+// *TooManyAttemptsError.
+func (s *PasswordResetService) RequestReset(email, ip string) error {
+	keys := []string{"reset:" + strings.ToLower(email)}
+	if ip != "" {
+		keys = append(keys, "reset-ip:"+ip)
+	}
+	if err := s.authService.checkThrottle(keys); err != nil {
+		return err
+	}
+	s.authService.recordAttempt(keys)
+
+	go func() {
+		if err := s.sendReset(email); err != nil {
+			fmt.Printf("Warning: Failed to send password reset email: %v\n", err)
+		}
+	}()
+	return nil
+}
+
+// sendReset creates a reset token for the admin with the given address and
+// emails the link. Unknown addresses are ignored.
+func (s *PasswordResetService) sendReset(email string) error {
+	admin, err := s.adminRepo.GetByEmail(email)
+	if err != nil {
+		return nil
+	}
+
+	// Only the newest link is valid
+	if err := s.resetRepo.InvalidateForAdmin(admin.ID); err != nil {
+		return fmt.Errorf("error creating reset token: %v", err)
+	}
+
+	token, err := generateToken(32)
+	if err != nil {
+		return fmt.Errorf("error creating reset token: %v", err)
+	}
+
+	err = s.resetRepo.Create(&model.PasswordResetToken{
+		TokenHash: hashToken(token),
+		AdminID:   admin.ID,
+		ExpiresAt: time.Now().Add(s.expiry),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating reset token: %v", err)
+	}
+
+	link, err := s.buildLink(token)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(`Hello %s,
+
+A password reset was requested for your Fitness Center admin account.
+Open the link below to choose a new password. The link expires in %d minutes
+and can be used once.
+
+%s
+
+If you did not request this, you can ignore this email.
+`, admin.Username, int(s.expiry.Minutes()), link)
+
+	err = s.mailer.Send(mail.Message{
+		To:      admin.Email,
+		Subject: "Reset your Fitness Center password",
+		Body:    body,
+	})
+	if err != nil {
+		return fmt.Errorf("error sending reset email: %v", err)
+	}
+
+	fmt.Printf("Password reset requested for user: %s\n", admin.Username)
+	return nil
+}
+
+// ResetPassword sets a new password using a reset token. All existing
+// sessions of the admin are revoked.
+func (s *PasswordResetService) ResetPassword(token, newPassword string) error {
+	stored, err := s.resetRepo.GetByHash(hashToken(token))
+	if err != nil {
+		return ErrInvalidResetToken
+	}
+	if stored.UsedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	admin, err := s.adminRepo.GetByID(stored.AdminID)
+	if err != nil || !admin.IsActive {
+		return ErrInvalidResetToken
+	}
+
+	// Checked before using up the token so the admin can pick another password
+	if err := s.authService.checkNewPassword(newPassword, admin.Username, admin.Password, admin.ID, 0); err != nil {
+		return err
+	}
+
+	marked, err := s.resetRepo.MarkUsed(stored.ID)
+	if err != nil {
+		return fmt.Errorf("error resetting password: %v", err)
+	}
+	if !marked {
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := s.authService.HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("error hashing new password: %v", err)
+	}
+
+	if err := s.adminRepo.ResetPassword(admin.ID, hashedPassword); err != nil {
+		return fmt.Errorf("error resetting password: %v", err)
+	}
+	s.authService.rememberPassword(admin.Password, admin.ID, 0)
+
+	if err := s.tokenRepo.RevokeAllFamilies(admin.ID); err != nil {
+		fmt.Printf("Warning: Failed to revoke sessions for user %s: %v\n", admin.Username, err)
+	}
+
+	fmt.Printf("Password reset for user: %s\n", admin.Username)
+	return nil
+}
+
+// buildLink appends the token to the configured reset URL
+func (s *PasswordResetService) buildLink(token string) (string, error) {
+	u, err := url.Parse(s.resetURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid password reset URL: %v", err)
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
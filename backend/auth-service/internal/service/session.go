@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+)
+
+// Session errors
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrNoSessions      = errors.New("service tokens have no sessions")
+	ErrOutranked       = errors.New("cannot manage the sessions of an admin with a higher role")
+)
+
+// ListSessions returns the active sessions of the token's owner
+func (s *AuthService) ListSessions(claims *Claims) ([]model.TokenFamily, error) {
+	adminID, memberAccountID, err := sessionOwner(claims)
+	if err != nil {
+		return nil, err
+	}
+	return s.listSessions(adminID, memberAccountID)
+}
+
+// RevokeSession revokes one of the sessions of the token's owner
+func (s *AuthService) RevokeSession(claims *Claims, sessionID string) error {
+	adminID, memberAccountID, err := sessionOwner(claims)
+	if err != nil {
+		return err
+	}
+	_, err = s.revokeSession(adminID, memberAccountID, sessionID)
+	return err
+}
+
+// ListAdminSessions returns the active sessions of an admin
+func (s *AuthService) ListAdminSessions(username string) ([]model.TokenFamily, error) {
+	admin, err := s.adminRepo.FindByUsername(username)
+	if err != nil {
+		return nil, ErrAdminNotFound
+	}
+	return s.listSessions(admin.ID, 0)
+}
+
+// RevokeAdminSession revokes one of the sessions of an admin. Callers cannot
+// revoke the sessions of an admin whose role outranks all of theirs.
+func (s *AuthService) RevokeAdminSession(ctx context.Context, claims *Claims, username, sessionID string) error {
+	admin, err := s.adminRepo.FindByUsername(username)
+	if err != nil {
+		return ErrAdminNotFound
+	}
+	if !model.AnyRanksAtLeast(claims.Roles, admin.Role) {
+		return ErrOutranked
+	}
+	family, err := s.revokeSession(admin.ID, 0, sessionID)
+	if err != nil {
+		return err
+	}
+	audit.Before(ctx, family)
+
+	fmt.Printf("Session %s of user %s revoked\n", sessionID, username)
+	return nil
+}
+
+func (s *AuthService) listSessions(adminID, memberAccountID uint) ([]model.TokenFamily, error) {
+	sessions, err := s.tokenRepo.ListActiveFamilies(adminID, memberAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing sessions: %v", err)
+	}
+	return sessions, nil
+}
+
+// revokeSession revokes a session of the given owner and returns it as it
+// was before the revocation
+func (s *AuthService) revokeSession(adminID, memberAccountID uint, sessionID string) (*model.TokenFamily, error) {
+	family, err := s.tokenRepo.GetFamily(sessionID)
+	if err != nil || family.IsRevoked() ||
+		family.AdminID != adminID || family.MemberAccountID != memberAccountID {
+		return nil, ErrSessionNotFound
+	}
+
+	if err := s.tokenRepo.RevokeFamily(family.ID); err != nil {
+		return nil, fmt.Errorf("error revoking session: %v", err)
+	}
+	return family, nil
+}
+
+// sessionOwner returns the admin or member account ID that owns the token's
+// sessions; the other ID is zero
+func sessionOwner(claims *Claims) (uint, uint, error) {
+	if claims.IsService() {
+		return 0, 0, ErrNoSessions
+	}
+
+	subjectID, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid token subject")
+	}
+	if claims.IsMember() {
+		return 0, uint(subjectID), nil
+	}
+	return uint(subjectID), 0, nil
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/FurkanArikk/fitness-center/backend/auth-service/internal/model"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Token errors
+var (
+	ErrTokenRevoked        = errors.New("token has been revoked")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+)
+
+// sessionTouchInterval limits how often the last-seen time of a session is
+// written while its access tokens are being validated
+const sessionTouchInterval = time.Minute
+
+// ClientInfo describes the client a session was started or used from
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// TokenPair is the result of a successful login or refresh
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int
+}
+
+// tokenSubject is the identity a token is issued to: an admin or a member
+// account
+type tokenSubject struct {
+	adminID         uint
+	memberAccountID uint
+	memberID        uint
+	username        string
+	roles           []string
+}
+
+// adminSubject returns the token subject of an admin
+func adminSubject(admin *model.Admin) tokenSubject {
+	return tokenSubject{
+		adminID:  admin.ID,
+		username: admin.Username,
+		roles:    []string{string(admin.Role)},
+	}
+}
+
+// memberSubject returns the token subject of a member account
+func memberSubject(account *model.MemberAccount) tokenSubject {
+	return tokenSubject{
+		memberAccountID: account.ID,
+		memberID:        account.MemberID,
+		username:        account.Email,
+		roles:           []string{string(model.RoleMember)},
+	}
+}
+
+// id returns the subject ID used in the token's sub claim
+func (t tokenSubject) id() string {
+	if t.memberAccountID != 0 {
+		return strconv.FormatUint(uint64(t.memberAccountID), 10)
+	}
+	return strconv.FormatUint(uint64(t.adminID), 10)
+}
+
+// startFamily creates a new token family for the subject and issues its first
+// access and refresh tokens
+func (s *AuthService) startFamily(subject tokenSubject, client ClientInfo) (*TokenPair, error) {
+	familyID, err := generateToken(16)
+	if err != nil {
+		return nil, fmt.Errorf("error creating token family: %v", err)
+	}
+
+	now := time.Now()
+	family := &model.TokenFamily{
+		ID:              familyID,
+		AdminID:         subject.adminID,
+		MemberAccountID: subject.memberAccountID,
+		UserAgent:       truncate(client.UserAgent, 255),
+		IPAddress:       client.IP,
+		LastSeenAt:      &now,
+		CreatedAt:       now,
+	}
+	if err := s.tokenRepo.CreateFamily(family); err != nil {
+		return nil, fmt.Errorf("error creating token family: %v", err)
+	}
+
+	return s.issueTokens(subject, familyID)
+}
+
+// issueTokens creates a new access token and refresh token in the given family
+func (s *AuthService) issueTokens(subject tokenSubject, familyID string) (*TokenPair, error) {
+	accessToken, err := s.createAccessToken(subject, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := generateToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("error creating refresh token: %v", err)
+	}
+
+	err = s.tokenRepo.CreateRefreshToken(&model.RefreshToken{
+		TokenHash:       hashToken(refreshToken),
+		FamilyID:        familyID,
+		AdminID:         subject.adminID,
+		MemberAccountID: subject.memberAccountID,
+		ExpiresAt:       time.Now().Add(s.refreshExpiry),
+		CreatedAt:       time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error storing refresh token: %v", err)
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(s.accessExpiry.Seconds()),
+	}, nil
+}
+
+// createAccessToken signs a short-lived JWT access token
+func (s *AuthService) createAccessToken(subject tokenSubject, familyID string) (string, error) {
+	tokenID, err := generateToken(16)
+	if err != nil {
+		return "", fmt.Errorf("error creating token: %v", err)
+	}
+
+	now := time.Now()
+	claims := Claims{
+		Username: subject.username,
+		Roles:    subject.roles,
+		MemberID: subject.memberID,
+		FamilyID: familyID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			Subject:   subject.id(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.accessExpiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    "fitness-center-auth",
+		},
+	}
+
+	tokenString, err := s.keys.Sign(claims)
+	if err != nil {
+		return "", fmt.Errorf("error creating token: %v", err)
+	}
+
+	return tokenString, nil
+}
+
+// Refresh exchanges a refresh token for a new token pair. Refresh tokens are
+// single-use: presenting one that was already used revokes the whole family,
+// since it means the token has been stolen or replayed.
+func (s *AuthService) Refresh(refreshToken string, client ClientInfo) (*TokenPair, error) {
+	stored, err := s.tokenRepo.GetRefreshTokenByHash(hashToken(refreshToken))
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	family, err := s.tokenRepo.GetFamily(stored.FamilyID)
+	if err != nil || family.IsRevoked() {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if stored.UsedAt != nil {
+		s.revokeReusedFamily(stored)
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	marked, err := s.tokenRepo.MarkRefreshTokenUsed(stored.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error rotating refresh token: %v", err)
+	}
+	if !marked {
+		s.revokeReusedFamily(stored)
+		return nil, ErrInvalidRefreshToken
+	}
+
+	subject, err := s.refreshSubject(stored)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if err := s.tokenRepo.TouchFamily(stored.FamilyID, client.IP); err != nil {
+		fmt.Printf("Warning: Failed to update session %s: %v\n", stored.FamilyID, err)
+	}
+
+	return s.issueTokens(subject, stored.FamilyID)
+}
+
+// refreshSubject reloads the owner of a refresh token so that role changes and
+// deactivation take effect on the next refresh
+func (s *AuthService) refreshSubject(token *model.RefreshToken) (tokenSubject, error) {
+	if token.MemberAccountID != 0 {
+		account, err := s.memberRepo.GetByID(token.MemberAccountID)
+		if err != nil || !account.IsActive {
+			return tokenSubject{}, ErrInvalidRefreshToken
+		}
+		return memberSubject(account), nil
+	}
+
+	admin, err := s.adminRepo.GetByID(token.AdminID)
+	if err != nil || !admin.IsActive {
+		return tokenSubject{}, ErrInvalidRefreshToken
+	}
+	return adminSubject(admin), nil
+}
+
+// revokeReusedFamily revokes the family of a refresh token that was presented
+// more than once
+func (s *AuthService) revokeReusedFamily(token *model.RefreshToken) {
+	if err := s.tokenRepo.RevokeFamily(token.FamilyID); err != nil {
+		fmt.Printf("Warning: Failed to revoke token family %s: %v\n", token.FamilyID, err)
+		return
+	}
+	fmt.Printf("Refresh token reuse detected, revoked token family %s\n", token.FamilyID)
+}
+
+// Logout revokes the token family the access token belongs to
+func (s *AuthService) Logout(claims *Claims) error {
+	if claims.IsService() {
+		return ErrServiceTokenLogout
+	}
+	if err := s.tokenRepo.RevokeFamily(claims.FamilyID); err != nil {
+		return fmt.Errorf("error revoking token: %v", err)
+	}
+	return nil
+}
+
+// LogoutAll revokes every token family of the admin or member owning the
+// access token
+func (s *AuthService) LogoutAll(claims *Claims) error {
+	if claims.IsService() {
+		return ErrServiceTokenLogout
+	}
+
+	subjectID, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid token subject")
+	}
+
+	if claims.IsMember() {
+		err = s.tokenRepo.RevokeAllMemberFamilies(uint(subjectID))
+	} else {
+		err = s.tokenRepo.RevokeAllFamilies(uint(subjectID))
+	}
+	if err != nil {
+		return fmt.Errorf("error revoking tokens: %v", err)
+	}
+	return nil
+}
+
+// truncate shortens a string to at most n bytes without splitting a UTF-8
+// encoded character
+func truncate(value string, n int) string {
+	if len(value) <= n {
+		return value
+	}
+	for n > 0 && !utf8.RuneStart(value[n]) {
+		n--
+	}
+	return value[:n]
+}
+
+// generateToken returns a URL-safe random token built from n random bytes
+func generateToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the hex encoded SHA-256 hash of a token
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // LoginRequest represents user login request
 type LoginRequest struct {
 	Username string `json:"username" binding:"required"`
@@ -8,8 +10,47 @@ type LoginRequest struct {
 
 // LoginResponse represents login response
 type LoginResponse struct {
-	Token   string `json:"token"`
-	Message string `json:"message"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	TokenType    string `json:"token_type"`
+	Message      string `json:"message"`
+}
+
+// MFAChallengeResponse is returned by login when a second factor is required
+type MFAChallengeResponse struct {
+	MFARequired    bool   `json:"mfa_required"`
+	ChallengeToken string `json:"challenge_token"`
+	ExpiresIn      int    `json:"expires_in"`
+	Message        string `json:"message"`
+}
+
+// MFALoginRequest represents the second login step
+type MFALoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// MFACodeRequest represents a request confirmed with a TOTP or recovery code
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// MFAEnrollResponse represents the result of starting MFA enrolment
+type MFAEnrollResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// MFARecoveryCodesResponse represents newly issued recovery codes
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+	Message       string   `json:"message"`
+}
+
+// RefreshRequest represents a token refresh request
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
 // ErrorResponse represents error response
@@ -29,6 +70,7 @@ type CreateAdminRequest struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
 	Email    string `json:"email" binding:"required"`
+	Role     string `json:"role"`
 }
 
 // UpdatePasswordRequest represents password update request
@@ -38,6 +80,42 @@ type UpdatePasswordRequest struct {
 	NewPassword     string `json:"new_password" binding:"required"`
 }
 
+// ForgotPasswordRequest represents a password reset link request
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+// ResetPasswordRequest represents setting a new password with a reset token
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// InviteMemberRequest represents a member account invitation
+type InviteMemberRequest struct {
+	MemberID uint   `json:"member_id" binding:"required"`
+	Email    string `json:"email" binding:"required"`
+}
+
+// MemberRegisterRequest represents accepting a member invitation
+type MemberRegisterRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// MemberLoginRequest represents member login request
+type MemberLoginRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// MemberAccountResponse represents member account information
+type MemberAccountResponse struct {
+	ID       uint   `json:"id"`
+	MemberID uint   `json:"member_id"`
+	Email    string `json:"email"`
+}
+
 // SuccessResponse represents success response
 type SuccessResponse struct {
 	Message string `json:"message"`
@@ -48,12 +126,105 @@ type AdminResponse struct {
 	ID          uint   `json:"id"`
 	Username    string `json:"username"`
 	Email       string `json:"email"`
+	Role        string `json:"role"`
 	IsActive    bool   `json:"is_active"`
+	MFAEnabled  bool   `json:"mfa_enabled"`
 	CreatedAt   string `json:"created_at"`
 	LastLoginAt string `json:"last_login_at,omitempty"`
+
+	FailedLoginAttempts int    `json:"failed_login_attempts"`
+	IsLocked            bool   `json:"is_locked"`
+	LockedUntil         string `json:"locked_until,omitempty"`
+	LastLockedAt        string `json:"last_locked_at,omitempty"`
+	LockCount           int    `json:"lock_count"`
 }
 
-// ListAdminsResponse represents list of admin users
+// ListAdminsResponse represents a page of admin users
 type ListAdminsResponse struct {
-	Admins []AdminResponse `json:"admins"`
+	Admins     []AdminResponse `json:"admins"`
+	Page       int             `json:"page"`
+	PageSize   int             `json:"pageSize"`
+	TotalItems int64           `json:"totalItems"`
+	TotalPages int             `json:"totalPages"`
+}
+
+// UpdateAdminRequest represents changing an admin's email and/or role
+type UpdateAdminRequest struct {
+	Email *string `json:"email"`
+	Role  *string `json:"role"`
+}
+
+// UpdateProfileRequest represents an admin changing their own email
+type UpdateProfileRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+// RotateKeysResponse represents the result of a signing key rotation
+type RotateKeysResponse struct {
+	KID         string    `json:"kid"`
+	ActivatesAt time.Time `json:"activates_at"`
+	Message     string    `json:"message"`
+}
+
+// CreateClientRequest represents creating a service client
+type CreateClientRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Scopes      []string `json:"scopes" binding:"required"`
+}
+
+// ClientResponse represents service client information
+type ClientResponse struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Scopes      []string `json:"scopes"`
+	IsActive    bool     `json:"is_active"`
+	CreatedBy   string   `json:"created_by"`
+	CreatedAt   string   `json:"created_at"`
+	LastUsedAt  string   `json:"last_used_at,omitempty"`
+}
+
+// ClientSecretResponse represents a newly issued client secret, which is only
+// shown once
+type ClientSecretResponse struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Message      string `json:"message"`
+}
+
+// ListClientsResponse represents list of service clients
+type ListClientsResponse struct {
+	Clients []ClientResponse `json:"clients"`
+}
+
+// ClientTokenRequest represents a client credentials grant. The credentials
+// can also be sent with HTTP Basic authentication.
+type ClientTokenRequest struct {
+	GrantType    string `json:"grant_type" form:"grant_type" binding:"required"`
+	ClientID     string `json:"client_id" form:"client_id"`
+	ClientSecret string `json:"client_secret" form:"client_secret"`
+	Scope        string `json:"scope" form:"scope"`
+}
+
+// ClientTokenResponse represents a service access token
+type ClientTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// SessionResponse represents an active login session
+type SessionResponse struct {
+	ID         string `json:"id"`
+	UserAgent  string `json:"user_agent"`
+	IPAddress  string `json:"ip_address"`
+	CreatedAt  string `json:"created_at"`
+	LastSeenAt string `json:"last_seen_at,omitempty"`
+	Current    bool   `json:"current"`
+}
+
+// ListSessionsResponse represents list of active sessions
+type ListSessionsResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
 }
@@ -5,6 +5,7 @@
 
 BASE_URL="http://localhost:8085"
 AUTH_URL="$BASE_URL/api/v1"
+ADMIN_PASSWORD="${ADMIN_PASSWORD:?set ADMIN_PASSWORD to the initial admin password}"
 
 echo "🔐 Auth Service Test Script"
 echo "=========================="
@@ -22,7 +23,7 @@ LOGIN_RESPONSE=$(curl -s -X POST "$AUTH_URL/login" \
   -H "Content-Type: application/json" \
   -d '{
     "username": "admin",
-    "password": "admin"
+    "password": "'"$ADMIN_PASSWORD"'"
   }')
 
 echo "Login Response: $LOGIN_RESPONSE"
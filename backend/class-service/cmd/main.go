@@ -1,14 +1,17 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/config"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/db"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/handler"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/repository"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/server"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/service"
@@ -23,6 +26,9 @@ func main() {
 	cfg := config.LoadConfig()
 	log.Printf("Loaded configuration: server port=%d", cfg.Server.Port)
 
+	// Class dates and times are wall-clock times of the center
+	model.SetLocation(cfg.Schedule.Location)
+
 	// Initialize database connection
 	database, err := db.NewPostgresDB(cfg.Database)
 	if err != nil {
@@ -34,13 +40,88 @@ func main() {
 	repos := repository.NewRepositories(database.DB)
 
 	// Initialize services
-	services := service.NewServices(repos)
+	services := service.NewServices(repos, cfg.Schedule, cfg.Booking, cfg.Penalty, cfg.Calendar, cfg.Clients)
+
+	// Keep dated occurrences generated for the rolling horizon
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			created, err := services.OccurrenceService.GenerateOccurrences(context.Background())
+			if err != nil {
+				log.Printf("Failed to generate class occurrences: %v", err)
+			} else if created > 0 {
+				log.Printf("Generated %d class occurrences", created)
+			}
+			<-ticker.C
+		}
+	}()
+
+	// Expired waitlist offers are also released on every booking request; the
+	// sweep passes seats on when there is no traffic
+	if cfg.Booking.WaitlistOfferWindow > 0 {
+		go func() {
+			ticker := time.NewTicker(time.Minute)
+			defer ticker.Stop()
+			for range ticker.C {
+				if err := services.BookingService.ExpireWaitlistOffers(context.Background()); err != nil {
+					log.Printf("Failed to expire waitlist offers: %v", err)
+				}
+			}
+		}()
+	}
+
+	// Close the attendance of ended classes. The job takes an advisory lock,
+	// so only one replica marks bookings at a time.
+	if cfg.Booking.NoShowJobInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(cfg.Booking.NoShowJobInterval)
+			defer ticker.Stop()
+			for range ticker.C {
+				marked, err := services.BookingService.MarkNoShows(context.Background(), cfg.Booking.NoShowGracePeriod)
+				if err != nil {
+					log.Printf("Failed to mark no-shows: %v", err)
+				}
+				if marked > 0 {
+					log.Printf("Marked %d bookings as no-show", marked)
+				}
+			}
+		}()
+	}
+
+	// Trainer ratings are pushed when feedback is given; the sync catches up
+	// on the ones the staff service did not take. Both need a service token.
+	if cfg.Booking.RatingSyncInterval > 0 && cfg.Clients.Staff.BaseURL != "" && cfg.Clients.Staff.Credentials.TokenURL != "" {
+		go func() {
+			ticker := time.NewTicker(cfg.Booking.RatingSyncInterval)
+			defer ticker.Stop()
+			for range ticker.C {
+				if _, err := services.BookingService.SyncTrainerRatings(context.Background()); err != nil {
+					log.Printf("Failed to sync trainer ratings: %v", err)
+				}
+			}
+		}()
+	}
+
+	// Penalty fees are posted when they are recorded; the sweep retries the
+	// ones the payment service did not take
+	if cfg.Clients.Payment.BaseURL != "" {
+		go func() {
+			ticker := time.NewTicker(5 * time.Minute)
+			defer ticker.Stop()
+			for range ticker.C {
+				if err := services.BookingService.PostPendingCharges(context.Background()); err != nil {
+					log.Printf("Failed to post pending penalty charges: %v", err)
+				}
+			}
+		}()
+	}
 
 	// Initialize handlers
-	handlers := handler.NewHandlers(services, database)
+	handlers := handler.NewHandlers(services, database, cfg.Calendar)
 
 	// Create and initialize server
-	srv := server.NewServer(&cfg, handlers)
+	srv := server.NewServer(&cfg, handlers, repos.AuditRepo)
 
 	// Handle graceful shutdown
 	done := make(chan os.Signal, 1)
@@ -5,6 +5,8 @@ go 1.23.0
 toolchain go1.23.8
 
 require (
+	github.com/FurkanArikk/fitness-center/backend/audit v0.0.0
+	github.com/FurkanArikk/fitness-center/backend/identity v0.0.0
 	github.com/gin-gonic/gin v1.10.0
 	github.com/joho/godotenv v1.5.1
 	github.com/lib/pq v1.10.9
@@ -22,6 +24,7 @@ require (
 	github.com/go-playground/universal-translator v0.18.1 // indirect
 	github.com/go-playground/validator/v10 v10.26.0 // indirect
 	github.com/goccy/go-json v0.10.5 // indirect
+	github.com/golang-jwt/jwt/v5 v5.2.0 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20221227161230-091c0ba34f0a // indirect
 	github.com/jackc/pgx/v5 v5.5.5 // indirect
@@ -48,3 +51,7 @@ require (
 	google.golang.org/protobuf v1.36.6 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/FurkanArikk/fitness-center/backend/audit => ../audit
+
+replace github.com/FurkanArikk/fitness-center/backend/identity => ../identity
@@ -0,0 +1,213 @@
+package calendar
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType is the media type of iCalendar documents
+const ContentType = "text/calendar; charset=utf-8"
+
+// productID identifies class-service as the producer of its calendars
+const productID = "-//Fitness Center//Class Service//EN"
+
+// utcLayout is the layout of UTC date-times in iCalendar
+const utcLayout = "20060102T150405Z"
+
+// localLayout is the layout of local date-times in iCalendar, whose time zone
+// is given by a TZID parameter or a VTIMEZONE observance
+const localLayout = "20060102T150405"
+
+// maxLineOctets is the longest a content line may be before it is folded
+const maxLineOctets = 75
+
+// Event statuses
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+// Calendar is an iCalendar (RFC 5545) document
+type Calendar struct {
+	// Name is shown by calendar apps for the subscribed calendar
+	Name string
+	// RefreshInterval asks calendar apps to reload the calendar this often.
+	// Zero leaves it to the app.
+	RefreshInterval time.Duration
+	// TimeZone is the time zone event times are written in, with its
+	// VTIMEZONE, so that they stay at the same wall-clock time across
+	// daylight saving changes. Nil or UTC writes them in UTC.
+	TimeZone *time.Location
+	Events   []Event
+}
+
+// Event is a VEVENT of a calendar. Times are written in the time zone of the
+// calendar, and the stamp in UTC.
+type Event struct {
+	UID     string
+	Start   time.Time
+	End     time.Time
+	Stamp   time.Time
+	Summary string
+	// Description and Location are left out when empty
+	Description string
+	Location    string
+	// Status is StatusConfirmed or StatusCancelled. Empty leaves it out.
+	Status string
+	// RRule repeats the event, such as "FREQ=WEEKLY;BYDAY=MO"
+	RRule string
+	// ExDates are the starts of repetitions that do not take place
+	ExDates []time.Time
+	// RecurrenceID is the start of the repetition of the event with the same
+	// UID that this event replaces
+	RecurrenceID *time.Time
+}
+
+// Marshal writes the calendar in the iCalendar format
+func (c Calendar) Marshal() []byte {
+	w := &writer{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", productID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		w.line("X-WR-CALNAME", escape(c.Name))
+	}
+	if c.RefreshInterval > 0 {
+		w.line("REFRESH-INTERVAL;VALUE=DURATION", duration(c.RefreshInterval))
+		w.line("X-PUBLISHED-TTL", duration(c.RefreshInterval))
+	}
+
+	tz := c.TimeZone
+	if tz == time.UTC {
+		tz = nil
+	}
+	if tz != nil && len(c.Events) > 0 {
+		from, to := c.span()
+		writeTimeZone(w, tz, from, to)
+	}
+	for _, event := range c.Events {
+		event.write(w, tz)
+	}
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+// span returns the first start and last end of the events. Recurring events
+// have no last end, so the span reaches a year past the latest one.
+func (c Calendar) span() (time.Time, time.Time) {
+	from, to := c.Events[0].Start, c.Events[0].End
+	recurring := false
+	for _, event := range c.Events {
+		if event.Start.Before(from) {
+			from = event.Start
+		}
+		if event.End.After(to) {
+			to = event.End
+		}
+		recurring = recurring || event.RRule != ""
+	}
+	if recurring {
+		to = to.AddDate(1, 0, 0)
+	}
+	return from, to
+}
+
+// write writes the event as a VEVENT with its times in tz, or in UTC if tz is
+// nil
+func (e Event) write(w *writer, tz *time.Location) {
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", e.UID)
+	w.line("DTSTAMP", utc(e.Stamp))
+	w.dateTime("DTSTART", e.Start, tz)
+	w.dateTime("DTEND", e.End, tz)
+	if e.RecurrenceID != nil {
+		w.dateTime("RECURRENCE-ID", *e.RecurrenceID, tz)
+	}
+	if e.RRule != "" {
+		w.line("RRULE", e.RRule)
+	}
+	for _, exDate := range e.ExDates {
+		w.dateTime("EXDATE", exDate, tz)
+	}
+	w.line("SUMMARY", escape(e.Summary))
+	if e.Description != "" {
+		w.line("DESCRIPTION", escape(e.Description))
+	}
+	if e.Location != "" {
+		w.line("LOCATION", escape(e.Location))
+	}
+	if e.Status != "" {
+		w.line("STATUS", e.Status)
+	}
+	w.line("END", "VEVENT")
+}
+
+// Weekday returns the RRULE BYDAY code of a weekday, such as "MO"
+func Weekday(day time.Weekday) string {
+	return strings.ToUpper(day.String()[:2])
+}
+
+// writer writes content lines, folded and ended with CRLF
+type writer struct {
+	buf bytes.Buffer
+}
+
+// line writes a property with a value that is already escaped. Lines longer
+// than 75 octets are folded without splitting UTF-8 characters.
+func (w *writer) line(name, value string) {
+	line := name + ":" + value
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.buf.WriteString(line[:cut])
+		w.buf.WriteString("\r\n ")
+		line = line[cut:]
+		// The space that starts a continuation line counts towards its length
+		limit = maxLineOctets - 1
+	}
+	w.buf.WriteString(line)
+	w.buf.WriteString("\r\n")
+}
+
+// dateTime writes a date-time property in tz, or in UTC if tz is nil
+func (w *writer) dateTime(name string, t time.Time, tz *time.Location) {
+	if tz == nil {
+		w.line(name, utc(t))
+		return
+	}
+	w.line(name+";TZID="+tz.String(), t.In(tz).Format(localLayout))
+}
+
+// escape escapes a TEXT value
+func escape(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(text)
+}
+
+// utc formats a date-time in UTC
+func utc(t time.Time) string {
+	return t.UTC().Format(utcLayout)
+}
+
+// duration formats a duration as an iCalendar DURATION in whole minutes
+func duration(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+	return fmt.Sprintf("PT%dM", minutes)
+}
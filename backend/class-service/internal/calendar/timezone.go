@@ -0,0 +1,76 @@
+package calendar
+
+import (
+	"fmt"
+	"time"
+)
+
+// writeTimeZone writes the VTIMEZONE of tz for the times between from and
+// to. The time zone database of Go has no recurrence rules, so each change of
+// the UTC offset in that span is written as an observance of its own, after
+// one for the offset in effect at from.
+func writeTimeZone(w *writer, tz *time.Location, from, to time.Time) {
+	w.line("BEGIN", "VTIMEZONE")
+	w.line("TZID", tz.String())
+
+	start := from.In(tz)
+	_, offset := start.Zone()
+	writeObservance(w, start, offset)
+
+	// Offsets change at most a few times a year, so checking once a day and
+	// narrowing down to the minute finds every change
+	for day := start; day.Before(to); {
+		next := day.Add(24 * time.Hour)
+		if _, nextOffset := next.Zone(); nextOffset != offset {
+			change := findChange(day, next, offset)
+			writeObservance(w, change, offset)
+			_, offset = change.Zone()
+		}
+		day = next
+	}
+
+	w.line("END", "VTIMEZONE")
+}
+
+// findChange returns the first minute after before at which the UTC offset is
+// no longer offset, given that it changed by after
+func findChange(before, after time.Time, offset int) time.Time {
+	for after.Sub(before) > time.Minute {
+		middle := before.Add(after.Sub(before) / 2).Truncate(time.Minute)
+		if _, middleOffset := middle.Zone(); middleOffset == offset {
+			before = middle
+		} else {
+			after = middle
+		}
+	}
+	return after
+}
+
+// writeObservance writes the STANDARD or DAYLIGHT observance that starts at
+// t, when the offset changes from fromOffset to the offset of t
+func writeObservance(w *writer, t time.Time, fromOffset int) {
+	kind := "STANDARD"
+	if t.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	name, offset := t.Zone()
+
+	w.line("BEGIN", kind)
+	// The start is a local time in the offset it changes from
+	w.line("DTSTART", t.In(time.FixedZone("", fromOffset)).Format(localLayout))
+	w.line("TZOFFSETFROM", utcOffset(fromOffset))
+	w.line("TZOFFSETTO", utcOffset(offset))
+	w.line("TZNAME", escape(name))
+	w.line("END", kind)
+}
+
+// utcOffset formats an offset in seconds east of UTC as a UTC-OFFSET such as
+// "+0300"
+func utcOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
@@ -0,0 +1,158 @@
+// Package client provides typed HTTP clients for the member, staff and
+// facility services, whose records class-service refers to by ID, and for
+// the payment service, which penalty fees are charged through. Trainer
+// ratings are synced to the staff service.
+//
+// The clients are meant to call the services through the gateway, with a
+// service token requested from the auth service with the client credentials
+// grant, so that the gateway checks the scopes of class-service like those
+// of any other caller. Without credentials, requests are sent without a
+// token, which only works when the base URLs are the services' own
+// addresses.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/config"
+)
+
+// ErrNotFound is returned when a service has no record with the requested ID
+var ErrNotFound = errors.New("not found")
+
+// ErrUnavailable is returned, wrapped with the cause, when a service cannot
+// be reached or does not answer as expected
+var ErrUnavailable = errors.New("service unavailable")
+
+// baseClient sends the requests of a typed client
+type baseClient struct {
+	baseURL    string
+	httpClient *http.Client
+	tokens     *tokenSource
+}
+
+// newBaseClient creates a baseClient from the client settings of a service
+func newBaseClient(cfg config.ServiceClientConfig) baseClient {
+	httpClient := &http.Client{Timeout: cfg.Timeout}
+	return baseClient{
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		httpClient: httpClient,
+		tokens:     sharedTokenSource(cfg.Credentials, httpClient),
+	}
+}
+
+// send sends a request for path with the JSON encoding of v as its body, if
+// v is not nil. The request carries a service token when credentials are
+// configured.
+func (c baseClient) send(ctx context.Context, method, path string, v interface{}) (*http.Response, error) {
+	var body io.Reader
+	if v != nil {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", method, path, err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %s: %v", ErrUnavailable, method, path, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if v != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.tokens != nil {
+		token, err := c.tokens.token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %s: %v", ErrUnavailable, method, path, err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %s: %v", ErrUnavailable, method, path, err)
+	}
+
+	// A token revoked before it expired, for instance when the client
+	// secret was rotated, is replaced on the next request
+	if resp.StatusCode == http.StatusUnauthorized && c.tokens != nil {
+		c.tokens.reset()
+	}
+	return resp, nil
+}
+
+// get sends a GET request for path and decodes the JSON response into out
+func (c baseClient) get(ctx context.Context, path string, out interface{}) error {
+	resp, err := c.send(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		io.Copy(io.Discard, resp.Body)
+		return ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("%w: GET %s: unexpected status %d", ErrUnavailable, path, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: GET %s: invalid response: %v", ErrUnavailable, path, err)
+	}
+	return nil
+}
+
+// post sends v as JSON in a POST request to path and decodes the JSON
+// response into out. Any status other than 201 Created is an error.
+func (c baseClient) post(ctx context.Context, path string, v interface{}, out interface{}) error {
+	resp, err := c.send(ctx, http.MethodPost, path, v)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%w: POST %s: unexpected status %d: %s", ErrUnavailable, path, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: POST %s: invalid response: %v", ErrUnavailable, path, err)
+	}
+	return nil
+}
+
+// put sends v as JSON in a PUT request to path and decodes the JSON response
+// into out. Any status other than 200 OK is an error.
+func (c baseClient) put(ctx context.Context, path string, v interface{}, out interface{}) error {
+	resp, err := c.send(ctx, http.MethodPut, path, v)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		io.Copy(io.Discard, resp.Body)
+		return ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%w: PUT %s: unexpected status %d: %s", ErrUnavailable, path, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: PUT %s: invalid response: %v", ErrUnavailable, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/config"
+)
+
+// FacilityActive is the status of facilities that are open for use
+const FacilityActive = "active"
+
+// Facility is a facility as returned by the facility service. Class rooms
+// are facilities.
+type Facility struct {
+	FacilityID  int    `json:"facility_id"`
+	Name        string `json:"name"`
+	Capacity    int    `json:"capacity"`
+	Status      string `json:"status"`
+	OpeningHour string `json:"opening_hour"`
+	ClosingHour string `json:"closing_hour"`
+	IsDeleted   bool   `json:"is_deleted"`
+}
+
+// FacilityClient reads facilities from the facility service
+type FacilityClient struct {
+	baseClient
+}
+
+// NewFacilityClient creates a new FacilityClient
+func NewFacilityClient(cfg config.ServiceClientConfig) *FacilityClient {
+	return &FacilityClient{baseClient: newBaseClient(cfg)}
+}
+
+// GetFacility returns a facility by its ID
+func (c *FacilityClient) GetFacility(ctx context.Context, id int) (Facility, error) {
+	var facility Facility
+	if err := c.get(ctx, fmt.Sprintf("/api/v1/facilities/%d", id), &facility); err != nil {
+		return Facility{}, err
+	}
+	return facility, nil
+}
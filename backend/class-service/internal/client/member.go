@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/config"
+)
+
+// MemberActive is the status of members who may use the fitness center
+const MemberActive = "active"
+
+// Member is a member as returned by the member service
+type Member struct {
+	ID        int    `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Status    string `json:"status"`
+}
+
+// MemberClient reads members and their memberships from the member service
+type MemberClient struct {
+	baseClient
+}
+
+// NewMemberClient creates a new MemberClient
+func NewMemberClient(cfg config.ServiceClientConfig) *MemberClient {
+	return &MemberClient{baseClient: newBaseClient(cfg)}
+}
+
+// GetMember returns a member by its ID
+func (c *MemberClient) GetMember(ctx context.Context, id int) (Member, error) {
+	var member Member
+	if err := c.get(ctx, fmt.Sprintf("/api/v1/members/%d", id), &member); err != nil {
+		return Member{}, err
+	}
+	return member, nil
+}
+
+// MemberMembership is the membership a member holds, as returned by the
+// member service. Dates are given as YYYY-MM-DD.
+type MemberMembership struct {
+	ID           int    `json:"id"`
+	MemberID     int    `json:"member_id"`
+	MembershipID int    `json:"membership_id"`
+	StartDate    string `json:"start_date"`
+	EndDate      string `json:"end_date"`
+}
+
+// GetActiveMembership returns the paid membership of a member that has not
+// ended, and false if the member has none
+func (c *MemberClient) GetActiveMembership(ctx context.Context, id int) (MemberMembership, bool, error) {
+	var response struct {
+		Active     bool             `json:"active"`
+		Membership MemberMembership `json:"membership"`
+	}
+	if err := c.get(ctx, fmt.Sprintf("/api/v1/members/%d/active-membership", id), &response); err != nil {
+		return MemberMembership{}, false, err
+	}
+	return response.Membership, response.Active, nil
+}
+
+// HasActiveMembership reports whether a member has a paid membership that
+// has not ended
+func (c *MemberClient) HasActiveMembership(ctx context.Context, id int) (bool, error) {
+	_, active, err := c.GetActiveMembership(ctx, id)
+	return active, err
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/config"
+)
+
+// Statuses of payments
+const (
+	// PaymentPending is the status of payments that are charged but not paid
+	// yet
+	PaymentPending = "pending"
+	// PaymentRefunded is the status of payments that were given back
+	PaymentRefunded = "refunded"
+)
+
+// PaymentRequest is a payment as created in the payment service
+type PaymentRequest struct {
+	MemberID      int     `json:"member_id"`
+	Amount        float64 `json:"amount"`
+	PaymentMethod string  `json:"payment_method"`
+	PaymentStatus string  `json:"payment_status"`
+	InvoiceNumber *string `json:"invoice_number,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	PaymentTypeID *int    `json:"payment_type_id,omitempty"`
+}
+
+// Payment is a payment as returned by the payment service
+type Payment struct {
+	PaymentID     int     `json:"payment_id"`
+	MemberID      int     `json:"member_id"`
+	Amount        float64 `json:"amount"`
+	PaymentStatus string  `json:"payment_status"`
+}
+
+// PaymentClient creates payments in the payment service
+type PaymentClient struct {
+	baseClient
+}
+
+// NewPaymentClient creates a new PaymentClient
+func NewPaymentClient(cfg config.ServiceClientConfig) *PaymentClient {
+	return &PaymentClient{baseClient: newBaseClient(cfg)}
+}
+
+// CreatePayment creates a payment and returns it with its ID
+func (c *PaymentClient) CreatePayment(ctx context.Context, req PaymentRequest) (Payment, error) {
+	var payment Payment
+	if err := c.post(ctx, "/api/v1/payments", req, &payment); err != nil {
+		return Payment{}, err
+	}
+	return payment, nil
+}
+
+// FindPaymentByInvoice returns the payment with an invoice number, or
+// ErrNotFound if there is none
+func (c *PaymentClient) FindPaymentByInvoice(ctx context.Context, invoice string) (Payment, error) {
+	var page struct {
+		Data []Payment `json:"data"`
+	}
+	path := "/api/v1/payments?pageSize=1&invoice_number=" + url.QueryEscape(invoice)
+	if err := c.get(ctx, path, &page); err != nil {
+		return Payment{}, err
+	}
+	if len(page.Data) == 0 {
+		return Payment{}, ErrNotFound
+	}
+	return page.Data[0], nil
+}
+
+// RefundPayment sets the status of a payment to refunded. The payment service
+// only updates whole payments, so the payment is read first and written back
+// unchanged apart from its status.
+func (c *PaymentClient) RefundPayment(ctx context.Context, id int) error {
+	var payment map[string]interface{}
+	path := fmt.Sprintf("/api/v1/payments/%d", id)
+	if err := c.get(ctx, path, &payment); err != nil {
+		return err
+	}
+	if payment["payment_status"] == PaymentRefunded {
+		return nil
+	}
+
+	payment["payment_status"] = PaymentRefunded
+	var updated map[string]interface{}
+	return c.put(ctx, path, payment, &updated)
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/config"
+)
+
+// Trainer is a trainer as returned by the staff service
+type Trainer struct {
+	TrainerID      int    `json:"trainer_id"`
+	StaffID        int    `json:"staff_id"`
+	Specialization string `json:"specialization"`
+	IsActive       bool   `json:"is_active"`
+}
+
+// TrainerRating is the rating of a trainer synced to the staff service
+type TrainerRating struct {
+	Rating      float64 `json:"rating"`
+	RatingCount int     `json:"rating_count"`
+}
+
+// StaffClient reads trainers from the staff service and syncs their ratings
+type StaffClient struct {
+	baseClient
+}
+
+// NewStaffClient creates a new StaffClient
+func NewStaffClient(cfg config.ServiceClientConfig) *StaffClient {
+	return &StaffClient{baseClient: newBaseClient(cfg)}
+}
+
+// GetTrainer returns a trainer by its ID. The staff service does not return
+// inactive trainers, so they are reported as ErrNotFound.
+func (c *StaffClient) GetTrainer(ctx context.Context, id int) (Trainer, error) {
+	var trainer Trainer
+	if err := c.get(ctx, fmt.Sprintf("/api/v1/trainers/%d", id), &trainer); err != nil {
+		return Trainer{}, err
+	}
+	return trainer, nil
+}
+
+// UpdateTrainerRating sets the rating of a trainer from class feedback. The
+// staff service only accepts it with a service token with the ratings:sync
+// scope.
+func (c *StaffClient) UpdateTrainerRating(ctx context.Context, id int, rating TrainerRating) (Trainer, error) {
+	var trainer Trainer
+	if err := c.put(ctx, fmt.Sprintf("/api/v1/trainers/%d/rating", id), rating, &trainer); err != nil {
+		return Trainer{}, err
+	}
+	return trainer, nil
+}
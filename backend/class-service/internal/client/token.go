@@ -0,0 +1,114 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/config"
+)
+
+// tokenRenewBefore is how long before it expires a service token is replaced
+const tokenRenewBefore = time.Minute
+
+// tokenSource requests service tokens from the auth service with the client
+// credentials grant and reuses each token until shortly before it expires
+type tokenSource struct {
+	cfg        config.CredentialsConfig
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	current   string
+	expiresAt time.Time
+}
+
+// tokenSources holds one token source per set of credentials, so the clients
+// of all services share their token
+var (
+	tokenSourcesMu sync.Mutex
+	tokenSources   = map[config.CredentialsConfig]*tokenSource{}
+)
+
+// sharedTokenSource returns the token source of the credentials, or nil when
+// no credentials are configured
+func sharedTokenSource(cfg config.CredentialsConfig, httpClient *http.Client) *tokenSource {
+	if cfg.TokenURL == "" || cfg.ClientID == "" {
+		return nil
+	}
+
+	tokenSourcesMu.Lock()
+	defer tokenSourcesMu.Unlock()
+
+	source, ok := tokenSources[cfg]
+	if !ok {
+		source = &tokenSource{cfg: cfg, httpClient: httpClient}
+		tokenSources[cfg] = source
+	}
+	return source
+}
+
+// tokenResponse is the response of the auth service's token endpoint
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// token returns a valid service token, requesting a new one when needed
+func (s *tokenSource) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.current != "" && time.Now().Before(s.expiresAt) {
+		return s.current, nil
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"grant_type":    "client_credentials",
+		"client_id":     s.cfg.ClientID,
+		"client_secret": s.cfg.ClientSecret,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.TokenURL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("token request: %v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("token request: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	var token tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil || token.AccessToken == "" {
+		return "", fmt.Errorf("token request: invalid response")
+	}
+
+	lifetime := time.Duration(token.ExpiresIn) * time.Second
+	s.current = token.AccessToken
+	s.expiresAt = time.Now().Add(lifetime - min(tokenRenewBefore, lifetime/2))
+	return s.current, nil
+}
+
+// reset drops the current token, so the next request asks for a new one
+func (s *tokenSource) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current = ""
+}
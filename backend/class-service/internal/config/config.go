@@ -15,6 +15,11 @@ import (
 type Config struct {
 	Server   ServerConfig
 	Database DatabaseConfig
+	Schedule ScheduleConfig
+	Booking  BookingConfig
+	Penalty  PenaltyConfig
+	Calendar CalendarConfig
+	Clients  ClientsConfig
 }
 
 // ServerConfig holds HTTP server configuration
@@ -25,6 +30,82 @@ type ServerConfig struct {
 	IdleTimeout  time.Duration
 }
 
+// ScheduleConfig holds settings for the occurrences generated from schedules
+type ScheduleConfig struct {
+	// OccurrenceHorizonDays is how many days ahead occurrences are generated
+	OccurrenceHorizonDays int
+	// Location is the time zone of the center. Class dates and times are
+	// wall-clock times in it.
+	Location *time.Location
+}
+
+// BookingConfig holds booking and waitlist settings
+type BookingConfig struct {
+	// WaitlistOfferWindow is how long a waitlisted member has to confirm a
+	// freed seat. Zero books the member into the seat straight away.
+	WaitlistOfferWindow time.Duration
+	// NoShowGracePeriod is how long after a class ends bookings that are
+	// still booked are marked as no-shows
+	NoShowGracePeriod time.Duration
+	// NoShowJobInterval is how often the no-show job runs. Zero turns it off.
+	NoShowJobInterval time.Duration
+	// RatingSyncInterval is how often every trainer rating is pushed to the
+	// staff service, catching up on updates that failed. Zero turns it off.
+	RatingSyncInterval time.Duration
+}
+
+// PenaltyConfig holds settings for the late-cancel and no-show fees posted
+// to the payment service
+type PenaltyConfig struct {
+	// PaymentMethod is the payment method the fees are posted with
+	PaymentMethod string
+	// PaymentTypeID is the payment type of the fees. Zero leaves it unset.
+	PaymentTypeID int
+}
+
+// CalendarConfig holds settings for the iCalendar feeds
+type CalendarConfig struct {
+	// HistoryDays is how many days of past classes the feeds include
+	HistoryDays int
+	// PublicURL is the address calendar apps reach the API gateway at, used
+	// to give out full feed URLs. Empty gives out feed paths only.
+	PublicURL string
+}
+
+// ClientsConfig holds the clients of the services whose members, trainers
+// and rooms class-service refers to by ID, and of the payment service that
+// penalty fees are charged through
+type ClientsConfig struct {
+	Member   ServiceClientConfig
+	Staff    ServiceClientConfig
+	Facility ServiceClientConfig
+	Payment  ServiceClientConfig
+}
+
+// ServiceClientConfig configures the client of another service
+type ServiceClientConfig struct {
+	// BaseURL is the address of the service. Empty turns its checks, or
+	// posting penalty fees and syncing trainer ratings, off.
+	BaseURL string
+	// Timeout limits each request to the service
+	Timeout time.Duration
+	// FailOpen accepts IDs unchecked while the service is unavailable,
+	// instead of rejecting the request
+	FailOpen bool
+	// Credentials are used to request the service token sent to the service
+	Credentials CredentialsConfig
+}
+
+// CredentialsConfig holds the client credentials class-service requests
+// service tokens with from the auth service
+type CredentialsConfig struct {
+	// TokenURL is the client credentials token endpoint of the auth service.
+	// Empty sends requests to other services without a token.
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+}
+
 type DatabaseConfig struct {
 	Host     string
 	Port     int
@@ -84,16 +165,68 @@ func LoadConfig() Config {
 			DBName:   getEnv("CLASS_SERVICE_DB_NAME", "fitness_class_db"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
 		},
+		Schedule: ScheduleConfig{
+			OccurrenceHorizonDays: getEnvAsInt("CLASS_SERVICE_OCCURRENCE_HORIZON_DAYS", 28),
+			Location:              getEnvAsLocation("CLASS_SERVICE_TIMEZONE", time.UTC),
+		},
+		Booking: BookingConfig{
+			WaitlistOfferWindow: getEnvAsDuration("CLASS_SERVICE_WAITLIST_OFFER_WINDOW", 0),
+			NoShowGracePeriod:   getEnvAsDuration("CLASS_SERVICE_NO_SHOW_GRACE_PERIOD", 30*time.Minute),
+			NoShowJobInterval:   getEnvAsDuration("CLASS_SERVICE_NO_SHOW_JOB_INTERVAL", 5*time.Minute),
+			RatingSyncInterval:  getEnvAsDuration("CLASS_SERVICE_RATING_SYNC_INTERVAL", time.Hour),
+		},
+		Penalty: PenaltyConfig{
+			PaymentMethod: getEnv("CLASS_SERVICE_PENALTY_PAYMENT_METHOD", "credit_card"),
+			PaymentTypeID: getEnvAsInt("CLASS_SERVICE_PENALTY_PAYMENT_TYPE_ID", 0),
+		},
+		Calendar: CalendarConfig{
+			HistoryDays: getEnvAsInt("CLASS_SERVICE_CALENDAR_HISTORY_DAYS", 30),
+			PublicURL:   getEnv("CLASS_SERVICE_CALENDAR_PUBLIC_URL", ""),
+		},
+	}
+
+	credentials := CredentialsConfig{
+		TokenURL:     getEnv("CLASS_SERVICE_TOKEN_URL", ""),
+		ClientID:     getEnv("CLASS_SERVICE_CLIENT_ID", "class-service"),
+		ClientSecret: getEnv("CLASS_SERVICE_CLIENT_SECRET", ""),
+	}
+	config.Clients = ClientsConfig{
+		Member:   serviceClientConfig("MEMBER", "http://localhost:8001", credentials),
+		Staff:    serviceClientConfig("STAFF", "http://localhost:8002", credentials),
+		Facility: serviceClientConfig("FACILITY", "http://localhost:8004", credentials),
+		Payment:  serviceClientConfig("PAYMENT", "http://localhost:8003", credentials),
 	}
 
 	// Log the configuration to help with debugging
 	log.Printf("Server configuration: port=%d", config.Server.Port)
 	log.Printf("Database configuration: host=%s, port=%d, dbname=%s",
 		config.Database.Host, config.Database.Port, config.Database.DBName)
+	log.Printf("Schedule configuration: timezone=%s", config.Schedule.Location)
+	log.Printf("Service clients: member=%q, staff=%q, facility=%q, payment=%q",
+		config.Clients.Member.BaseURL, config.Clients.Staff.BaseURL, config.Clients.Facility.BaseURL,
+		config.Clients.Payment.BaseURL)
+	if credentials.TokenURL == "" {
+		log.Printf("Warning: CLASS_SERVICE_TOKEN_URL is not set, other services are called without a service token")
+	} else {
+		log.Printf("Service token: client_id=%s, token_url=%s", credentials.ClientID, credentials.TokenURL)
+	}
 
 	return config
 }
 
+// serviceClientConfig reads the client settings of another service from the
+// CLASS_SERVICE_<name>_SERVICE_URL, _TIMEOUT and _FAIL_OPEN variables. IDs are
+// rejected while the service is unavailable unless _FAIL_OPEN is true.
+func serviceClientConfig(name, defaultURL string, credentials CredentialsConfig) ServiceClientConfig {
+	prefix := "CLASS_SERVICE_" + name + "_SERVICE_"
+	return ServiceClientConfig{
+		BaseURL:     getEnv(prefix+"URL", defaultURL),
+		Timeout:     getEnvAsDuration(prefix+"TIMEOUT", 3*time.Second),
+		FailOpen:    getEnvAsBool(prefix+"FAIL_OPEN", false),
+		Credentials: credentials,
+	}
+}
+
 // findServiceEnvFile looks for the service-specific .env file
 func findServiceEnvFile() string {
 	// Start from current working directory
@@ -193,3 +326,26 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+// Helper function to get environment variables as booleans
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvAsLocation reads an IANA time zone name such as "Europe/Istanbul".
+// An unknown name stops the service, as class times would be wrong.
+func getEnvAsLocation(key string, defaultValue *time.Location) *time.Location {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+	loc, err := time.LoadLocation(valueStr)
+	if err != nil || loc == time.Local {
+		log.Fatalf("Invalid %s %q: must be an IANA time zone name", key, valueStr)
+	}
+	return loc
+}
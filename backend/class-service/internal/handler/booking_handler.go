@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/FurkanArikk/fitness-center/backend/class-service/pkg/dto"
+	"github.com/FurkanArikk/fitness-center/backend/identity"
 	"github.com/gin-gonic/gin"
 )
 
@@ -14,10 +15,35 @@ func (h *BookingHandler) GetBookings(c *gin.Context) {
 	status := c.Query("status")
 	date := c.Query("date")
 
+	memberID := 0
+	if c.Query("member_id") != "" {
+		id, err := strconv.Atoi(c.Query("member_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid member ID"})
+			return
+		}
+		memberID = id
+	}
+
+	occurrenceID := 0
+	if c.Query("occurrence_id") != "" {
+		id, err := strconv.Atoi(c.Query("occurrence_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid occurrence ID"})
+			return
+		}
+		occurrenceID = id
+	}
+
+	// Members only see their own bookings
+	if caller, ok := identity.FromContext(c.Request.Context()); ok && caller.IsMember() {
+		memberID = caller.MemberID
+	}
+
 	// Parse pagination parameters
 	params := ParsePaginationParams(c)
 
-	bookings, total, err := h.service.GetBookingsPaginated(c.Request.Context(), status, date, params.Offset, params.PageSize)
+	bookings, total, err := h.service.GetBookingsPaginated(c.Request.Context(), status, date, memberID, occurrenceID, params.Offset, params.PageSize)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -39,7 +65,7 @@ func (h *BookingHandler) GetBookingByID(c *gin.Context) {
 	}
 
 	booking, err := h.service.GetBookingByID(c.Request.Context(), id)
-	if err != nil {
+	if err != nil || !canAccessBooking(c, booking.MemberID) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Booking not found"})
 		return
 	}
@@ -60,21 +86,34 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 		return
 	}
 
+	if !canAccessBooking(c, req.MemberID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Members can only book for themselves"})
+		return
+	}
+
 	// Convert DTO to model
 	modelReq := req.ToModel()
 
 	booking, err := h.service.CreateBooking(c.Request.Context(), modelReq)
 	if err != nil {
-		if err.Error() == "class is already at full capacity" {
+		if referenceError(c, err) || suspensionError(c, err) {
+			return
+		}
+		if err.Error() == "class is already at full capacity" ||
+			err.Error() == "member has not enough class credits" {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
-		} else if err.Error() == "invalid schedule ID" {
+		} else if err.Error() == "class occurrence not found" ||
+			err.Error() == "occurrence_id or schedule_id and booking_date are required" ||
+			err.Error() == "class occurrence is cancelled" ||
+			err.Error() == "class occurrence has already started" ||
+			err.Error() == "class occurrence falls in a closure" {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
-		} else if err.Error() == "member already has a booking for this schedule" ||
+		} else if err.Error() == "member already has a booking for this class" ||
 			err.Error() == "ERROR: duplicate key value violates unique constraint \"unique_booking\" (SQLSTATE 23505)" ||
 			err.Error() == "failed to create booking: ERROR: duplicate key value violates unique constraint \"unique_booking\" (SQLSTATE 23505)" {
-			c.JSON(http.StatusConflict, gin.H{"error": "Member already has a booking for this schedule"})
+			c.JSON(http.StatusConflict, gin.H{"error": "Member already has a booking for this class"})
 			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -98,6 +137,12 @@ func (h *BookingHandler) UpdateBooking(c *gin.Context) {
 		return
 	}
 
+	// Attendance is recorded by staff
+	if caller, ok := identity.FromContext(c.Request.Context()); ok && caller.IsMember() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
 	var req dto.BookingStatusUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -106,6 +151,19 @@ func (h *BookingHandler) UpdateBooking(c *gin.Context) {
 
 	booking, err := h.service.UpdateBookingStatus(c.Request.Context(), id, req.AttendanceStatus)
 	if err != nil {
+		if err.Error() == "booking not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Booking not found"})
+			return
+		}
+		if err.Error() == "class is already at full capacity" ||
+			err.Error() == "member has not enough class credits" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "booking was changed by another request" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -128,22 +186,35 @@ func (h *BookingHandler) DeleteBooking(c *gin.Context) {
 	}
 
 	// First check if booking exists
-	_, err = h.service.GetBookingByID(c.Request.Context(), id)
-	if err != nil {
+	existing, err := h.service.GetBookingByID(c.Request.Context(), id)
+	if err != nil || !canAccessBooking(c, existing.MemberID) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Booking not found"})
 		return
 	}
 
-	_, err = h.service.CancelBooking(c.Request.Context(), id)
+	_, penalty, err := h.service.CancelBooking(c.Request.Context(), id)
 	if err != nil {
 		if err.Error() == "only bookings with 'booked' status can be cancelled" {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == "booking was changed by another request" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	// Late cancellations report the penalty they incurred
+	if penalty != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Booking cancelled late, a penalty was recorded",
+			"penalty": dto.PenaltyResponseFromModel(*penalty),
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Booking cancelled successfully",
 	})
@@ -158,8 +229,8 @@ func (h *BookingHandler) AddFeedback(c *gin.Context) {
 	}
 
 	// Check if booking exists
-	_, err = h.service.GetBookingByID(c.Request.Context(), id)
-	if err != nil {
+	existing, err := h.service.GetBookingByID(c.Request.Context(), id)
+	if err != nil || !canAccessBooking(c, existing.MemberID) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Booking not found"})
 		return
 	}
@@ -191,3 +262,13 @@ func (h *BookingHandler) AddFeedback(c *gin.Context) {
 		"message": "Feedback added successfully",
 	})
 }
+
+// canAccessBooking reports whether the caller may access a booking of the
+// given member. Admins may access every booking, members only their own.
+func canAccessBooking(c *gin.Context, memberID int) bool {
+	caller, ok := identity.FromContext(c.Request.Context())
+	if !ok || !caller.IsMember() {
+		return true
+	}
+	return caller.MemberID != 0 && caller.MemberID == memberID
+}
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/calendar"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/pkg/dto"
+	"github.com/FurkanArikk/fitness-center/backend/identity"
+	"github.com/gin-gonic/gin"
+)
+
+// feedPath is the path of the public feed that opens with a token
+const feedPath = "/api/v1/calendar/"
+
+// GetScheduleCalendar handles GET /schedules/calendar
+func (h *CalendarHandler) GetScheduleCalendar(c *gin.Context) {
+	body, err := h.service.ScheduleCalendar(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeCalendar(c, "schedule", body)
+}
+
+// GetTrainerCalendar handles GET /schedules/calendar/trainers/:trainer_id
+func (h *CalendarHandler) GetTrainerCalendar(c *gin.Context) {
+	trainerID, err := strconv.Atoi(c.Param("trainer_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid trainer ID"})
+		return
+	}
+
+	body, err := h.service.TrainerCalendar(c.Request.Context(), trainerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeCalendar(c, fmt.Sprintf("trainer-%d", trainerID), body)
+}
+
+// GetRoomCalendar handles GET /schedules/calendar/rooms/:room_id
+func (h *CalendarHandler) GetRoomCalendar(c *gin.Context) {
+	roomID, err := strconv.Atoi(c.Param("room_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid room ID"})
+		return
+	}
+
+	body, err := h.service.RoomCalendar(c.Request.Context(), roomID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeCalendar(c, fmt.Sprintf("room-%d", roomID), body)
+}
+
+// GetMemberCalendar handles GET /bookings/calendar/members/:member_id
+func (h *CalendarHandler) GetMemberCalendar(c *gin.Context) {
+	memberID, err := strconv.Atoi(c.Param("member_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid member ID"})
+		return
+	}
+
+	if !canAccessBooking(c, memberID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Members can only see their own calendar"})
+		return
+	}
+
+	body, err := h.service.MemberCalendar(c.Request.Context(), memberID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeCalendar(c, fmt.Sprintf("member-%d", memberID), body)
+}
+
+// GetFeedCalendar handles GET /calendar/:token. The token is the credential,
+// so this route is reachable without logging in; a ".ics" suffix is allowed
+// for calendar apps that expect one.
+func (h *CalendarHandler) GetFeedCalendar(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	body, err := h.service.FeedCalendar(c.Request.Context(), token)
+	if err != nil {
+		if err.Error() == "calendar feed not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Calendar feed not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeCalendar(c, "classes", body)
+}
+
+// CreateFeed handles POST /bookings/calendar/feeds
+func (h *CalendarHandler) CreateFeed(c *gin.Context) {
+	var req dto.CalendarFeedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	feedReq := req.ToModel()
+	if caller, ok := identity.FromContext(c.Request.Context()); ok {
+		feedReq.CreatedBy = caller.UserID
+	}
+
+	// Members can subscribe to the timetables, but only to their own bookings
+	if feedReq.FeedType == model.FeedMember && !canAccessBooking(c, feedReq.SubjectID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Members can only subscribe to their own calendar"})
+		return
+	}
+
+	feed, token, err := h.service.CreateFeed(c.Request.Context(), feedReq)
+	if err != nil {
+		switch err.Error() {
+		case "calendar feed subject ID is required", "invalid calendar feed type":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	response := dto.CalendarFeedCreatedResponse{
+		CalendarFeedResponse: dto.CalendarFeedResponseFromModel(feed),
+		Token:                token,
+		TokenPath:            feedPath + token + ".ics",
+	}
+	if h.publicURL != "" {
+		response.TokenURL = strings.TrimSuffix(h.publicURL, "/") + response.TokenPath
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    response,
+		"message": "Calendar feed created successfully",
+	})
+}
+
+// GetFeeds handles GET /bookings/calendar/feeds
+func (h *CalendarHandler) GetFeeds(c *gin.Context) {
+	filter := model.CalendarFeedFilter{
+		FeedType:   c.Query("feed_type"),
+		ActiveOnly: c.Query("active") == "true",
+	}
+
+	if c.Query("subject_id") != "" {
+		id, err := strconv.Atoi(c.Query("subject_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid subject ID"})
+			return
+		}
+		filter.SubjectID = id
+	}
+
+	// Members only see the feeds they created
+	if caller, ok := identity.FromContext(c.Request.Context()); ok && caller.IsMember() {
+		filter.CreatedBy = caller.UserID
+	}
+
+	params := ParsePaginationParams(c)
+
+	feeds, total, err := h.service.GetFeedsPaginated(c.Request.Context(), filter, params.Offset, params.PageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := CreatePaginatedResponse(dto.CalendarFeedResponseListFromModel(feeds), params, total)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetFeed handles GET /bookings/calendar/feeds/:id
+func (h *CalendarHandler) GetFeed(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar feed ID"})
+		return
+	}
+
+	feed, err := h.service.GetFeed(c.Request.Context(), id)
+	if err != nil || !canAccessFeed(c, feed) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Calendar feed not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.CalendarFeedResponseFromModel(feed),
+	})
+}
+
+// RevokeFeed handles DELETE /bookings/calendar/feeds/:id
+func (h *CalendarHandler) RevokeFeed(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar feed ID"})
+		return
+	}
+
+	existing, err := h.service.GetFeed(c.Request.Context(), id)
+	if err != nil || !canAccessFeed(c, existing) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Calendar feed not found"})
+		return
+	}
+
+	feed, err := h.service.RevokeFeed(c.Request.Context(), id)
+	if err != nil {
+		switch err.Error() {
+		case "calendar feed not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Calendar feed not found"})
+		case "calendar feed is already revoked":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    dto.CalendarFeedResponseFromModel(feed),
+		"message": "Calendar feed revoked successfully",
+	})
+}
+
+// canAccessFeed reports whether the caller may see and revoke a calendar
+// feed. Members can only reach the feeds they created.
+func canAccessFeed(c *gin.Context, feed model.CalendarFeed) bool {
+	caller, ok := identity.FromContext(c.Request.Context())
+	if !ok || !caller.IsMember() {
+		return true
+	}
+	return feed.CreatedBy != "" && feed.CreatedBy == caller.UserID
+}
+
+// writeCalendar writes an iCalendar document, named for the apps that save
+// it as a file
+func writeCalendar(c *gin.Context, name string, body []byte) {
+	// The JSON content type set for all API responses is replaced
+	c.Header("Content-Type", calendar.ContentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s.ics"`, name))
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, calendar.ContentType, body)
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/pkg/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// GetClosures handles GET /closures
+func (h *ClosureHandler) GetClosures(c *gin.Context) {
+	filter := model.ClosureFilter{Scope: c.Query("scope")}
+
+	if c.Query("subject_id") != "" {
+		id, err := strconv.Atoi(c.Query("subject_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid subject ID"})
+			return
+		}
+		filter.SubjectID = id
+	}
+
+	dates := []struct {
+		param  string
+		target **time.Time
+	}{
+		{"from", &filter.From},
+		{"to", &filter.To},
+	}
+	for _, date := range dates {
+		if c.Query(date.param) == "" {
+			continue
+		}
+		value, err := time.Parse(dto.DateLayout, c.Query(date.param))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + date.param + " date, expected YYYY-MM-DD"})
+			return
+		}
+		*date.target = &value
+	}
+
+	params := ParsePaginationParams(c)
+
+	closures, total, err := h.service.GetClosuresPaginated(c.Request.Context(), filter, params.Offset, params.PageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := CreatePaginatedResponse(dto.ClosureResponseListFromModel(closures), params, total)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetClosure handles GET /closures/:id
+func (h *ClosureHandler) GetClosure(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid closure ID"})
+		return
+	}
+
+	closure, err := h.service.GetClosure(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == "closure not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Closure not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.ClosureResponseFromModel(closure),
+	})
+}
+
+// CreateClosure handles POST /closures
+func (h *ClosureHandler) CreateClosure(c *gin.Context) {
+	if !isStaff(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	var req dto.ClosureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	closure, err := req.ToModel()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.CreateClosure(c.Request.Context(), closure)
+	if err != nil {
+		switch err.Error() {
+		case "center closures cannot have a subject ID", "closure subject ID is required", "invalid closure scope",
+			"end date must not be before start date", "closure cannot end in the past":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    dto.ClosureCreatedResponseFromModel(result),
+		"message": "Closure created successfully",
+	})
+}
+
+// GetClosureBookings handles GET /closures/:id/bookings
+func (h *ClosureHandler) GetClosureBookings(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid closure ID"})
+		return
+	}
+
+	if !isStaff(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	bookings, err := h.service.GetClosureBookings(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == "closure not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Closure not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":                dto.ClosedBookingResponseListFromModel(bookings),
+		"affected_member_ids": dto.AffectedMemberIDs(bookings),
+	})
+}
+
+// DeleteClosure handles DELETE /closures/:id
+func (h *ClosureHandler) DeleteClosure(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid closure ID"})
+		return
+	}
+
+	if !isStaff(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	if err := h.service.DeleteClosure(c.Request.Context(), id); err != nil {
+		if err.Error() == "closure not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Closure not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Closure deleted successfully",
+	})
+}
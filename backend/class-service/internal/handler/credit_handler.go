@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/pkg/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// GetCreditBalance handles GET /bookings/credits/members/:member_id
+func (h *BookingHandler) GetCreditBalance(c *gin.Context) {
+	memberID, err := strconv.Atoi(c.Param("member_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid member ID"})
+		return
+	}
+
+	if !canAccessBooking(c, memberID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Members can only see their own credits"})
+		return
+	}
+
+	balance, err := h.service.GetCreditBalance(c.Request.Context(), memberID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.CreditBalanceResponseFromModel(balance),
+	})
+}
+
+// GetCreditHistory handles GET /bookings/credits/members/:member_id/history
+func (h *BookingHandler) GetCreditHistory(c *gin.Context) {
+	memberID, err := strconv.Atoi(c.Param("member_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid member ID"})
+		return
+	}
+
+	if !canAccessBooking(c, memberID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Members can only see their own credits"})
+		return
+	}
+
+	params := ParsePaginationParams(c)
+
+	transactions, total, err := h.service.GetCreditHistoryPaginated(c.Request.Context(), memberID, params.Offset, params.PageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := CreatePaginatedResponse(dto.CreditTransactionResponseListFromModel(transactions), params, total)
+	c.JSON(http.StatusOK, response)
+}
+
+// GrantCredits handles POST /bookings/credits/grants
+func (h *BookingHandler) GrantCredits(c *gin.Context) {
+	if !isStaff(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	var req dto.CreditGrantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grant, err := h.service.GrantCredits(c.Request.Context(), req.ToModel())
+	if err != nil {
+		switch err.Error() {
+		case "credits can only be granted as a pack or manually", "expiry must be in the future":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    dto.CreditGrantResponseFromModel(grant),
+		"message": "Credits granted successfully",
+	})
+}
+
+// GetCreditPlans handles GET /bookings/credits/plans
+func (h *BookingHandler) GetCreditPlans(c *gin.Context) {
+	plans, err := h.service.GetCreditPlans(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.CreditPlanResponseListFromModel(plans),
+	})
+}
+
+// SaveCreditPlan handles PUT /bookings/credits/plans/:membership_id
+func (h *BookingHandler) SaveCreditPlan(c *gin.Context) {
+	membershipID, err := strconv.Atoi(c.Param("membership_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid membership ID"})
+		return
+	}
+
+	if !isStaff(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	var req dto.CreditPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plan, err := h.service.SaveCreditPlan(c.Request.Context(), req.ToModel(membershipID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    dto.CreditPlanResponseFromModel(plan),
+		"message": "Credit plan saved successfully",
+	})
+}
+
+// DeleteCreditPlan handles DELETE /bookings/credits/plans/:membership_id
+func (h *BookingHandler) DeleteCreditPlan(c *gin.Context) {
+	membershipID, err := strconv.Atoi(c.Param("membership_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid membership ID"})
+		return
+	}
+
+	if !isStaff(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	if err := h.service.DeleteCreditPlan(c.Request.Context(), membershipID); err != nil {
+		if err.Error() == "credit plan not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Credit plan not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Credit plan deleted successfully",
+	})
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/pkg/dto"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// defaultFeedbackComments is how many recent comments a summary has
+	// unless the comments parameter asks for more or fewer
+	defaultFeedbackComments = 5
+	// maxFeedbackComments caps the recent comments of a summary
+	maxFeedbackComments = 50
+)
+
+// GetFeedbackSummary handles GET /bookings/feedback
+func (h *BookingHandler) GetFeedbackSummary(c *gin.Context) {
+	var filter model.FeedbackFilter
+
+	ids := []struct {
+		param  string
+		target *int
+	}{
+		{"class_id", &filter.ClassID},
+		{"schedule_id", &filter.ScheduleID},
+		{"trainer_id", &filter.TrainerID},
+	}
+	for _, id := range ids {
+		if c.Query(id.param) == "" {
+			continue
+		}
+		value, err := strconv.Atoi(c.Query(id.param))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + id.param})
+			return
+		}
+		*id.target = value
+	}
+
+	h.writeFeedbackSummary(c, filter)
+}
+
+// GetClassFeedback handles GET /classes/:id/feedback
+func (h *BookingHandler) GetClassFeedback(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid class ID"})
+		return
+	}
+
+	h.writeFeedbackSummary(c, model.FeedbackFilter{ClassID: id})
+}
+
+// GetScheduleFeedback handles GET /schedules/:id/feedback
+func (h *BookingHandler) GetScheduleFeedback(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	h.writeFeedbackSummary(c, model.FeedbackFilter{ScheduleID: id})
+}
+
+// GetTrainerFeedback handles GET /bookings/feedback/trainers/:trainer_id
+func (h *BookingHandler) GetTrainerFeedback(c *gin.Context) {
+	trainerID, err := strconv.Atoi(c.Param("trainer_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid trainer ID"})
+		return
+	}
+
+	h.writeFeedbackSummary(c, model.FeedbackFilter{TrainerID: trainerID})
+}
+
+// SyncTrainerRatings handles POST /bookings/feedback/trainers/sync
+func (h *BookingHandler) SyncTrainerRatings(c *gin.Context) {
+	if !isStaff(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	synced, err := h.service.SyncTrainerRatings(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    dto.TrainerRatingSyncResponse{Synced: synced},
+		"message": "Trainer ratings synced successfully",
+	})
+}
+
+// writeFeedbackSummary reads the from, to and comments parameters into a
+// feedback summary and writes it. Members are not told who commented.
+func (h *BookingHandler) writeFeedbackSummary(c *gin.Context, filter model.FeedbackFilter) {
+	dates := []struct {
+		param  string
+		target **time.Time
+	}{
+		{"from", &filter.From},
+		{"to", &filter.To},
+	}
+	for _, date := range dates {
+		if c.Query(date.param) == "" {
+			continue
+		}
+		value, err := time.Parse(dto.DateLayout, c.Query(date.param))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + date.param + " date, expected YYYY-MM-DD"})
+			return
+		}
+		*date.target = &value
+	}
+
+	comments := defaultFeedbackComments
+	if c.Query("comments") != "" {
+		value, err := strconv.Atoi(c.Query("comments"))
+		if err != nil || value < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comments"})
+			return
+		}
+		comments = min(value, maxFeedbackComments)
+	}
+
+	summary, err := h.service.GetFeedbackSummary(c.Request.Context(), filter, comments)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.FeedbackSummaryResponseFromModel(filter, summary, isStaff(c)),
+	})
+}
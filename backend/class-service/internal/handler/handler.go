@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/config"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/db"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/service"
+	"github.com/gin-gonic/gin"
 )
 
 // ClassHandler handles class-related requests
@@ -19,22 +25,46 @@ type ScheduleHandler struct {
 	classService model.ClassService
 }
 
+// OccurrenceHandler handles requests for dated class occurrences
+type OccurrenceHandler struct {
+	db      *db.PostgresDB
+	service model.OccurrenceService
+}
+
 // BookingHandler handles booking-related requests
 type BookingHandler struct {
 	db      *db.PostgresDB
 	service model.BookingService
 }
 
+// CalendarHandler handles iCalendar feed requests
+type CalendarHandler struct {
+	db      *db.PostgresDB
+	service model.CalendarService
+	// publicURL is the gateway address feed URLs are given out with
+	publicURL string
+}
+
+// ClosureHandler handles requests for closures of the center, rooms and
+// trainers
+type ClosureHandler struct {
+	db      *db.PostgresDB
+	service model.ClosureService
+}
+
 // Handler provides the interface to the handler functions
 type Handler struct {
-	db              *db.PostgresDB
-	ClassHandler    *ClassHandler
-	ScheduleHandler *ScheduleHandler
-	BookingHandler  *BookingHandler
+	db                *db.PostgresDB
+	ClassHandler      *ClassHandler
+	ScheduleHandler   *ScheduleHandler
+	OccurrenceHandler *OccurrenceHandler
+	BookingHandler    *BookingHandler
+	CalendarHandler   *CalendarHandler
+	ClosureHandler    *ClosureHandler
 }
 
 // NewHandlers creates a new handler instance with the given database connection
-func NewHandlers(services *service.Service, db *db.PostgresDB) *Handler {
+func NewHandlers(services *service.Service, db *db.PostgresDB, calendarCfg config.CalendarConfig) *Handler {
 	handler := &Handler{
 		db: db,
 	}
@@ -42,7 +72,41 @@ func NewHandlers(services *service.Service, db *db.PostgresDB) *Handler {
 	// Initialize sub-handlers with services
 	handler.ClassHandler = &ClassHandler{db: db, service: services.ClassService}
 	handler.ScheduleHandler = &ScheduleHandler{db: db, service: services.ScheduleService, classService: services.ClassService}
+	handler.OccurrenceHandler = &OccurrenceHandler{db: db, service: services.OccurrenceService}
 	handler.BookingHandler = &BookingHandler{db: db, service: services.BookingService}
+	handler.CalendarHandler = &CalendarHandler{db: db, service: services.CalendarService, publicURL: calendarCfg.PublicURL}
+	handler.ClosureHandler = &ClosureHandler{db: db, service: services.ClosureService}
 
 	return handler
 }
+
+// referenceError writes the response for a member, trainer or room that
+// another service rejected or could not be asked about. It reports whether
+// err was such an error.
+func referenceError(c *gin.Context, err error) bool {
+	var referenceErr *model.ReferenceError
+	var unavailableErr *model.ServiceUnavailableError
+	switch {
+	case errors.As(err, &referenceErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": referenceErr.Error()})
+	case errors.As(err, &unavailableErr):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": unavailableErr.Error()})
+	default:
+		return false
+	}
+	return true
+}
+
+// suspensionError writes the response for a member who is suspended from
+// booking. It reports whether err was such an error.
+func suspensionError(c *gin.Context, err error) bool {
+	var suspensionErr *model.SuspensionError
+	if !errors.As(err, &suspensionErr) {
+		return false
+	}
+	c.JSON(http.StatusForbidden, gin.H{
+		"error":           suspensionErr.Error(),
+		"suspended_until": suspensionErr.Until.Format(time.RFC3339),
+	})
+	return true
+}
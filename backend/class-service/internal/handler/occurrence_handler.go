@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/pkg/dto"
+	"github.com/FurkanArikk/fitness-center/backend/identity"
+	"github.com/gin-gonic/gin"
+)
+
+// GetOccurrences handles GET /occurrences
+func (h *OccurrenceHandler) GetOccurrences(c *gin.Context) {
+	filter := model.OccurrenceFilter{Status: c.Query("status")}
+
+	ids := []struct {
+		param  string
+		target *int
+	}{
+		{"schedule_id", &filter.ScheduleID},
+		{"class_id", &filter.ClassID},
+		{"trainer_id", &filter.TrainerID},
+		{"room_id", &filter.RoomID},
+	}
+	for _, id := range ids {
+		if c.Query(id.param) == "" {
+			continue
+		}
+		value, err := strconv.Atoi(c.Query(id.param))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + id.param})
+			return
+		}
+		*id.target = value
+	}
+
+	dates := []struct {
+		param  string
+		target **time.Time
+	}{
+		{"from", &filter.From},
+		{"to", &filter.To},
+	}
+	for _, date := range dates {
+		if c.Query(date.param) == "" {
+			continue
+		}
+		value, err := time.Parse(dto.DateLayout, c.Query(date.param))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + date.param + " date, expected YYYY-MM-DD"})
+			return
+		}
+		*date.target = &value
+	}
+
+	params := ParsePaginationParams(c)
+
+	occurrences, total, err := h.service.GetOccurrencesPaginated(c.Request.Context(), filter, params.Offset, params.PageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := CreatePaginatedResponse(dto.OccurrenceResponseListFromModel(occurrences), params, total)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetOccurrenceByID handles GET /occurrences/:id
+func (h *OccurrenceHandler) GetOccurrenceByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid occurrence ID"})
+		return
+	}
+
+	occurrence, err := h.service.GetOccurrenceByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Class occurrence not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.OccurrenceResponseFromModel(occurrence),
+	})
+}
+
+// RescheduleOccurrence handles PUT /occurrences/:id
+func (h *OccurrenceHandler) RescheduleOccurrence(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid occurrence ID"})
+		return
+	}
+
+	if !isStaff(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	var req dto.OccurrenceRescheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	modelReq, err := req.ToModel()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	occurrence, err := h.service.RescheduleOccurrence(c.Request.Context(), id, modelReq)
+	if err != nil {
+		if referenceError(c, err) {
+			return
+		}
+		var conflictErr *model.ScheduleConflictError
+		if errors.As(err, &conflictErr) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":     conflictErr.Error(),
+				"conflicts": dto.ScheduleConflictListFromModel(conflictErr.Conflicts),
+			})
+			return
+		}
+		switch err.Error() {
+		case "class occurrence not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Class occurrence not found"})
+		case "cancelled class occurrences cannot be rescheduled", "class occurrence has already started":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case "start and end time must be given as HH:MM or HH:MM:SS", "end time must be after start time",
+			"class occurrence cannot be moved into the past", "class occurrence falls in a closure":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    dto.OccurrenceResponseFromOccurrence(occurrence),
+		"message": "Class occurrence rescheduled successfully",
+	})
+}
+
+// CancelOccurrence handles POST /occurrences/:id/cancel
+func (h *OccurrenceHandler) CancelOccurrence(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid occurrence ID"})
+		return
+	}
+
+	if !isStaff(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	// The reason is optional, so an empty body is accepted
+	var req dto.OccurrenceCancelRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	occurrence, err := h.service.CancelOccurrence(c.Request.Context(), id, req.Reason)
+	if err != nil {
+		switch err.Error() {
+		case "class occurrence not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Class occurrence not found"})
+		case "class occurrence is already cancelled", "class occurrence has already started":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    dto.OccurrenceResponseFromOccurrence(occurrence),
+		"message": "Class occurrence cancelled successfully",
+	})
+}
+
+// isStaff reports whether the caller is not a member. Members may look at
+// occurrences but not change them.
+func isStaff(c *gin.Context) bool {
+	caller, ok := identity.FromContext(c.Request.Context())
+	return !ok || !caller.IsMember()
+}
@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/pkg/dto"
+	"github.com/FurkanArikk/fitness-center/backend/identity"
+	"github.com/gin-gonic/gin"
+)
+
+// GetPolicy handles GET /classes/:id/policy
+func (h *ClassHandler) GetPolicy(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid class ID"})
+		return
+	}
+
+	policy, err := h.service.GetPolicy(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == "class not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Class not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.PolicyResponseFromModel(policy),
+	})
+}
+
+// UpdatePolicy handles PUT /classes/:id/policy
+func (h *ClassHandler) UpdatePolicy(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid class ID"})
+		return
+	}
+
+	if !isStaff(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	var req dto.PolicyUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.service.UpdatePolicy(c.Request.Context(), id, req.ToModel())
+	if err != nil {
+		if err.Error() == "class not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Class not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    dto.PolicyResponseFromModel(policy),
+		"message": "Cancellation policy updated successfully",
+	})
+}
+
+// GetPenalties handles GET /bookings/penalties
+func (h *BookingHandler) GetPenalties(c *gin.Context) {
+	filter := model.PenaltyFilter{
+		PenaltyType: c.Query("penalty_type"),
+		Status:      c.Query("status"),
+	}
+
+	if c.Query("member_id") != "" {
+		id, err := strconv.Atoi(c.Query("member_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid member ID"})
+			return
+		}
+		filter.MemberID = id
+	}
+
+	if c.Query("booking_id") != "" {
+		id, err := strconv.Atoi(c.Query("booking_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid booking ID"})
+			return
+		}
+		filter.BookingID = id
+	}
+
+	if c.Query("class_id") != "" {
+		id, err := strconv.Atoi(c.Query("class_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid class ID"})
+			return
+		}
+		filter.ClassID = id
+	}
+
+	// Members only see their own penalties
+	if caller, ok := identity.FromContext(c.Request.Context()); ok && caller.IsMember() {
+		filter.MemberID = caller.MemberID
+	}
+
+	params := ParsePaginationParams(c)
+
+	penalties, total, err := h.service.GetPenaltiesPaginated(c.Request.Context(), filter, params.Offset, params.PageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := CreatePaginatedResponse(dto.PenaltyResponseListFromModel(penalties), params, total)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetPenalty handles GET /bookings/penalties/:id
+func (h *BookingHandler) GetPenalty(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid penalty ID"})
+		return
+	}
+
+	penalty, err := h.service.GetPenalty(c.Request.Context(), id)
+	if err != nil || !canAccessBooking(c, penalty.MemberID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Penalty not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.PenaltyResponseFromModel(penalty),
+	})
+}
+
+// WaivePenalty handles POST /bookings/penalties/:id/waive
+func (h *BookingHandler) WaivePenalty(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid penalty ID"})
+		return
+	}
+
+	if !isStaff(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	penalty, err := h.service.WaivePenalty(c.Request.Context(), id)
+	if err != nil {
+		switch err.Error() {
+		case "penalty not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Penalty not found"})
+		case "penalty is already waived", "penalty fee was already charged":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			if !referenceError(c, err) {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    dto.PenaltyResponseFromModel(penalty),
+		"message": "Penalty waived successfully",
+	})
+}
+
+// GetSuspensions handles GET /bookings/suspensions
+func (h *BookingHandler) GetSuspensions(c *gin.Context) {
+	filter := model.SuspensionFilter{ActiveOnly: c.Query("active") == "true"}
+
+	if c.Query("member_id") != "" {
+		id, err := strconv.Atoi(c.Query("member_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid member ID"})
+			return
+		}
+		filter.MemberID = id
+	}
+
+	// Members only see their own suspensions
+	if caller, ok := identity.FromContext(c.Request.Context()); ok && caller.IsMember() {
+		filter.MemberID = caller.MemberID
+	}
+
+	params := ParsePaginationParams(c)
+
+	suspensions, total, err := h.service.GetSuspensionsPaginated(c.Request.Context(), filter, params.Offset, params.PageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := CreatePaginatedResponse(dto.SuspensionResponseListFromModel(suspensions), params, total)
+	c.JSON(http.StatusOK, response)
+}
+
+// LiftSuspension handles POST /bookings/suspensions/:id/lift
+func (h *BookingHandler) LiftSuspension(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid suspension ID"})
+		return
+	}
+
+	if !isStaff(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	suspension, err := h.service.LiftSuspension(c.Request.Context(), id)
+	if err != nil {
+		switch err.Error() {
+		case "suspension not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Suspension not found"})
+		case "suspension is not in force":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    dto.SuspensionResponseFromModel(suspension),
+		"message": "Suspension lifted successfully",
+	})
+}
@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/pkg/dto"
 	"github.com/gin-gonic/gin"
 )
@@ -92,7 +94,7 @@ func (h *ScheduleHandler) CreateSchedule(c *gin.Context) {
 
 	schedule, err := h.service.CreateSchedule(c.Request.Context(), modelReq)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		scheduleError(c, err)
 		return
 	}
 
@@ -124,7 +126,7 @@ func (h *ScheduleHandler) UpdateSchedule(c *gin.Context) {
 
 	schedule, err := h.service.UpdateSchedule(c.Request.Context(), id, modelReq)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		scheduleError(c, err)
 		return
 	}
 
@@ -166,3 +168,52 @@ func (h *ScheduleHandler) DeleteSchedule(c *gin.Context) {
 		"message": "Schedule deleted successfully",
 	})
 }
+
+// CheckConflicts handles GET /schedules/conflicts. It reports the schedules a
+// planned or edited schedule would double-book a trainer or room with,
+// without saving anything.
+func (h *ScheduleHandler) CheckConflicts(c *gin.Context) {
+	var req dto.ScheduleConflictCheckRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conflicts, err := h.service.CheckConflicts(c.Request.Context(), req.ToModel())
+	if err != nil {
+		scheduleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"has_conflicts": len(conflicts) > 0,
+			"conflicts":     dto.ScheduleConflictListFromModel(conflicts),
+		},
+	})
+}
+
+// scheduleError writes the response for an error of a schedule change.
+// Conflicts list the schedules that clash with the change.
+func scheduleError(c *gin.Context, err error) {
+	if referenceError(c, err) {
+		return
+	}
+
+	var conflictErr *model.ScheduleConflictError
+	if errors.As(err, &conflictErr) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":     conflictErr.Error(),
+			"conflicts": dto.ScheduleConflictListFromModel(conflictErr.Conflicts),
+		})
+		return
+	}
+
+	switch err.Error() {
+	case "cannot schedule an inactive class", "start and end time must be given as HH:MM or HH:MM:SS",
+		"end time must be after start time":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/pkg/dto"
+	"github.com/FurkanArikk/fitness-center/backend/identity"
+	"github.com/gin-gonic/gin"
+)
+
+// GetWaitlist handles GET /bookings/waitlist
+func (h *BookingHandler) GetWaitlist(c *gin.Context) {
+	filter := model.WaitlistFilter{Status: c.Query("status")}
+
+	if c.Query("schedule_id") != "" {
+		id, err := strconv.Atoi(c.Query("schedule_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+			return
+		}
+		filter.ScheduleID = id
+	}
+
+	if c.Query("occurrence_id") != "" {
+		id, err := strconv.Atoi(c.Query("occurrence_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid occurrence ID"})
+			return
+		}
+		filter.OccurrenceID = id
+	}
+
+	if c.Query("member_id") != "" {
+		id, err := strconv.Atoi(c.Query("member_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid member ID"})
+			return
+		}
+		filter.MemberID = id
+	}
+
+	// Members only see their own waitlist entries
+	if caller, ok := identity.FromContext(c.Request.Context()); ok && caller.IsMember() {
+		filter.MemberID = caller.MemberID
+	}
+
+	params := ParsePaginationParams(c)
+
+	entries, total, err := h.service.GetWaitlistPaginated(c.Request.Context(), filter, params.Offset, params.PageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := CreatePaginatedResponse(dto.WaitlistResponseListFromModel(entries), params, total)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetWaitlistEntry handles GET /bookings/waitlist/:id
+func (h *BookingHandler) GetWaitlistEntry(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid waitlist ID"})
+		return
+	}
+
+	entry, err := h.service.GetWaitlistEntry(c.Request.Context(), id)
+	if err != nil || !canAccessBooking(c, entry.MemberID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Waitlist entry not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.WaitlistResponseFromModel(entry),
+	})
+}
+
+// JoinWaitlist handles POST /bookings/waitlist
+func (h *BookingHandler) JoinWaitlist(c *gin.Context) {
+	var req dto.WaitlistJoinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !canAccessBooking(c, req.MemberID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Members can only join the waitlist for themselves"})
+		return
+	}
+
+	entry, err := h.service.JoinWaitlist(c.Request.Context(), req.ToModel())
+	if err != nil {
+		if referenceError(c, err) || suspensionError(c, err) {
+			return
+		}
+		switch err.Error() {
+		case "class occurrence not found", "occurrence_id or schedule_id and booking_date are required",
+			"class occurrence is cancelled", "class occurrence has already started", "class occurrence falls in a closure",
+			"class has free seats, book it directly", "member has not enough class credits":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "member already has a booking for this class", "member is already on the waitlist for this class":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    dto.WaitlistResponseFromModel(entry),
+		"message": "Joined the waitlist successfully",
+	})
+}
+
+// ConfirmWaitlistOffer handles POST /bookings/waitlist/:id/confirm
+func (h *BookingHandler) ConfirmWaitlistOffer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid waitlist ID"})
+		return
+	}
+
+	existing, err := h.service.GetWaitlistEntry(c.Request.Context(), id)
+	if err != nil || !canAccessBooking(c, existing.MemberID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Waitlist entry not found"})
+		return
+	}
+
+	booking, err := h.service.ConfirmWaitlistOffer(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == "no open seat offer for this waitlist entry" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "member has not enough class credits" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    dto.BookingResponseFromModel(booking),
+		"message": "Booking created successfully",
+	})
+}
+
+// LeaveWaitlist handles DELETE /bookings/waitlist/:id
+func (h *BookingHandler) LeaveWaitlist(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid waitlist ID"})
+		return
+	}
+
+	existing, err := h.service.GetWaitlistEntry(c.Request.Context(), id)
+	if err != nil || !canAccessBooking(c, existing.MemberID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Waitlist entry not found"})
+		return
+	}
+
+	if _, err := h.service.LeaveWaitlist(c.Request.Context(), id); err != nil {
+		if err.Error() == "only waiting or offered entries can leave the waitlist" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Left the waitlist successfully",
+	})
+}
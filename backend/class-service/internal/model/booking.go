@@ -9,6 +9,7 @@ import (
 type Booking struct {
 	BookingID        int       `json:"booking_id" gorm:"column:booking_id;primaryKey;autoIncrement"`
 	ScheduleID       int       `json:"schedule_id" gorm:"column:schedule_id;not null"`
+	OccurrenceID     int       `json:"occurrence_id" gorm:"column:occurrence_id;not null"`
 	MemberID         int       `json:"member_id" gorm:"column:member_id;not null"`
 	BookingDate      time.Time `json:"booking_date" gorm:"column:booking_date;not null"`
 	AttendanceStatus string    `json:"attendance_status" gorm:"column:attendance_status;type:varchar(20);default:'booked'"`
@@ -26,11 +27,13 @@ func (Booking) TableName() string {
 	return "class_bookings"
 }
 
-// BookingRequest is used for creating a booking
+// BookingRequest is used for creating a booking. The occurrence is given by
+// its ID, or by a schedule and the date of the occurrence.
 type BookingRequest struct {
-	ScheduleID  int       `json:"schedule_id" binding:"required"`
-	MemberID    int       `json:"member_id" binding:"required"`
-	BookingDate time.Time `json:"booking_date" binding:"required"`
+	OccurrenceID int       `json:"occurrence_id"`
+	ScheduleID   int       `json:"schedule_id"`
+	MemberID     int       `json:"member_id" binding:"required"`
+	BookingDate  time.Time `json:"booking_date"`
 }
 
 // BookingStatusUpdate is used for updating attendance status
@@ -44,35 +47,92 @@ type FeedbackRequest struct {
 	Comment string `json:"comment"`
 }
 
-// BookingResponse includes schedule and class details with the booking
+// BookingResponse includes occurrence and class details with the booking
 type BookingResponse struct {
 	Booking
-	ClassName string `json:"class_name"`
-	DayOfWeek string `json:"day_of_week"`
-	StartTime string `json:"start_time"`
-	TrainerID int    `json:"trainer_id"`
+	ClassName      string    `json:"class_name"`
+	DayOfWeek      string    `json:"day_of_week"`
+	OccurrenceDate time.Time `json:"occurrence_date"`
+	StartTime      string    `json:"start_time"`
+	EndTime        string    `json:"end_time"`
+	TrainerID      int       `json:"trainer_id"`
+	RoomID         int       `json:"room_id"`
+}
+
+// StartsAt returns the start of the booked occurrence
+func (b BookingResponse) StartsAt() time.Time {
+	return atTime(b.OccurrenceDate, b.StartTime)
+}
+
+// EndsAt returns the end of the booked occurrence
+func (b BookingResponse) EndsAt() time.Time {
+	return atTime(b.OccurrenceDate, b.EndTime)
 }
 
 // BookingRepository defines the operations for booking data access
 type BookingRepository interface {
 	GetAll(ctx context.Context, status string, date string) ([]BookingResponse, error)
-	GetAllPaginated(ctx context.Context, status string, date string, offset, limit int) ([]BookingResponse, int, error)
+	GetAllPaginated(ctx context.Context, status string, date string, memberID, occurrenceID int, offset, limit int) ([]BookingResponse, int, error)
 	GetByID(ctx context.Context, id int) (BookingResponse, error)
 	GetByMemberID(ctx context.Context, memberID int) ([]BookingResponse, error)
 	Create(ctx context.Context, booking Booking) (Booking, error)
-	UpdateStatus(ctx context.Context, id int, status string) (Booking, error)
+	UpdateStatus(ctx context.Context, id int, from, status string) (Booking, error)
 	AddFeedback(ctx context.Context, id int, rating int, comment string) (Booking, error)
 	Cancel(ctx context.Context, id int) (Booking, error)
-	CheckCapacity(ctx context.Context, scheduleID int) (int, int, error)
+	CheckCapacity(ctx context.Context, occurrenceID int) (int, int, error)
+	// MarkNoShows marks up to limit bookings that are still booked for
+	// occurrences that ended more than grace ago as no-shows, with their
+	// penalty pending. It reports false without marking any when another
+	// replica is running it.
+	MarkNoShows(ctx context.Context, grace time.Duration, limit int) ([]Booking, bool, error)
+	// GetPendingPenalties returns up to limit no-show bookings whose penalty
+	// is still pending
+	GetPendingPenalties(ctx context.Context, limit int) ([]Booking, error)
+	// ClearPendingPenalty records that the penalty of a booking was applied
+	ClearPendingPenalty(ctx context.Context, id int) error
 }
 
 // BookingService defines operations for managing bookings
 type BookingService interface {
 	GetBookings(ctx context.Context, status string, date string) ([]BookingResponse, error)
-	GetBookingsPaginated(ctx context.Context, status string, date string, offset, limit int) ([]BookingResponse, int, error)
+	GetBookingsPaginated(ctx context.Context, status string, date string, memberID, occurrenceID int, offset, limit int) ([]BookingResponse, int, error)
 	GetBookingByID(ctx context.Context, id int) (BookingResponse, error)
 	CreateBooking(ctx context.Context, req BookingRequest) (Booking, error)
 	UpdateBookingStatus(ctx context.Context, id int, status string) (Booking, error)
 	AddBookingFeedback(ctx context.Context, id int, req FeedbackRequest) (Booking, error)
-	CancelBooking(ctx context.Context, id int) (Booking, error)
+	// CancelBooking cancels a booking and returns the late-cancel penalty it
+	// incurred, if any
+	CancelBooking(ctx context.Context, id int) (Booking, *Penalty, error)
+	JoinWaitlist(ctx context.Context, req WaitlistRequest) (WaitlistResponse, error)
+	GetWaitlistPaginated(ctx context.Context, filter WaitlistFilter, offset, limit int) ([]WaitlistResponse, int, error)
+	GetWaitlistEntry(ctx context.Context, id int) (WaitlistResponse, error)
+	LeaveWaitlist(ctx context.Context, id int) (WaitlistEntry, error)
+	ConfirmWaitlistOffer(ctx context.Context, id int) (Booking, error)
+	ExpireWaitlistOffers(ctx context.Context) error
+	// MarkNoShows closes the attendance of occurrences that ended more than
+	// grace ago, marking bookings still booked as no-shows, and returns how
+	// many it marked
+	MarkNoShows(ctx context.Context, grace time.Duration) (int, error)
+	GetPenaltiesPaginated(ctx context.Context, filter PenaltyFilter, offset, limit int) ([]Penalty, int, error)
+	GetPenalty(ctx context.Context, id int) (Penalty, error)
+	WaivePenalty(ctx context.Context, id int) (Penalty, error)
+	// PostPendingCharges posts penalty fees the payment service did not take
+	// when they were recorded
+	PostPendingCharges(ctx context.Context) error
+	GetSuspensionsPaginated(ctx context.Context, filter SuspensionFilter, offset, limit int) ([]Suspension, int, error)
+	LiftSuspension(ctx context.Context, id int) (Suspension, error)
+	// GetCreditBalance returns the credits a member can book with, after
+	// granting the credits of their membership for the current month
+	GetCreditBalance(ctx context.Context, memberID int) (CreditBalance, error)
+	GetCreditHistoryPaginated(ctx context.Context, memberID int, offset, limit int) ([]CreditTransaction, int, error)
+	GrantCredits(ctx context.Context, grant CreditGrant) (CreditGrant, error)
+	GetCreditPlans(ctx context.Context) ([]CreditPlan, error)
+	SaveCreditPlan(ctx context.Context, plan CreditPlan) (CreditPlan, error)
+	DeleteCreditPlan(ctx context.Context, membershipID int) error
+	// GetFeedbackSummary returns the ratings the filter selects, with up to
+	// comments of the latest comments
+	GetFeedbackSummary(ctx context.Context, filter FeedbackFilter, comments int) (FeedbackSummary, error)
+	// SyncTrainerRatings pushes the rating of every trainer with feedback to
+	// the staff service and returns how many it pushed
+	SyncTrainerRatings(ctx context.Context) (int, error)
 }
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Types of calendar feeds
+const (
+	// FeedSchedule is the timetable of all active schedules
+	FeedSchedule = "schedule"
+	// FeedTrainer is the timetable of the classes of one trainer
+	FeedTrainer = "trainer"
+	// FeedRoom is the timetable of the classes held in one room
+	FeedRoom = "room"
+	// FeedMember is the classes one member has booked
+	FeedMember = "member"
+)
+
+// CalendarFeed is a calendar feed that calendar apps subscribe to with a
+// secret token. SubjectID is the trainer, room or member of the feed, and nil
+// for the schedule feed. CreatedBy is the user ID of the user who created it.
+type CalendarFeed struct {
+	FeedID         int        `json:"feed_id" gorm:"column:feed_id;primaryKey;autoIncrement"`
+	TokenHash      string     `json:"-" gorm:"column:token_hash;type:char(64);not null"`
+	FeedType       string     `json:"feed_type" gorm:"column:feed_type;type:varchar(20);not null"`
+	SubjectID      *int       `json:"subject_id,omitempty" gorm:"column:subject_id"`
+	CreatedBy      string     `json:"created_by,omitempty" gorm:"column:created_by;type:varchar(100)"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty" gorm:"column:last_accessed_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty" gorm:"column:revoked_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (CalendarFeed) TableName() string {
+	return "calendar_feeds"
+}
+
+// CalendarFeedFilter selects calendar feeds. Zero fields are ignored.
+type CalendarFeedFilter struct {
+	FeedType  string
+	SubjectID int
+	// CreatedBy selects the feeds a user created
+	CreatedBy string
+	// ActiveOnly leaves out revoked feeds
+	ActiveOnly bool
+}
+
+// CalendarFeedRequest is used for creating a calendar feed. CreatedBy is the
+// user ID of the caller.
+type CalendarFeedRequest struct {
+	FeedType  string
+	SubjectID int
+	CreatedBy string
+}
+
+// CalendarRepository defines the operations for calendar feed data access
+type CalendarRepository interface {
+	Create(ctx context.Context, feed CalendarFeed) (CalendarFeed, error)
+	GetByID(ctx context.Context, id int) (CalendarFeed, error)
+	// GetByTokenHash returns the feed a token opens, or "calendar feed not
+	// found" when there is none or it was revoked, and records the access
+	GetByTokenHash(ctx context.Context, tokenHash string) (CalendarFeed, error)
+	GetAllPaginated(ctx context.Context, filter CalendarFeedFilter, offset, limit int) ([]CalendarFeed, int, error)
+	Revoke(ctx context.Context, id int) (CalendarFeed, error)
+}
+
+// CalendarService defines operations for calendar feeds
+type CalendarService interface {
+	// ScheduleCalendar returns the iCalendar of all active schedules
+	ScheduleCalendar(ctx context.Context) ([]byte, error)
+	// TrainerCalendar returns the iCalendar of the classes a trainer teaches
+	TrainerCalendar(ctx context.Context, trainerID int) ([]byte, error)
+	// RoomCalendar returns the iCalendar of the classes held in a room
+	RoomCalendar(ctx context.Context, roomID int) ([]byte, error)
+	// MemberCalendar returns the iCalendar of the classes a member booked
+	MemberCalendar(ctx context.Context, memberID int) ([]byte, error)
+	// FeedCalendar returns the iCalendar of the feed a token opens
+	FeedCalendar(ctx context.Context, token string) ([]byte, error)
+	// CreateFeed creates a feed and returns it with its token, which is not
+	// stored and cannot be looked up again
+	CreateFeed(ctx context.Context, req CalendarFeedRequest) (CalendarFeed, string, error)
+	GetFeedsPaginated(ctx context.Context, filter CalendarFeedFilter, offset, limit int) ([]CalendarFeed, int, error)
+	GetFeed(ctx context.Context, id int) (CalendarFeed, error)
+	RevokeFeed(ctx context.Context, id int) (CalendarFeed, error)
+}
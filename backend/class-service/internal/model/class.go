@@ -55,4 +55,7 @@ type ClassService interface {
 	CreateClass(ctx context.Context, req ClassRequest) (Class, error)
 	UpdateClass(ctx context.Context, id int, req ClassRequest) (Class, error)
 	DeleteClass(ctx context.Context, id int) error
+	// GetPolicy returns the cancellation policy of a class
+	GetPolicy(ctx context.Context, classID int) (CancellationPolicy, error)
+	UpdatePolicy(ctx context.Context, classID int, req PolicyRequest) (CancellationPolicy, error)
 }
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Scopes of closures
+const (
+	// ClosureCenter closes the whole fitness center
+	ClosureCenter = "center"
+	// ClosureRoom closes one room
+	ClosureRoom = "room"
+	// ClosureTrainer takes one trainer off, for a holiday or sick leave
+	ClosureTrainer = "trainer"
+)
+
+// Closure is a range of days on which the center, a room or a trainer does
+// not hold classes. SubjectID is the room or trainer, and nil for the center.
+// StartDate and EndDate are inclusive.
+type Closure struct {
+	ClosureID int       `json:"closure_id" gorm:"column:closure_id;primaryKey;autoIncrement"`
+	Scope     string    `json:"scope" gorm:"column:scope;type:varchar(20);not null"`
+	SubjectID *int      `json:"subject_id,omitempty" gorm:"column:subject_id"`
+	StartDate time.Time `json:"start_date" gorm:"column:start_date;type:date;not null"`
+	EndDate   time.Time `json:"end_date" gorm:"column:end_date;type:date;not null"`
+	Reason    string    `json:"reason" gorm:"column:reason;type:varchar(255)"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (Closure) TableName() string {
+	return "closures"
+}
+
+// ClosedBooking is a booking that was cancelled because of a closure, kept so
+// the member can be told
+type ClosedBooking struct {
+	ClosureID      int       `json:"closure_id"`
+	BookingID      int       `json:"booking_id"`
+	OccurrenceID   int       `json:"occurrence_id"`
+	MemberID       int       `json:"member_id"`
+	ClassName      string    `json:"class_name"`
+	OccurrenceDate time.Time `json:"occurrence_date"`
+	StartTime      string    `json:"start_time"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ClosureResult is a new closure with what it did to the classes already
+// generated: occurrences nobody booked are removed, the others are cancelled
+// and their bookings are cancelled and refunded
+type ClosureResult struct {
+	Closure
+	RemovedOccurrences   int
+	CancelledOccurrences int
+	CancelledBookings    []ClosedBooking
+}
+
+// ClosureFilter selects closures. Zero fields are ignored; From and To select
+// the closures that overlap those dates.
+type ClosureFilter struct {
+	Scope     string
+	SubjectID int
+	From      *time.Time
+	To        *time.Time
+}
+
+// ClosureRepository defines the operations for closure data access
+type ClosureRepository interface {
+	// Create saves a closure and, in the same transaction, removes or cancels
+	// the occurrences it covers that have not started yet
+	Create(ctx context.Context, closure Closure) (ClosureResult, error)
+	GetByID(ctx context.Context, id int) (Closure, error)
+	GetAllPaginated(ctx context.Context, filter ClosureFilter, offset, limit int) ([]Closure, int, error)
+	// GetCovering returns the closures that fall on a date and apply to the
+	// trainer or room
+	GetCovering(ctx context.Context, date time.Time, trainerID, roomID int) ([]Closure, error)
+	// GetBookings returns the bookings a closure cancelled
+	GetBookings(ctx context.Context, id int) ([]ClosedBooking, error)
+	Delete(ctx context.Context, id int) error
+}
+
+// ClosureService defines operations for managing closures
+type ClosureService interface {
+	CreateClosure(ctx context.Context, closure Closure) (ClosureResult, error)
+	GetClosure(ctx context.Context, id int) (Closure, error)
+	GetClosuresPaginated(ctx context.Context, filter ClosureFilter, offset, limit int) ([]Closure, int, error)
+	GetClosureBookings(ctx context.Context, id int) ([]ClosedBooking, error)
+	// DeleteClosure deletes a closure and generates the occurrences it held
+	// back. Classes it cancelled stay cancelled.
+	DeleteClosure(ctx context.Context, id int) error
+}
@@ -0,0 +1,135 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Sources of credit grants
+const (
+	// GrantMembership grants are the monthly credits of a membership
+	GrantMembership = "membership"
+	// GrantPack grants are class packs a member bought
+	GrantPack = "pack"
+	// GrantManual grants are given by staff
+	GrantManual = "manual"
+)
+
+// Types of credit transactions
+const (
+	// LedgerGrant adds the credits of a grant
+	LedgerGrant = "grant"
+	// LedgerDebit takes the credits a booking costs
+	LedgerDebit = "debit"
+	// LedgerRefund returns the credits of a cancelled booking
+	LedgerRefund = "refund"
+	// LedgerForfeit takes the credits forfeited for a penalty
+	LedgerForfeit = "forfeit"
+	// LedgerRestore returns the credits of a waived penalty
+	LedgerRestore = "restore"
+)
+
+// CreditPlan gives members of a membership a number of class credits every
+// month. MembershipID is the membership of the member service.
+type CreditPlan struct {
+	MembershipID    int       `json:"membership_id" gorm:"column:membership_id;primaryKey;autoIncrement:false"`
+	CreditsPerMonth int       `json:"credits_per_month" gorm:"column:credits_per_month;not null"`
+	Description     string    `json:"description" gorm:"column:description;type:varchar(255)"`
+	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (CreditPlan) TableName() string {
+	return "credit_plans"
+}
+
+// CreditGrant is a number of class credits given to a member. Bookings take
+// credits from the grants that expire first.
+type CreditGrant struct {
+	GrantID  int    `json:"grant_id" gorm:"column:grant_id;primaryKey;autoIncrement"`
+	MemberID int    `json:"member_id" gorm:"column:member_id;not null"`
+	Source   string `json:"source" gorm:"column:source;type:varchar(20);not null"`
+	// Reference identifies the grant within its source, such as the
+	// membership month or the invoice of a pack, so it is granted only once
+	Reference   *string    `json:"reference,omitempty" gorm:"column:reference;type:varchar(100)"`
+	Credits     int        `json:"credits" gorm:"column:credits;not null"`
+	Remaining   int        `json:"remaining" gorm:"column:remaining;not null"`
+	ValidFrom   time.Time  `json:"valid_from" gorm:"column:valid_from;not null"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" gorm:"column:expires_at"`
+	Description string     `json:"description" gorm:"column:description;type:varchar(255)"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (CreditGrant) TableName() string {
+	return "credit_grants"
+}
+
+// CreditTransaction is an entry in the credit ledger of a member. Amount is
+// negative for credits taken from a grant.
+type CreditTransaction struct {
+	TransactionID   int       `json:"transaction_id" gorm:"column:transaction_id;primaryKey;autoIncrement"`
+	MemberID        int       `json:"member_id" gorm:"column:member_id;not null"`
+	GrantID         int       `json:"grant_id" gorm:"column:grant_id;not null"`
+	BookingID       *int      `json:"booking_id,omitempty" gorm:"column:booking_id"`
+	PenaltyID       *int      `json:"penalty_id,omitempty" gorm:"column:penalty_id"`
+	TransactionType string    `json:"transaction_type" gorm:"column:transaction_type;type:varchar(20);not null"`
+	Amount          int       `json:"amount" gorm:"column:amount;not null"`
+	Description     string    `json:"description" gorm:"column:description;type:varchar(255)"`
+	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (CreditTransaction) TableName() string {
+	return "credit_transactions"
+}
+
+// CreditBalance is the number of credits a member can book with, and the
+// grants they come from
+type CreditBalance struct {
+	MemberID int
+	Balance  int
+	Grants   []CreditGrant
+}
+
+// ActiveMembership is the membership a member currently holds in the member
+// service
+type ActiveMembership struct {
+	// ID identifies the member's membership record
+	ID int
+	// MembershipID is the membership type the member holds
+	MembershipID int
+	EndDate      time.Time
+}
+
+// CreditRepository defines the operations for class credit data access.
+// Bookings are debited and refunded by the booking, waitlist and occurrence
+// repositories in the transaction that changes them.
+type CreditRepository interface {
+	// Grant adds a grant with its ledger entry. A grant whose reference was
+	// already granted to the member is returned as it is.
+	Grant(ctx context.Context, grant CreditGrant) (CreditGrant, error)
+	// GetBalance returns the credits a member can book with now
+	GetBalance(ctx context.Context, memberID int) (CreditBalance, error)
+	GetTransactionsPaginated(ctx context.Context, memberID int, offset, limit int) ([]CreditTransaction, int, error)
+	// Forfeit takes the credits of a penalty from the member, at most as
+	// many as they have left. Forfeiting a penalty again does nothing.
+	Forfeit(ctx context.Context, penalty Penalty) error
+	// RestoreForfeit returns the credits forfeited for a penalty
+	RestoreForfeit(ctx context.Context, penaltyID int) error
+	ListPlans(ctx context.Context) ([]CreditPlan, error)
+	// GetPlan returns the plan of a membership, or "credit plan not found"
+	GetPlan(ctx context.Context, membershipID int) (CreditPlan, error)
+	SavePlan(ctx context.Context, plan CreditPlan) (CreditPlan, error)
+	DeletePlan(ctx context.Context, membershipID int) error
+}
+
+// MembershipSource looks up the memberships members hold in the member
+// service
+type MembershipSource interface {
+	// ActiveMembership returns the membership a member holds now, and false
+	// if they hold none
+	ActiveMembership(ctx context.Context, memberID int) (ActiveMembership, bool, error)
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// FeedbackFilter selects the feedback given on bookings. Zero fields are
+// ignored; From and To are inclusive occurrence dates. Feedback counts for the
+// trainer who taught the occurrence, which may not be the schedule's trainer.
+type FeedbackFilter struct {
+	ClassID    int
+	ScheduleID int
+	TrainerID  int
+	From       *time.Time
+	To         *time.Time
+}
+
+// FeedbackComment is a rating with a comment a member gave a booked class
+type FeedbackComment struct {
+	BookingID      int       `json:"booking_id"`
+	OccurrenceID   int       `json:"occurrence_id"`
+	ScheduleID     int       `json:"schedule_id"`
+	ClassID        int       `json:"class_id"`
+	ClassName      string    `json:"class_name"`
+	TrainerID      int       `json:"trainer_id"`
+	MemberID       int       `json:"member_id"`
+	OccurrenceDate time.Time `json:"occurrence_date"`
+	Rating         int       `json:"rating"`
+	Comment        string    `json:"comment"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// FeedbackSummary aggregates the ratings a filter selects. Distribution has
+// the number of ratings of each value from 1 to 5.
+type FeedbackSummary struct {
+	Count          int
+	Average        float64
+	Distribution   map[int]int
+	RecentComments []FeedbackComment
+}
+
+// TrainerRating is the average rating of the classes a trainer taught
+type TrainerRating struct {
+	TrainerID int     `json:"trainer_id"`
+	Rating    float64 `json:"rating"`
+	Count     int     `json:"count"`
+}
+
+// FeedbackRepository defines the operations for reading booking feedback
+type FeedbackRepository interface {
+	// GetDistribution returns the number of ratings of each value
+	GetDistribution(ctx context.Context, filter FeedbackFilter) (map[int]int, error)
+	// GetRecentComments returns the latest comments, by occurrence date
+	GetRecentComments(ctx context.Context, filter FeedbackFilter, limit int) ([]FeedbackComment, error)
+	// GetTrainerRatings returns the rating of every trainer who taught an
+	// occurrence that got feedback
+	GetTrainerRatings(ctx context.Context) ([]TrainerRating, error)
+}
+
+// RatingPublisher keeps the trainer ratings in the staff service in line
+// with the feedback given on their classes
+type RatingPublisher interface {
+	PublishRating(ctx context.Context, rating TrainerRating) error
+}
@@ -0,0 +1,146 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Occurrence statuses
+const (
+	OccurrenceScheduled = "scheduled"
+	OccurrenceCancelled = "cancelled"
+)
+
+// Occurrence is a dated session of a weekly schedule. Occurrences are
+// generated from their schedule and can be cancelled or rescheduled one by
+// one without changing the series.
+type Occurrence struct {
+	OccurrenceID       int       `json:"occurrence_id" gorm:"column:occurrence_id;primaryKey;autoIncrement"`
+	ScheduleID         int       `json:"schedule_id" gorm:"column:schedule_id;not null"`
+	OccurrenceDate     time.Time `json:"occurrence_date" gorm:"column:occurrence_date;type:date;not null"`
+	OriginalDate       time.Time `json:"original_date" gorm:"column:original_date;type:date;not null"`
+	StartTime          string    `json:"start_time" gorm:"column:start_time;type:time;not null"`
+	EndTime            string    `json:"end_time" gorm:"column:end_time;type:time;not null"`
+	TrainerID          int       `json:"trainer_id" gorm:"column:trainer_id;not null"`
+	RoomID             int       `json:"room_id" gorm:"column:room_id;not null"`
+	Status             string    `json:"status" gorm:"column:status;type:varchar(20);default:'scheduled'"`
+	IsModified         bool      `json:"is_modified" gorm:"column:is_modified;default:false"`
+	CancellationReason string    `json:"cancellation_reason,omitempty" gorm:"column:cancellation_reason;type:varchar(255)"`
+	CreatedAt          time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt          time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (Occurrence) TableName() string {
+	return "class_occurrences"
+}
+
+// StartsAt returns the start of the occurrence
+func (o Occurrence) StartsAt() time.Time {
+	return atTime(o.OccurrenceDate, o.StartTime)
+}
+
+// EndsAt returns the end of the occurrence
+func (o Occurrence) EndsAt() time.Time {
+	return atTime(o.OccurrenceDate, o.EndTime)
+}
+
+// location is the time zone of the center. Class dates and times are
+// wall-clock times in it.
+var location = time.UTC
+
+// SetLocation sets the time zone of the center. It is called once at startup,
+// before requests are served and jobs are run.
+func SetLocation(loc *time.Location) {
+	location = loc
+}
+
+// Location returns the time zone of the center
+func Location() *time.Location {
+	return location
+}
+
+// Today returns the current date in the time zone of the center, at midnight
+// UTC like the dates read from the database
+func Today() time.Time {
+	now := time.Now().In(location)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// atTime combines a date with a time of day in the "15:04:05" or "15:04"
+// format, in the time zone of the center. An unparsable time of day yields
+// midnight.
+func atTime(date time.Time, clock string) time.Time {
+	t, err := time.Parse("15:04:05", clock)
+	if err != nil {
+		t, _ = time.Parse("15:04", clock)
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), t.Second(), 0, location)
+}
+
+// OccurrenceResponse includes class details and the number of booked seats
+// with the occurrence
+type OccurrenceResponse struct {
+	Occurrence
+	ClassID     int    `json:"class_id"`
+	ClassName   string `json:"class_name"`
+	Capacity    int    `json:"capacity"`
+	BookedCount int    `json:"booked_count"`
+}
+
+// OccurrenceRescheduleRequest is used for moving a single occurrence. Zero
+// trainer and room IDs keep the current ones.
+type OccurrenceRescheduleRequest struct {
+	OccurrenceDate time.Time
+	StartTime      string
+	EndTime        string
+	TrainerID      int
+	RoomID         int
+}
+
+// OccurrenceFilter selects occurrences. Zero fields are ignored; From and To
+// are inclusive dates.
+type OccurrenceFilter struct {
+	ScheduleID int
+	ClassID    int
+	TrainerID  int
+	RoomID     int
+	Status     string
+	From       *time.Time
+	To         *time.Time
+}
+
+// OccurrenceRepository defines the operations for occurrence data access
+type OccurrenceRepository interface {
+	GetAllPaginated(ctx context.Context, filter OccurrenceFilter, offset, limit int) ([]OccurrenceResponse, int, error)
+	// GetAll returns all occurrences the filter selects, in chronological
+	// order
+	GetAll(ctx context.Context, filter OccurrenceFilter) ([]OccurrenceResponse, error)
+	GetByID(ctx context.Context, id int) (OccurrenceResponse, error)
+	GetByScheduleDate(ctx context.Context, scheduleID int, date time.Time) (OccurrenceResponse, error)
+	// Generate creates the missing occurrences of active schedules between
+	// from and to, limited to one schedule when scheduleID is set, and
+	// returns how many were created
+	Generate(ctx context.Context, scheduleID int, from, to time.Time) (int, error)
+	// SyncSchedule applies a changed schedule to its occurrences from the
+	// given date on. Occurrences that were changed one by one are left alone,
+	// and those a closure now covers are removed or cancelled.
+	SyncSchedule(ctx context.Context, scheduleID int, from time.Time) error
+	// Cancel cancels an occurrence together with its bookings and waitlist
+	Cancel(ctx context.Context, id int, reason string) (Occurrence, error)
+	// Reschedule moves an occurrence and the bookings on it. It returns a
+	// *ScheduleConflictError when the occurrence would double-book its
+	// trainer or room.
+	Reschedule(ctx context.Context, id int, occurrence Occurrence) (Occurrence, error)
+}
+
+// OccurrenceService defines operations for managing occurrences
+type OccurrenceService interface {
+	GetOccurrencesPaginated(ctx context.Context, filter OccurrenceFilter, offset, limit int) ([]OccurrenceResponse, int, error)
+	GetOccurrenceByID(ctx context.Context, id int) (OccurrenceResponse, error)
+	CancelOccurrence(ctx context.Context, id int, reason string) (Occurrence, error)
+	RescheduleOccurrence(ctx context.Context, id int, req OccurrenceRescheduleRequest) (Occurrence, error)
+	// GenerateOccurrences extends the occurrences of all active schedules to
+	// the configured horizon
+	GenerateOccurrences(ctx context.Context) (int, error)
+}
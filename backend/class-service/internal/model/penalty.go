@@ -0,0 +1,208 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Penalty types
+const (
+	// PenaltyLateCancel is given for cancelling inside the cancellation cutoff
+	PenaltyLateCancel = "late_cancel"
+	// PenaltyNoShow is given for a booking marked as no_show
+	PenaltyNoShow = "no_show"
+)
+
+// Penalty statuses
+const (
+	PenaltyActive = "active"
+	PenaltyWaived = "waived"
+)
+
+// Charge statuses of a penalty's fee
+const (
+	// ChargeNone penalties have no fee to charge
+	ChargeNone = "none"
+	// ChargePending fees still have to be posted to the payment service
+	ChargePending = "pending"
+	// ChargePosted fees were posted to the payment service
+	ChargePosted = "posted"
+	// ChargeReversed fees were posted and then refunded in the payment
+	// service, as their penalty was waived
+	ChargeReversed = "reversed"
+)
+
+// CancellationPolicy holds the cancellation and no-show rules of a class.
+// Zero values turn a rule off.
+type CancellationPolicy struct {
+	ClassID int `json:"class_id" gorm:"column:class_id;primaryKey"`
+	// CancellationCutoffMinutes is how long before the start a booking can
+	// be cancelled without penalty
+	CancellationCutoffMinutes int     `json:"cancellation_cutoff_minutes" gorm:"column:cancellation_cutoff_minutes;not null"`
+	LateCancelFee             float64 `json:"late_cancel_fee" gorm:"column:late_cancel_fee;type:decimal(10,2);not null"`
+	LateCancelCredits         int     `json:"late_cancel_credits" gorm:"column:late_cancel_credits;not null"`
+	NoShowFee                 float64 `json:"no_show_fee" gorm:"column:no_show_fee;type:decimal(10,2);not null"`
+	NoShowCredits             int     `json:"no_show_credits" gorm:"column:no_show_credits;not null"`
+	// CreditCost is the number of class credits a booking of the class takes
+	CreditCost int `json:"credit_cost" gorm:"column:credit_cost;not null"`
+	// NoShowLimit is the number of no-shows within NoShowPeriodDays that
+	// suspends a member from booking for SuspensionDays
+	NoShowLimit      int       `json:"no_show_limit" gorm:"column:no_show_limit;not null"`
+	NoShowPeriodDays int       `json:"no_show_period_days" gorm:"column:no_show_period_days;not null"`
+	SuspensionDays   int       `json:"suspension_days" gorm:"column:suspension_days;not null"`
+	CreatedAt        time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (CancellationPolicy) TableName() string {
+	return "class_policies"
+}
+
+// DefaultPolicy returns the policy of classes that have none configured: no
+// cutoff, no penalties, no suspensions and no credits to book
+func DefaultPolicy(classID int) CancellationPolicy {
+	return CancellationPolicy{ClassID: classID, NoShowPeriodDays: 30, SuspensionDays: 7}
+}
+
+// IsLateCancel reports whether cancelling at the given time, for a class
+// starting at startsAt, is inside the cancellation cutoff
+func (p CancellationPolicy) IsLateCancel(startsAt, at time.Time) bool {
+	if p.CancellationCutoffMinutes == 0 {
+		return false
+	}
+	return !at.Before(startsAt.Add(-time.Duration(p.CancellationCutoffMinutes) * time.Minute))
+}
+
+// PenaltyFor returns the fee and the credits forfeited for a penalty type
+func (p CancellationPolicy) PenaltyFor(penaltyType string) (float64, int) {
+	if penaltyType == PenaltyNoShow {
+		return p.NoShowFee, p.NoShowCredits
+	}
+	return p.LateCancelFee, p.LateCancelCredits
+}
+
+// PolicyRequest is used for setting the cancellation policy of a class
+type PolicyRequest struct {
+	CancellationCutoffMinutes int
+	LateCancelFee             float64
+	LateCancelCredits         int
+	NoShowFee                 float64
+	NoShowCredits             int
+	NoShowLimit               int
+	NoShowPeriodDays          int
+	SuspensionDays            int
+	CreditCost                int
+}
+
+// Penalty is a late cancellation or no-show of a booking. The fee is charged
+// through the payment service; forfeited credits are recorded with it.
+type Penalty struct {
+	PenaltyID    int       `json:"penalty_id" gorm:"column:penalty_id;primaryKey;autoIncrement"`
+	BookingID    int       `json:"booking_id" gorm:"column:booking_id;not null"`
+	OccurrenceID int       `json:"occurrence_id" gorm:"column:occurrence_id;not null"`
+	ClassID      int       `json:"class_id" gorm:"column:class_id;not null"`
+	MemberID     int       `json:"member_id" gorm:"column:member_id;not null"`
+	PenaltyType  string    `json:"penalty_type" gorm:"column:penalty_type;type:varchar(20);not null"`
+	Fee          float64   `json:"fee" gorm:"column:fee;type:decimal(10,2);not null"`
+	Credits      int       `json:"credits" gorm:"column:credits;not null"`
+	Status       string    `json:"status" gorm:"column:status;type:varchar(20);default:'active'"`
+	ChargeStatus string    `json:"charge_status" gorm:"column:charge_status;type:varchar(20);default:'none'"`
+	PaymentID    *int      `json:"payment_id,omitempty" gorm:"column:payment_id"`
+	CreatedAt    time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (Penalty) TableName() string {
+	return "booking_penalties"
+}
+
+// PenaltyFilter selects penalties. Zero fields are ignored.
+type PenaltyFilter struct {
+	MemberID    int
+	BookingID   int
+	ClassID     int
+	PenaltyType string
+	Status      string
+}
+
+// Suspension keeps a member from booking classes until it ends or is lifted
+type Suspension struct {
+	SuspensionID int        `json:"suspension_id" gorm:"column:suspension_id;primaryKey;autoIncrement"`
+	MemberID     int        `json:"member_id" gorm:"column:member_id;not null"`
+	ClassID      int        `json:"class_id" gorm:"column:class_id;not null"`
+	NoShowCount  int        `json:"no_show_count" gorm:"column:no_show_count;not null"`
+	StartsAt     time.Time  `json:"starts_at" gorm:"column:starts_at;not null"`
+	EndsAt       time.Time  `json:"ends_at" gorm:"column:ends_at;not null"`
+	LiftedAt     *time.Time `json:"lifted_at,omitempty" gorm:"column:lifted_at"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (Suspension) TableName() string {
+	return "member_suspensions"
+}
+
+// IsActive reports whether the suspension is in force at the given time
+func (s Suspension) IsActive(at time.Time) bool {
+	return s.LiftedAt == nil && !at.Before(s.StartsAt) && at.Before(s.EndsAt)
+}
+
+// SuspensionFilter selects suspensions. Zero fields are ignored.
+type SuspensionFilter struct {
+	MemberID   int
+	ActiveOnly bool
+}
+
+// SuspensionError is returned when a suspended member tries to book
+type SuspensionError struct {
+	Until time.Time
+}
+
+func (e *SuspensionError) Error() string {
+	return "member is suspended from booking classes"
+}
+
+// PenaltyRepository defines the operations for cancellation policy, penalty
+// and suspension data access
+type PenaltyRepository interface {
+	// GetPolicy returns the policy of a class, or DefaultPolicy if it has none
+	GetPolicy(ctx context.Context, classID int) (CancellationPolicy, error)
+	SavePolicy(ctx context.Context, policy CancellationPolicy) (CancellationPolicy, error)
+	// Record saves a penalty. A booking gets at most one penalty of each
+	// type; recording it again returns the existing one.
+	Record(ctx context.Context, penalty Penalty) (Penalty, error)
+	GetPenaltiesPaginated(ctx context.Context, filter PenaltyFilter, offset, limit int) ([]Penalty, int, error)
+	GetPenaltyByID(ctx context.Context, id int) (Penalty, error)
+	// Waive waives an active penalty whose fee has not been posted yet
+	Waive(ctx context.Context, id int) (Penalty, error)
+	// WaiveReversed waives an active penalty whose posted fee was reversed
+	WaiveReversed(ctx context.Context, id int) (Penalty, error)
+	// GetPendingCharges returns active penalties recorded before the given
+	// time whose fee still has to be posted, oldest first
+	GetPendingCharges(ctx context.Context, before time.Time, limit int) ([]Penalty, error)
+	// MarkCharged records the payment the fee of an active penalty with a
+	// pending charge was posted as
+	MarkCharged(ctx context.Context, id int, paymentID int) error
+	// CountNoShows counts the no-shows of a member in classes that started
+	// after since, leaving out those before the member's latest suspension
+	CountNoShows(ctx context.Context, memberID int, since time.Time) (int, error)
+	Suspend(ctx context.Context, suspension Suspension) (Suspension, error)
+	// GetActiveSuspension returns the suspension a member is under, or
+	// "member is not suspended"
+	GetActiveSuspension(ctx context.Context, memberID int) (Suspension, error)
+	GetSuspensionsPaginated(ctx context.Context, filter SuspensionFilter, offset, limit int) ([]Suspension, int, error)
+	Lift(ctx context.Context, id int) (Suspension, error)
+}
+
+// ChargePoster posts penalty fees to the payment service
+type ChargePoster interface {
+	// PostCharge records the fee of a penalty as a pending payment of the
+	// member and returns the payment ID. Posting the same penalty again
+	// returns the payment posted before.
+	PostCharge(ctx context.Context, penalty Penalty) (int, error)
+	// ReverseCharge refunds the payment the fee of a penalty was posted as
+	ReverseCharge(ctx context.Context, penalty Penalty) error
+}
@@ -0,0 +1,37 @@
+package model
+
+import "context"
+
+// ReferenceChecker checks IDs that refer to records kept by other services.
+// Members live in the member service, trainers in the staff service and
+// rooms in the facility service.
+type ReferenceChecker interface {
+	// CheckMember makes sure the member exists, is active and has a valid
+	// membership
+	CheckMember(ctx context.Context, memberID int) error
+	// CheckTrainer makes sure the trainer exists and is active
+	CheckTrainer(ctx context.Context, trainerID int) error
+	// CheckRoom makes sure the room exists and is open from startTime to
+	// endTime
+	CheckRoom(ctx context.Context, roomID int, startTime, endTime string) error
+}
+
+// ReferenceError is returned when an ID does not refer to a usable record of
+// another service, such as an inactive member or a closed room
+type ReferenceError struct {
+	Message string
+}
+
+func (e *ReferenceError) Error() string {
+	return e.Message
+}
+
+// ServiceUnavailableError is returned when an ID could not be checked because
+// the service that keeps the record did not answer
+type ServiceUnavailableError struct {
+	Service string
+}
+
+func (e *ServiceUnavailableError) Error() string {
+	return e.Service + " service is unavailable"
+}
@@ -27,6 +27,16 @@ func (Schedule) TableName() string {
 	return "class_schedule"
 }
 
+// StartsOn returns the start of the schedule's session on a date
+func (s Schedule) StartsOn(date time.Time) time.Time {
+	return atTime(date, s.StartTime)
+}
+
+// EndsOn returns the end of the schedule's session on a date
+func (s Schedule) EndsOn(date time.Time) time.Time {
+	return atTime(date, s.EndTime)
+}
+
 // ScheduleRequest is used for creating or updating a schedule
 type ScheduleRequest struct {
 	ClassID   int    `json:"class_id" binding:"required"`
@@ -45,16 +55,58 @@ type ScheduleResponse struct {
 	ClassDuration int    `json:"class_duration"`
 }
 
+// ScheduleConflict is an active schedule that uses the same trainer or room
+// as another schedule at an overlapping time on the same day
+type ScheduleConflict struct {
+	ScheduleID      int    `json:"schedule_id"`
+	ClassID         int    `json:"class_id"`
+	ClassName       string `json:"class_name"`
+	TrainerID       int    `json:"trainer_id"`
+	RoomID          int    `json:"room_id"`
+	DayOfWeek       string `json:"day_of_week"`
+	StartTime       string `json:"start_time"`
+	EndTime         string `json:"end_time"`
+	TrainerConflict bool   `json:"trainer_conflict"`
+	RoomConflict    bool   `json:"room_conflict"`
+}
+
+// ScheduleConflictCheck describes a planned schedule to check for conflicts.
+// ScheduleID is the schedule being edited, which does not conflict with
+// itself; it is zero for new schedules.
+type ScheduleConflictCheck struct {
+	ScheduleID int
+	TrainerID  int
+	RoomID     int
+	DayOfWeek  string
+	StartTime  string
+	EndTime    string
+}
+
+// ScheduleConflictError is returned when a schedule would double-book a
+// trainer or room
+type ScheduleConflictError struct {
+	Conflicts []ScheduleConflict
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return "schedule overlaps with existing schedules of the same trainer or room"
+}
+
 // ScheduleRepository defines the operations for schedule data access
 type ScheduleRepository interface {
 	GetAll(ctx context.Context, status string) ([]ScheduleResponse, error)
 	GetAllPaginated(ctx context.Context, status string, offset, limit int) ([]ScheduleResponse, int, error)
 	GetByID(ctx context.Context, id int) (ScheduleResponse, error)
 	GetByClassID(ctx context.Context, classID int) ([]ScheduleResponse, error)
+	// Create and Update return a *ScheduleConflictError when an active
+	// schedule would double-book its trainer or room
 	Create(ctx context.Context, schedule Schedule) (Schedule, error)
 	Update(ctx context.Context, id int, schedule Schedule) (Schedule, error)
 	Delete(ctx context.Context, id int) error
 	HasBookings(ctx context.Context, id int) (bool, error)
+	// FindConflicts returns the active schedules that overlap with check on
+	// the same day and share its trainer or room
+	FindConflicts(ctx context.Context, check ScheduleConflictCheck) ([]ScheduleConflict, error)
 }
 
 // ScheduleService defines operations for managing schedules
@@ -66,4 +118,7 @@ type ScheduleService interface {
 	CreateSchedule(ctx context.Context, req ScheduleRequest) (Schedule, error)
 	UpdateSchedule(ctx context.Context, id int, req ScheduleRequest) (Schedule, error)
 	DeleteSchedule(ctx context.Context, id int) error
+	// CheckConflicts reports the schedules a planned schedule would clash
+	// with, without saving anything
+	CheckConflicts(ctx context.Context, check ScheduleConflictCheck) ([]ScheduleConflict, error)
 }
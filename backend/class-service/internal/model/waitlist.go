@@ -0,0 +1,93 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Waitlist entry statuses
+const (
+	// WaitlistWaiting entries are in line for a seat
+	WaitlistWaiting = "waiting"
+	// WaitlistOffered entries hold a freed seat until the member confirms it
+	// or the offer expires
+	WaitlistOffered = "offered"
+	// WaitlistPromoted entries were turned into a booking
+	WaitlistPromoted = "promoted"
+	// WaitlistExpired entries were offered a seat that was not confirmed in time
+	WaitlistExpired = "expired"
+	// WaitlistLeft entries were withdrawn by the member
+	WaitlistLeft = "left"
+	// WaitlistCancelled entries belonged to an occurrence that was cancelled
+	WaitlistCancelled = "cancelled"
+)
+
+// WaitlistEntry is a member waiting for a seat in a fully booked class
+type WaitlistEntry struct {
+	WaitlistID     int        `json:"waitlist_id" gorm:"column:waitlist_id;primaryKey;autoIncrement"`
+	ScheduleID     int        `json:"schedule_id" gorm:"column:schedule_id;not null"`
+	OccurrenceID   int        `json:"occurrence_id" gorm:"column:occurrence_id;not null"`
+	MemberID       int        `json:"member_id" gorm:"column:member_id;not null"`
+	BookingDate    time.Time  `json:"booking_date" gorm:"column:booking_date;not null"`
+	Position       int        `json:"position" gorm:"column:position;not null"`
+	Status         string     `json:"status" gorm:"column:status;type:varchar(20);default:'waiting'"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty" gorm:"column:offer_expires_at"`
+	BookingID      *int       `json:"booking_id,omitempty" gorm:"column:booking_id"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (WaitlistEntry) TableName() string {
+	return "class_waitlist"
+}
+
+// IsActive reports whether the entry is still waiting for or holding a seat
+func (e WaitlistEntry) IsActive() bool {
+	return e.Status == WaitlistWaiting || e.Status == WaitlistOffered
+}
+
+// WaitlistRequest is used for joining the waitlist of an occurrence, which
+// is given like in a BookingRequest
+type WaitlistRequest struct {
+	OccurrenceID int       `json:"occurrence_id"`
+	ScheduleID   int       `json:"schedule_id"`
+	MemberID     int       `json:"member_id" binding:"required"`
+	BookingDate  time.Time `json:"booking_date"`
+}
+
+// WaitlistResponse includes class details and the current place in line with
+// the entry
+type WaitlistResponse struct {
+	WaitlistEntry
+	// QueuePosition is the 1-based place among waiting entries of the occurrence,
+	// zero once the entry is no longer waiting
+	QueuePosition int    `json:"queue_position" gorm:"column:queue_position"`
+	ClassName     string `json:"class_name"`
+	DayOfWeek     string `json:"day_of_week"`
+	StartTime     string `json:"start_time"`
+}
+
+// WaitlistFilter selects waitlist entries. Zero fields are ignored.
+type WaitlistFilter struct {
+	ScheduleID   int
+	OccurrenceID int
+	MemberID     int
+	Status       string
+}
+
+// WaitlistRepository defines the operations for waitlist data access
+type WaitlistRepository interface {
+	GetAllPaginated(ctx context.Context, filter WaitlistFilter, offset, limit int) ([]WaitlistResponse, int, error)
+	GetByID(ctx context.Context, id int) (WaitlistResponse, error)
+	Join(ctx context.Context, entry WaitlistEntry) (WaitlistEntry, error)
+	Leave(ctx context.Context, id int) (WaitlistEntry, error)
+	// PromoteNext fills free seats of an occurrence from the front of its
+	// waitlist. With a zero offerWindow members are booked straight away,
+	// otherwise they are offered the seat until the window has passed.
+	PromoteNext(ctx context.Context, occurrenceID int, offerWindow time.Duration) ([]WaitlistEntry, error)
+	ConfirmOffer(ctx context.Context, id int) (Booking, error)
+	// ExpireOffers marks unconfirmed offers past their expiry as expired and
+	// returns the occurrences whose seats were released
+	ExpireOffers(ctx context.Context) ([]int, error)
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // BookingRepository implements model.BookingRepository interface
@@ -24,8 +26,9 @@ func (r *BookingRepository) GetAll(ctx context.Context, status string, dateStr s
 	var bookings []model.BookingResponse
 
 	query := r.db.WithContext(ctx).Table("class_bookings cb").
-		Select("cb.*, c.class_name, cs.day_of_week, cs.start_time, cs.trainer_id").
-		Joins("JOIN class_schedule cs ON cb.schedule_id = cs.schedule_id").
+		Select("cb.*, c.class_name, to_char(o.occurrence_date, 'FMDay') AS day_of_week, o.occurrence_date, o.start_time, o.end_time, o.trainer_id, o.room_id").
+		Joins("JOIN class_occurrences o ON cb.occurrence_id = o.occurrence_id").
+		Joins("JOIN class_schedule cs ON o.schedule_id = cs.schedule_id").
 		Joins("JOIN classes c ON cs.class_id = c.class_id")
 
 	if status != "" {
@@ -33,7 +36,7 @@ func (r *BookingRepository) GetAll(ctx context.Context, status string, dateStr s
 	}
 
 	if dateStr != "" {
-		query = query.Where("DATE(cb.booking_date) = ?", dateStr)
+		query = query.Where("(cb.booking_date AT TIME ZONE ?)::date = ?", timezone(), dateStr)
 	}
 
 	err := query.Order("cb.booking_date DESC").Find(&bookings).Error
@@ -44,8 +47,9 @@ func (r *BookingRepository) GetAll(ctx context.Context, status string, dateStr s
 	return bookings, nil
 }
 
-// GetAllPaginated returns paginated bookings with total count
-func (r *BookingRepository) GetAllPaginated(ctx context.Context, status string, dateStr string, offset, limit int) ([]model.BookingResponse, int, error) {
+// GetAllPaginated returns paginated bookings with total count, optionally
+// filtered by status, date, member and occurrence
+func (r *BookingRepository) GetAllPaginated(ctx context.Context, status string, dateStr string, memberID, occurrenceID int, offset, limit int) ([]model.BookingResponse, int, error) {
 	var bookings []model.BookingResponse
 	var total int64
 
@@ -55,7 +59,13 @@ func (r *BookingRepository) GetAllPaginated(ctx context.Context, status string,
 		countQuery = countQuery.Where("attendance_status = ?", status)
 	}
 	if dateStr != "" {
-		countQuery = countQuery.Where("DATE(booking_date) = ?", dateStr)
+		countQuery = countQuery.Where("(booking_date AT TIME ZONE ?)::date = ?", timezone(), dateStr)
+	}
+	if memberID != 0 {
+		countQuery = countQuery.Where("member_id = ?", memberID)
+	}
+	if occurrenceID != 0 {
+		countQuery = countQuery.Where("occurrence_id = ?", occurrenceID)
 	}
 
 	err := countQuery.Count(&total).Error
@@ -65,8 +75,9 @@ func (r *BookingRepository) GetAllPaginated(ctx context.Context, status string,
 
 	// Data query
 	query := r.db.WithContext(ctx).Table("class_bookings cb").
-		Select("cb.*, c.class_name, cs.day_of_week, cs.start_time, cs.trainer_id").
-		Joins("JOIN class_schedule cs ON cb.schedule_id = cs.schedule_id").
+		Select("cb.*, c.class_name, to_char(o.occurrence_date, 'FMDay') AS day_of_week, o.occurrence_date, o.start_time, o.end_time, o.trainer_id, o.room_id").
+		Joins("JOIN class_occurrences o ON cb.occurrence_id = o.occurrence_id").
+		Joins("JOIN class_schedule cs ON o.schedule_id = cs.schedule_id").
 		Joins("JOIN classes c ON cs.class_id = c.class_id")
 
 	if status != "" {
@@ -74,7 +85,15 @@ func (r *BookingRepository) GetAllPaginated(ctx context.Context, status string,
 	}
 
 	if dateStr != "" {
-		query = query.Where("DATE(cb.booking_date) = ?", dateStr)
+		query = query.Where("(cb.booking_date AT TIME ZONE ?)::date = ?", timezone(), dateStr)
+	}
+
+	if memberID != 0 {
+		query = query.Where("cb.member_id = ?", memberID)
+	}
+
+	if occurrenceID != 0 {
+		query = query.Where("cb.occurrence_id = ?", occurrenceID)
 	}
 
 	err = query.Order("cb.booking_date DESC").
@@ -91,8 +110,9 @@ func (r *BookingRepository) GetByID(ctx context.Context, id int) (model.BookingR
 	var booking model.BookingResponse
 
 	err := r.db.WithContext(ctx).Table("class_bookings cb").
-		Select("cb.*, c.class_name, cs.day_of_week, cs.start_time, cs.trainer_id").
-		Joins("JOIN class_schedule cs ON cb.schedule_id = cs.schedule_id").
+		Select("cb.*, c.class_name, to_char(o.occurrence_date, 'FMDay') AS day_of_week, o.occurrence_date, o.start_time, o.end_time, o.trainer_id, o.room_id").
+		Joins("JOIN class_occurrences o ON cb.occurrence_id = o.occurrence_id").
+		Joins("JOIN class_schedule cs ON o.schedule_id = cs.schedule_id").
 		Joins("JOIN classes c ON cs.class_id = c.class_id").
 		Where("cb.booking_id = ?", id).
 		First(&booking).Error
@@ -112,8 +132,9 @@ func (r *BookingRepository) GetByMemberID(ctx context.Context, memberID int) ([]
 	var bookings []model.BookingResponse
 
 	err := r.db.WithContext(ctx).Table("class_bookings cb").
-		Select("cb.*, c.class_name, cs.day_of_week, cs.start_time, cs.trainer_id").
-		Joins("JOIN class_schedule cs ON cb.schedule_id = cs.schedule_id").
+		Select("cb.*, c.class_name, to_char(o.occurrence_date, 'FMDay') AS day_of_week, o.occurrence_date, o.start_time, o.end_time, o.trainer_id, o.room_id").
+		Joins("JOIN class_occurrences o ON cb.occurrence_id = o.occurrence_id").
+		Joins("JOIN class_schedule cs ON o.schedule_id = cs.schedule_id").
 		Joins("JOIN classes c ON cs.class_id = c.class_id").
 		Where("cb.member_id = ?", memberID).
 		Order("cb.booking_date DESC").
@@ -126,26 +147,88 @@ func (r *BookingRepository) GetByMemberID(ctx context.Context, memberID int) ([]
 	return bookings, nil
 }
 
-// Create adds a new booking
+// Create adds a new booking if its occurrence has a free seat and the member
+// has the credits it costs. The occurrence is locked while the seats are
+// counted, so concurrent bookings for the last seat cannot both succeed. A
+// booking the member cancelled earlier for the same occurrence is booked
+// again, like a promotion from the waitlist does, since a member has at most
+// one booking per occurrence.
 func (r *BookingRepository) Create(ctx context.Context, booking model.Booking) (model.Booking, error) {
-	err := r.db.WithContext(ctx).Create(&booking).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := takeSeat(tx, booking.OccurrenceID); err != nil {
+			return err
+		}
+
+		result := tx.Raw(`
+			INSERT INTO class_bookings (schedule_id, occurrence_id, member_id, booking_date, attendance_status)
+			VALUES (?, ?, ?, ?, 'booked')
+			ON CONFLICT ON CONSTRAINT unique_booking DO UPDATE SET
+				booking_date = EXCLUDED.booking_date,
+				attendance_status = 'booked',
+				feedback_rating = NULL,
+				feedback_comment = NULL,
+				updated_at = NOW()
+			WHERE class_bookings.attendance_status = 'cancelled'
+			RETURNING *`,
+			booking.ScheduleID, booking.OccurrenceID, booking.MemberID, booking.BookingDate,
+		).Scan(&booking)
+		if result.Error != nil {
+			return fmt.Errorf("failed to create booking: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("member already has a booking for this class")
+		}
+		return chargeCredits(tx, booking)
+	})
 	if err != nil {
-		return model.Booking{}, fmt.Errorf("failed to create booking: %w", err)
+		return model.Booking{}, err
 	}
 
 	return booking, nil
 }
 
-// UpdateStatus updates the attendance status of a booking
-func (r *BookingRepository) UpdateStatus(ctx context.Context, id int, status string) (model.Booking, error) {
+// UpdateStatus changes the attendance status of a booking from the given
+// status. The booking is locked while it is changed, and an error is returned
+// when its status is no longer from, so two concurrent changes are not both
+// applied. Moving a cancelled or no-show booking back to booked or
+// attended needs a free seat and is charged its credits again; cancelling
+// refunds them.
+func (r *BookingRepository) UpdateStatus(ctx context.Context, id int, from, status string) (model.Booking, error) {
 	var booking model.Booking
 
-	err := r.db.WithContext(ctx).Model(&booking).
-		Where("booking_id = ?", id).
-		Update("attendance_status", status).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current model.Booking
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("booking_id = ?", id).First(&current).Error
+		if err != nil {
+			return fmt.Errorf("failed to fetch booking: %w", err)
+		}
+		if current.AttendanceStatus != from {
+			return errors.New("booking was changed by another request")
+		}
+
+		if !holdsSeat(current.AttendanceStatus) && holdsSeat(status) {
+			if err := takeSeat(tx, current.OccurrenceID); err != nil {
+				return err
+			}
+			if err := chargeCredits(tx, current); err != nil {
+				return err
+			}
+		}
+
+		err = tx.Model(&booking).
+			Where("booking_id = ?", id).
+			Update("attendance_status", status).Error
+		if err != nil {
+			return fmt.Errorf("failed to update booking status: %w", err)
+		}
 
+		if status == "cancelled" && current.AttendanceStatus != "cancelled" {
+			return refundCredits(tx, id)
+		}
+		return nil
+	})
 	if err != nil {
-		return model.Booking{}, fmt.Errorf("failed to update booking status: %w", err)
+		return model.Booking{}, err
 	}
 
 	// Fetch the updated booking
@@ -181,16 +264,26 @@ func (r *BookingRepository) AddFeedback(ctx context.Context, id int, rating int,
 	return booking, nil
 }
 
-// Cancel cancels a booking
+// Cancel cancels a booked booking and refunds the credits it was charged. It
+// returns an error when the booking is no longer booked, such as when a
+// concurrent request cancelled it first.
 func (r *BookingRepository) Cancel(ctx context.Context, id int) (model.Booking, error) {
 	var booking model.Booking
 
-	err := r.db.WithContext(ctx).Model(&booking).
-		Where("booking_id = ?", id).
-		Update("attendance_status", "cancelled").Error
-
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&booking).
+			Where("booking_id = ? AND attendance_status = 'booked'", id).
+			Update("attendance_status", "cancelled")
+		if result.Error != nil {
+			return fmt.Errorf("failed to cancel booking: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("booking was changed by another request")
+		}
+		return refundCredits(tx, id)
+	})
 	if err != nil {
-		return model.Booking{}, fmt.Errorf("failed to cancel booking: %w", err)
+		return model.Booking{}, err
 	}
 
 	// Fetch the updated booking
@@ -202,30 +295,142 @@ func (r *BookingRepository) Cancel(ctx context.Context, id int) (model.Booking,
 	return booking, nil
 }
 
-// CheckCapacity checks the current and maximum capacity for a schedule
-func (r *BookingRepository) CheckCapacity(ctx context.Context, scheduleID int) (int, int, error) {
+// CheckCapacity checks the current and maximum capacity for an occurrence.
+// Seats offered to waitlisted members count as taken until the offer expires.
+func (r *BookingRepository) CheckCapacity(ctx context.Context, occurrenceID int) (int, int, error) {
 	var currentCount int64
+	var offeredCount int64
 	var maxCapacity int
 
 	// Count current bookings
 	err := r.db.WithContext(ctx).Table("class_bookings").
-		Where("schedule_id = ? AND attendance_status IN (?)", scheduleID, []string{"booked", "attended"}).
+		Where("occurrence_id = ? AND attendance_status IN (?)", occurrenceID, []string{"booked", "attended"}).
 		Count(&currentCount).Error
 
 	if err != nil {
 		return 0, 0, fmt.Errorf("failed to count current bookings: %w", err)
 	}
 
+	// Count seats held for waitlisted members
+	err = r.db.WithContext(ctx).Table("class_waitlist").
+		Where("occurrence_id = ? AND status = ? AND offer_expires_at > NOW()", occurrenceID, model.WaitlistOffered).
+		Count(&offeredCount).Error
+
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count waitlist offers: %w", err)
+	}
+
 	// Get max capacity
-	err = r.db.WithContext(ctx).Table("class_schedule cs").
+	err = r.db.WithContext(ctx).Table("class_occurrences o").
 		Select("c.capacity").
+		Joins("JOIN class_schedule cs ON o.schedule_id = cs.schedule_id").
 		Joins("JOIN classes c ON cs.class_id = c.class_id").
-		Where("cs.schedule_id = ?", scheduleID).
+		Where("o.occurrence_id = ?", occurrenceID).
 		Scan(&maxCapacity).Error
 
 	if err != nil {
 		return 0, 0, fmt.Errorf("failed to get class capacity: %w", err)
 	}
 
-	return int(currentCount), maxCapacity, nil
+	return int(currentCount + offeredCount), maxCapacity, nil
+}
+
+// noShowLockKey is the advisory lock key that keeps replicas from running the
+// no-show job at the same time
+const noShowLockKey = 7350521
+
+// MarkNoShows marks bookings that are still booked for occurrences that ended
+// more than grace ago as no-shows, at most limit at a time. Their penalty is
+// marked pending until ClearPendingPenalty is called. Only one caller at a
+// time gets to mark bookings; the others get locked false.
+func (r *BookingRepository) MarkNoShows(ctx context.Context, grace time.Duration, limit int) ([]model.Booking, bool, error) {
+	var bookings []model.Booking
+	locked := false
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The lock is released when the transaction ends
+		err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", noShowLockKey).Scan(&locked).Error
+		if err != nil {
+			return fmt.Errorf("failed to take no-show job lock: %w", err)
+		}
+		if !locked {
+			return nil
+		}
+
+		err = tx.Raw(`
+			UPDATE class_bookings SET attendance_status = 'no_show', penalty_pending = TRUE, updated_at = NOW()
+			WHERE booking_id IN (
+				SELECT b.booking_id
+				FROM class_bookings b
+				JOIN class_occurrences o ON b.occurrence_id = o.occurrence_id
+				WHERE b.attendance_status = 'booked' AND o.status = 'scheduled'
+					AND (o.occurrence_date + o.end_time) AT TIME ZONE ? <= NOW() - make_interval(secs => ?)
+				ORDER BY b.booking_id
+				LIMIT ?
+				FOR UPDATE OF b SKIP LOCKED)
+			RETURNING *`,
+			timezone(), grace.Seconds(), limit,
+		).Scan(&bookings).Error
+		if err != nil {
+			return fmt.Errorf("failed to mark no-shows: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return bookings, locked, nil
+}
+
+// GetPendingPenalties returns up to limit no-show bookings whose penalty is
+// still pending. Bookings staff moved away from no_show in the meantime are
+// left out.
+func (r *BookingRepository) GetPendingPenalties(ctx context.Context, limit int) ([]model.Booking, error) {
+	var bookings []model.Booking
+
+	err := r.db.WithContext(ctx).
+		Where("penalty_pending AND attendance_status = 'no_show'").
+		Order("booking_id").
+		Limit(limit).
+		Find(&bookings).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookings with pending penalties: %w", err)
+	}
+
+	return bookings, nil
+}
+
+// ClearPendingPenalty records that the penalty of a booking was applied
+func (r *BookingRepository) ClearPendingPenalty(ctx context.Context, id int) error {
+	err := r.db.WithContext(ctx).Model(&model.Booking{}).
+		Where("booking_id = ?", id).
+		UpdateColumn("penalty_pending", false).Error
+	if err != nil {
+		return fmt.Errorf("failed to clear pending penalty: %w", err)
+	}
+	return nil
+}
+
+// takeSeat locks an occurrence for the rest of the transaction and checks
+// that it has a seat left
+func takeSeat(tx *gorm.DB, occurrenceID int) error {
+	if err := lockOccurrence(tx, occurrenceID); err != nil {
+		return err
+	}
+
+	held, capacity, err := heldSeats(tx, occurrenceID)
+	if err != nil {
+		return err
+	}
+	if held >= capacity {
+		return errors.New("class is already at full capacity")
+	}
+	return nil
+}
+
+// holdsSeat reports whether a booking with the given attendance status takes
+// a seat of its occurrence
+func holdsSeat(status string) bool {
+	return status == "booked" || status == "attended"
 }
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// CalendarRepository implements model.CalendarRepository interface
+type CalendarRepository struct {
+	db *gorm.DB
+}
+
+// NewCalendarRepository creates a new CalendarRepository
+func NewCalendarRepository(db *gorm.DB) model.CalendarRepository {
+	return &CalendarRepository{db: db}
+}
+
+// Create saves a new calendar feed
+func (r *CalendarRepository) Create(ctx context.Context, feed model.CalendarFeed) (model.CalendarFeed, error) {
+	if err := r.db.WithContext(ctx).Create(&feed).Error; err != nil {
+		return model.CalendarFeed{}, fmt.Errorf("failed to create calendar feed: %w", err)
+	}
+	return feed, nil
+}
+
+// GetByID returns a calendar feed by its ID
+func (r *CalendarRepository) GetByID(ctx context.Context, id int) (model.CalendarFeed, error) {
+	var feed model.CalendarFeed
+
+	err := r.db.WithContext(ctx).Where("feed_id = ?", id).First(&feed).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.CalendarFeed{}, errors.New("calendar feed not found")
+		}
+		return model.CalendarFeed{}, fmt.Errorf("failed to fetch calendar feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+// GetByTokenHash returns the feed that is not revoked with the token hash and
+// records that it was accessed
+func (r *CalendarRepository) GetByTokenHash(ctx context.Context, tokenHash string) (model.CalendarFeed, error) {
+	var feed model.CalendarFeed
+
+	err := r.db.WithContext(ctx).
+		Where("token_hash = ? AND revoked_at IS NULL", tokenHash).
+		First(&feed).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.CalendarFeed{}, errors.New("calendar feed not found")
+		}
+		return model.CalendarFeed{}, fmt.Errorf("failed to fetch calendar feed: %w", err)
+	}
+
+	now := time.Now()
+	err = r.db.WithContext(ctx).Model(&model.CalendarFeed{}).
+		Where("feed_id = ?", feed.FeedID).
+		Update("last_accessed_at", now).Error
+	if err != nil {
+		return model.CalendarFeed{}, fmt.Errorf("failed to record calendar feed access: %w", err)
+	}
+	feed.LastAccessedAt = &now
+
+	return feed, nil
+}
+
+// GetAllPaginated returns paginated calendar feeds with total count, newest
+// first
+func (r *CalendarRepository) GetAllPaginated(ctx context.Context, filter model.CalendarFeedFilter, offset, limit int) ([]model.CalendarFeed, int, error) {
+	var feeds []model.CalendarFeed
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&model.CalendarFeed{})
+	if filter.FeedType != "" {
+		query = query.Where("feed_type = ?", filter.FeedType)
+	}
+	if filter.SubjectID != 0 {
+		query = query.Where("subject_id = ?", filter.SubjectID)
+	}
+	if filter.CreatedBy != "" {
+		query = query.Where("created_by = ?", filter.CreatedBy)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("revoked_at IS NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count calendar feeds: %w", err)
+	}
+
+	err := query.Order("created_at DESC, feed_id DESC").Limit(limit).Offset(offset).Find(&feeds).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch calendar feeds: %w", err)
+	}
+
+	return feeds, int(total), nil
+}
+
+// Revoke revokes a calendar feed so its token no longer opens it
+func (r *CalendarRepository) Revoke(ctx context.Context, id int) (model.CalendarFeed, error) {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return model.CalendarFeed{}, err
+	}
+
+	result := r.db.WithContext(ctx).Model(&model.CalendarFeed{}).
+		Where("feed_id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return model.CalendarFeed{}, fmt.Errorf("failed to revoke calendar feed: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.CalendarFeed{}, errors.New("calendar feed is already revoked")
+	}
+
+	return r.GetByID(ctx, id)
+}
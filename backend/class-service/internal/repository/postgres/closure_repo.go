@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// ClosureRepository implements model.ClosureRepository interface
+type ClosureRepository struct {
+	db *gorm.DB
+}
+
+// NewClosureRepository creates a new ClosureRepository
+func NewClosureRepository(db *gorm.DB) model.ClosureRepository {
+	return &ClosureRepository{db: db}
+}
+
+// closureCovers returns the condition under which a closure cl covers a class
+// on date with trainer and room, each given as an SQL expression
+func closureCovers(date, trainer, room string) string {
+	return fmt.Sprintf(`%s BETWEEN cl.start_date AND cl.end_date
+		AND (cl.scope = 'center'
+			OR (cl.scope = 'trainer' AND cl.subject_id = %s)
+			OR (cl.scope = 'room' AND cl.subject_id = %s))`, date, trainer, room)
+}
+
+// Create saves a closure and applies it to the occurrences it covers that
+// have not started yet. Removed occurrences come back when the closure is
+// deleted.
+func (r *ClosureRepository) Create(ctx context.Context, closure model.Closure) (model.ClosureResult, error) {
+	result := model.ClosureResult{}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&closure).Error; err != nil {
+			return fmt.Errorf("failed to create closure: %w", err)
+		}
+
+		var occurrenceIDs []int
+		err := tx.Raw(`
+			SELECT o.occurrence_id
+			FROM class_occurrences o
+			JOIN closures cl ON cl.closure_id = ?
+			WHERE o.status = ? AND (o.occurrence_date + o.start_time) AT TIME ZONE ? > NOW()
+				AND `+closureCovers("o.occurrence_date", "o.trainer_id", "o.room_id")+`
+			ORDER BY o.occurrence_id
+			FOR UPDATE OF o`,
+			closure.ClosureID, model.OccurrenceScheduled, timezone(),
+		).Scan(&occurrenceIDs).Error
+		if err != nil {
+			return fmt.Errorf("failed to find closed class occurrences: %w", err)
+		}
+		if len(occurrenceIDs) == 0 {
+			return nil
+		}
+
+		result.RemovedOccurrences, result.CancelledOccurrences, err = closeOccurrences(tx, closure.ClosureID, closure.Reason, occurrenceIDs)
+		return err
+	})
+	if err != nil {
+		return model.ClosureResult{}, err
+	}
+
+	result.Closure = closure
+	result.CancelledBookings, err = r.GetBookings(ctx, closure.ClosureID)
+	if err != nil {
+		return model.ClosureResult{}, err
+	}
+
+	return result, nil
+}
+
+// closeOccurrences applies a closure to the given occurrences, which tx has
+// locked. Occurrences that were not changed one by one and that nobody booked
+// or is waiting for are removed; the others are cancelled with the reason of
+// the closure, and the bookings cancelled with them are recorded. It returns
+// how many occurrences were removed and cancelled.
+func closeOccurrences(tx *gorm.DB, closureID int, reason string, occurrenceIDs []int) (int, int, error) {
+	// The occurrences are locked, so no booking can be made on them
+	// between this check and their removal
+	var removedIDs []int
+	err := tx.Raw(`
+		DELETE FROM class_occurrences o
+		WHERE o.occurrence_id IN ? AND NOT o.is_modified
+			AND NOT EXISTS (SELECT 1 FROM class_bookings cb WHERE cb.occurrence_id = o.occurrence_id)
+			AND NOT EXISTS (SELECT 1 FROM class_waitlist w
+				WHERE w.occurrence_id = o.occurrence_id AND w.status IN ('waiting', 'offered'))
+		RETURNING o.occurrence_id`,
+		occurrenceIDs,
+	).Scan(&removedIDs).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to remove closed class occurrences: %w", err)
+	}
+
+	removed := make(map[int]bool, len(removedIDs))
+	for _, id := range removedIDs {
+		removed[id] = true
+	}
+
+	cancelled := 0
+	for _, id := range occurrenceIDs {
+		if removed[id] {
+			continue
+		}
+
+		bookings, err := cancelOccurrence(tx, id, reason)
+		if err != nil {
+			return 0, 0, err
+		}
+		cancelled++
+
+		for _, booking := range bookings {
+			err := tx.Exec(`
+				INSERT INTO closure_bookings (closure_id, booking_id, occurrence_id, member_id)
+				VALUES (?, ?, ?, ?)`,
+				closureID, booking.BookingID, id, booking.MemberID,
+			).Error
+			if err != nil {
+				return 0, 0, fmt.Errorf("failed to record closed booking: %w", err)
+			}
+		}
+	}
+
+	return len(removedIDs), cancelled, nil
+}
+
+// closeCovered applies the closures covering the scheduled occurrences o
+// matched by where that have not started yet, such as occurrences that just
+// moved to a closed trainer or room. An occurrence covered by several closures
+// is closed by the earliest of them.
+func closeCovered(tx *gorm.DB, where string, args ...interface{}) error {
+	var covered []struct {
+		OccurrenceID int
+		ClosureID    int
+		Reason       string
+	}
+	err := tx.Raw(`
+		SELECT o.occurrence_id, cl.closure_id, cl.reason
+		FROM class_occurrences o
+		JOIN closures cl ON `+closureCovers("o.occurrence_date", "o.trainer_id", "o.room_id")+`
+		WHERE o.status = 'scheduled' AND (o.occurrence_date + o.start_time) AT TIME ZONE ? > NOW()
+			AND `+where+`
+		ORDER BY cl.start_date, cl.closure_id, o.occurrence_id
+		FOR UPDATE OF o`,
+		append([]interface{}{timezone()}, args...)...,
+	).Scan(&covered).Error
+	if err != nil {
+		return fmt.Errorf("failed to find closed class occurrences: %w", err)
+	}
+
+	var closureIDs []int
+	reasons := make(map[int]string)
+	occurrenceIDs := make(map[int][]int)
+	seen := make(map[int]bool)
+	for _, c := range covered {
+		if seen[c.OccurrenceID] {
+			continue
+		}
+		seen[c.OccurrenceID] = true
+
+		if _, ok := reasons[c.ClosureID]; !ok {
+			closureIDs = append(closureIDs, c.ClosureID)
+			reasons[c.ClosureID] = c.Reason
+		}
+		occurrenceIDs[c.ClosureID] = append(occurrenceIDs[c.ClosureID], c.OccurrenceID)
+	}
+
+	for _, id := range closureIDs {
+		if _, _, err := closeOccurrences(tx, id, reasons[id], occurrenceIDs[id]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetByID returns a closure by its ID
+func (r *ClosureRepository) GetByID(ctx context.Context, id int) (model.Closure, error) {
+	var closure model.Closure
+
+	err := r.db.WithContext(ctx).Where("closure_id = ?", id).First(&closure).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Closure{}, errors.New("closure not found")
+		}
+		return model.Closure{}, fmt.Errorf("failed to fetch closure: %w", err)
+	}
+
+	return closure, nil
+}
+
+// GetAllPaginated returns paginated closures with total count, by start date
+func (r *ClosureRepository) GetAllPaginated(ctx context.Context, filter model.ClosureFilter, offset, limit int) ([]model.Closure, int, error) {
+	var closures []model.Closure
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&model.Closure{})
+	if filter.Scope != "" {
+		query = query.Where("scope = ?", filter.Scope)
+	}
+	if filter.SubjectID != 0 {
+		query = query.Where("subject_id = ?", filter.SubjectID)
+	}
+	if filter.From != nil {
+		query = query.Where("end_date >= ?", filter.From.Format(dateLayout))
+	}
+	if filter.To != nil {
+		query = query.Where("start_date <= ?", filter.To.Format(dateLayout))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count closures: %w", err)
+	}
+
+	err := query.Order("start_date, closure_id").Limit(limit).Offset(offset).Find(&closures).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch closures: %w", err)
+	}
+
+	return closures, int(total), nil
+}
+
+// GetCovering returns the closures that fall on a date and apply to the
+// trainer or room
+func (r *ClosureRepository) GetCovering(ctx context.Context, date time.Time, trainerID, roomID int) ([]model.Closure, error) {
+	var closures []model.Closure
+
+	err := r.db.WithContext(ctx).Table("closures cl").
+		Where(closureCovers("?::date", "?", "?"), date.Format(dateLayout), trainerID, roomID).
+		Order("cl.start_date, cl.closure_id").
+		Find(&closures).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch closures: %w", err)
+	}
+
+	return closures, nil
+}
+
+// GetBookings returns the bookings a closure cancelled, by class start
+func (r *ClosureRepository) GetBookings(ctx context.Context, id int) ([]model.ClosedBooking, error) {
+	var bookings []model.ClosedBooking
+
+	err := r.db.WithContext(ctx).Table("closure_bookings clb").
+		Select("clb.closure_id, clb.booking_id, clb.occurrence_id, clb.member_id, c.class_name, o.occurrence_date, o.start_time, clb.created_at").
+		Joins("JOIN class_occurrences o ON clb.occurrence_id = o.occurrence_id").
+		Joins("JOIN class_schedule cs ON o.schedule_id = cs.schedule_id").
+		Joins("JOIN classes c ON cs.class_id = c.class_id").
+		Where("clb.closure_id = ?", id).
+		Order("o.occurrence_date, o.start_time, clb.booking_id").
+		Scan(&bookings).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch closed bookings: %w", err)
+	}
+
+	return bookings, nil
+}
+
+// Delete deletes a closure together with the record of the bookings it
+// cancelled
+func (r *ClosureRepository) Delete(ctx context.Context, id int) error {
+	result := r.db.WithContext(ctx).Where("closure_id = ?", id).Delete(&model.Closure{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete closure: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("closure not found")
+	}
+	return nil
+}
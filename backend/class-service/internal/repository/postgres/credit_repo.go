@@ -0,0 +1,364 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// usableGrantsCondition selects the grants of a member that can be spent now
+const usableGrantsCondition = "member_id = ? AND remaining > 0 AND valid_from <= NOW() AND (expires_at IS NULL OR expires_at > NOW())"
+
+// CreditRepository implements model.CreditRepository interface
+type CreditRepository struct {
+	db *gorm.DB
+}
+
+// NewCreditRepository creates a new CreditRepository
+func NewCreditRepository(db *gorm.DB) model.CreditRepository {
+	return &CreditRepository{db: db}
+}
+
+// Grant adds a grant and records it in the ledger, or returns the grant the
+// member already has with the same source and reference
+func (r *CreditRepository) Grant(ctx context.Context, grant model.CreditGrant) (model.CreditGrant, error) {
+	grant.Remaining = grant.Credits
+	if grant.ValidFrom.IsZero() {
+		grant.ValidFrom = time.Now()
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&grant)
+		if result.Error != nil {
+			return fmt.Errorf("failed to create credit grant: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			err := tx.Where("member_id = ? AND source = ? AND reference = ?", grant.MemberID, grant.Source, grant.Reference).
+				First(&grant).Error
+			if err != nil {
+				return fmt.Errorf("failed to fetch credit grant: %w", err)
+			}
+			return nil
+		}
+
+		return tx.Create(&model.CreditTransaction{
+			MemberID:        grant.MemberID,
+			GrantID:         grant.GrantID,
+			TransactionType: model.LedgerGrant,
+			Amount:          grant.Credits,
+			Description:     grant.Description,
+		}).Error
+	})
+	if err != nil {
+		return model.CreditGrant{}, err
+	}
+
+	return grant, nil
+}
+
+// GetBalance returns the credits a member can book with now, from the grants
+// that expire first
+func (r *CreditRepository) GetBalance(ctx context.Context, memberID int) (model.CreditBalance, error) {
+	var grants []model.CreditGrant
+
+	err := r.db.WithContext(ctx).
+		Where(usableGrantsCondition, memberID).
+		Order("expires_at NULLS LAST, grant_id").
+		Find(&grants).Error
+	if err != nil {
+		return model.CreditBalance{}, fmt.Errorf("failed to fetch credit grants: %w", err)
+	}
+
+	balance := model.CreditBalance{MemberID: memberID, Grants: grants}
+	for _, grant := range grants {
+		balance.Balance += grant.Remaining
+	}
+	return balance, nil
+}
+
+// GetTransactionsPaginated returns the credit ledger of a member with total
+// count, newest first
+func (r *CreditRepository) GetTransactionsPaginated(ctx context.Context, memberID int, offset, limit int) ([]model.CreditTransaction, int, error) {
+	var transactions []model.CreditTransaction
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&model.CreditTransaction{}).Where("member_id = ?", memberID)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count credit transactions: %w", err)
+	}
+
+	err := query.Order("created_at DESC, transaction_id DESC").Limit(limit).Offset(offset).Find(&transactions).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch credit transactions: %w", err)
+	}
+
+	return transactions, int(total), nil
+}
+
+// Forfeit takes the credits of a penalty from the member, at most as many as
+// they have left
+func (r *CreditRepository) Forfeit(ctx context.Context, penalty model.Penalty) error {
+	if penalty.Credits == 0 {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locking the grants first serializes forfeits of the member
+		grants, balance, err := usableGrants(tx, penalty.MemberID)
+		if err != nil {
+			return err
+		}
+
+		var forfeited int64
+		err = tx.Model(&model.CreditTransaction{}).
+			Where("penalty_id = ? AND transaction_type = ?", penalty.PenaltyID, model.LedgerForfeit).
+			Count(&forfeited).Error
+		if err != nil {
+			return fmt.Errorf("failed to fetch forfeited credits: %w", err)
+		}
+		if forfeited > 0 {
+			return nil
+		}
+
+		return spendCredits(tx, grants, min(penalty.Credits, balance), model.CreditTransaction{
+			MemberID:        penalty.MemberID,
+			PenaltyID:       &penalty.PenaltyID,
+			TransactionType: model.LedgerForfeit,
+			Description:     fmt.Sprintf("Forfeited for penalty %d", penalty.PenaltyID),
+		})
+	})
+}
+
+// RestoreForfeit returns the credits forfeited for a penalty to the grants
+// they were taken from
+func (r *CreditRepository) RestoreForfeit(ctx context.Context, penaltyID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var taken []grantAmount
+		err := tx.Raw(`
+			SELECT grant_id, member_id, -SUM(amount) AS amount
+			FROM credit_transactions
+			WHERE penalty_id = ? AND transaction_type IN (?, ?)
+			GROUP BY grant_id, member_id
+			HAVING SUM(amount) < 0`,
+			penaltyID, model.LedgerForfeit, model.LedgerRestore,
+		).Scan(&taken).Error
+		if err != nil {
+			return fmt.Errorf("failed to fetch forfeited credits: %w", err)
+		}
+
+		return returnCredits(tx, taken, model.CreditTransaction{
+			PenaltyID:       &penaltyID,
+			TransactionType: model.LedgerRestore,
+			Description:     fmt.Sprintf("Restored for waived penalty %d", penaltyID),
+		})
+	})
+}
+
+// ListPlans returns the credit plans of all memberships
+func (r *CreditRepository) ListPlans(ctx context.Context) ([]model.CreditPlan, error) {
+	var plans []model.CreditPlan
+
+	err := r.db.WithContext(ctx).Order("membership_id").Find(&plans).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch credit plans: %w", err)
+	}
+
+	return plans, nil
+}
+
+// GetPlan returns the credit plan of a membership
+func (r *CreditRepository) GetPlan(ctx context.Context, membershipID int) (model.CreditPlan, error) {
+	var plan model.CreditPlan
+
+	err := r.db.WithContext(ctx).Where("membership_id = ?", membershipID).First(&plan).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.CreditPlan{}, errors.New("credit plan not found")
+		}
+		return model.CreditPlan{}, fmt.Errorf("failed to fetch credit plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+// SavePlan creates or replaces the credit plan of a membership
+func (r *CreditRepository) SavePlan(ctx context.Context, plan model.CreditPlan) (model.CreditPlan, error) {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "membership_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"credits_per_month", "description", "updated_at"}),
+	}).Create(&plan).Error
+	if err != nil {
+		return model.CreditPlan{}, fmt.Errorf("failed to save credit plan: %w", err)
+	}
+
+	return r.GetPlan(ctx, plan.MembershipID)
+}
+
+// DeletePlan deletes the credit plan of a membership. Credits already
+// granted under it are kept.
+func (r *CreditRepository) DeletePlan(ctx context.Context, membershipID int) error {
+	result := r.db.WithContext(ctx).Where("membership_id = ?", membershipID).Delete(&model.CreditPlan{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete credit plan: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("credit plan not found")
+	}
+	return nil
+}
+
+// grantAmount is a number of credits taken from or returned to a grant
+type grantAmount struct {
+	GrantID  int
+	MemberID int
+	Amount   int
+}
+
+// chargeCredits takes the credits the class of a booking costs from the
+// member, less what the booking was already charged. The grants are locked
+// for the rest of the transaction.
+func chargeCredits(tx *gorm.DB, booking model.Booking) error {
+	var cost int
+	err := tx.Raw(`
+		SELECT COALESCE(p.credit_cost, 0)
+		FROM class_occurrences o
+		JOIN class_schedule cs ON o.schedule_id = cs.schedule_id
+		LEFT JOIN class_policies p ON p.class_id = cs.class_id
+		WHERE o.occurrence_id = ?`, booking.OccurrenceID,
+	).Scan(&cost).Error
+	if err != nil {
+		return fmt.Errorf("failed to get credit cost: %w", err)
+	}
+	if cost == 0 {
+		return nil
+	}
+
+	var charged int
+	err = tx.Raw(`
+		SELECT COALESCE(-SUM(amount), 0)
+		FROM credit_transactions
+		WHERE booking_id = ? AND transaction_type IN (?, ?)`,
+		booking.BookingID, model.LedgerDebit, model.LedgerRefund,
+	).Scan(&charged).Error
+	if err != nil {
+		return fmt.Errorf("failed to get charged credits: %w", err)
+	}
+	if charged >= cost {
+		return nil
+	}
+
+	grants, balance, err := usableGrants(tx, booking.MemberID)
+	if err != nil {
+		return err
+	}
+	if balance < cost-charged {
+		return errors.New("member has not enough class credits")
+	}
+
+	return spendCredits(tx, grants, cost-charged, model.CreditTransaction{
+		MemberID:        booking.MemberID,
+		BookingID:       &booking.BookingID,
+		TransactionType: model.LedgerDebit,
+		Description:     fmt.Sprintf("Booking %d", booking.BookingID),
+	})
+}
+
+// refundCredits returns the credits a booking was charged to the grants they
+// were taken from. Credits of grants that expired in the meantime are lost
+// with them.
+func refundCredits(tx *gorm.DB, bookingID int) error {
+	var charged []grantAmount
+	err := tx.Raw(`
+		SELECT grant_id, member_id, -SUM(amount) AS amount
+		FROM credit_transactions
+		WHERE booking_id = ? AND transaction_type IN (?, ?)
+		GROUP BY grant_id, member_id
+		HAVING SUM(amount) < 0`,
+		bookingID, model.LedgerDebit, model.LedgerRefund,
+	).Scan(&charged).Error
+	if err != nil {
+		return fmt.Errorf("failed to get charged credits: %w", err)
+	}
+
+	return returnCredits(tx, charged, model.CreditTransaction{
+		BookingID:       &bookingID,
+		TransactionType: model.LedgerRefund,
+		Description:     fmt.Sprintf("Cancelled booking %d", bookingID),
+	})
+}
+
+// usableGrants locks the grants a member can spend now, those that expire
+// first first, and returns them with their total
+func usableGrants(tx *gorm.DB, memberID int) ([]model.CreditGrant, int, error) {
+	var grants []model.CreditGrant
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(usableGrantsCondition, memberID).
+		Order("expires_at NULLS LAST, grant_id").
+		Find(&grants).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to lock credit grants: %w", err)
+	}
+
+	balance := 0
+	for _, grant := range grants {
+		balance += grant.Remaining
+	}
+	return grants, balance, nil
+}
+
+// spendCredits takes amount credits from grants in order and records each
+// part in the ledger as entry
+func spendCredits(tx *gorm.DB, grants []model.CreditGrant, amount int, entry model.CreditTransaction) error {
+	for _, grant := range grants {
+		if amount == 0 {
+			return nil
+		}
+
+		taken := min(grant.Remaining, amount)
+		err := tx.Model(&model.CreditGrant{}).
+			Where("grant_id = ?", grant.GrantID).
+			Update("remaining", gorm.Expr("remaining - ?", taken)).Error
+		if err != nil {
+			return fmt.Errorf("failed to take credits: %w", err)
+		}
+
+		record := entry
+		record.GrantID = grant.GrantID
+		record.Amount = -taken
+		if err := tx.Create(&record).Error; err != nil {
+			return fmt.Errorf("failed to record credit transaction: %w", err)
+		}
+		amount -= taken
+	}
+	return nil
+}
+
+// returnCredits adds credits back to the grants they were taken from and
+// records each in the ledger as entry
+func returnCredits(tx *gorm.DB, amounts []grantAmount, entry model.CreditTransaction) error {
+	for _, returned := range amounts {
+		err := tx.Model(&model.CreditGrant{}).
+			Where("grant_id = ?", returned.GrantID).
+			Update("remaining", gorm.Expr("remaining + ?", returned.Amount)).Error
+		if err != nil {
+			return fmt.Errorf("failed to return credits: %w", err)
+		}
+
+		record := entry
+		record.MemberID = returned.MemberID
+		record.GrantID = returned.GrantID
+		record.Amount = returned.Amount
+		if err := tx.Create(&record).Error; err != nil {
+			return fmt.Errorf("failed to record credit transaction: %w", err)
+		}
+	}
+	return nil
+}
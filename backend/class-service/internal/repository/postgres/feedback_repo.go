@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// FeedbackRepository implements model.FeedbackRepository interface
+type FeedbackRepository struct {
+	db *gorm.DB
+}
+
+// NewFeedbackRepository creates a new FeedbackRepository
+func NewFeedbackRepository(db *gorm.DB) model.FeedbackRepository {
+	return &FeedbackRepository{db: db}
+}
+
+// feedback starts a query on the bookings with a rating that the filter
+// selects, joined with their occurrence, schedule and class
+func (r *FeedbackRepository) feedback(ctx context.Context, filter model.FeedbackFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Table("class_bookings cb").
+		Joins("JOIN class_occurrences o ON cb.occurrence_id = o.occurrence_id").
+		Joins("JOIN class_schedule cs ON o.schedule_id = cs.schedule_id").
+		Joins("JOIN classes c ON cs.class_id = c.class_id").
+		Where("cb.feedback_rating IS NOT NULL")
+
+	if filter.ClassID != 0 {
+		query = query.Where("cs.class_id = ?", filter.ClassID)
+	}
+	if filter.ScheduleID != 0 {
+		query = query.Where("o.schedule_id = ?", filter.ScheduleID)
+	}
+	if filter.TrainerID != 0 {
+		query = query.Where("o.trainer_id = ?", filter.TrainerID)
+	}
+	if filter.From != nil {
+		query = query.Where("o.occurrence_date >= ?", filter.From.Format(dateLayout))
+	}
+	if filter.To != nil {
+		query = query.Where("o.occurrence_date <= ?", filter.To.Format(dateLayout))
+	}
+	return query
+}
+
+// GetDistribution returns the number of ratings of each value
+func (r *FeedbackRepository) GetDistribution(ctx context.Context, filter model.FeedbackFilter) (map[int]int, error) {
+	var rows []struct {
+		Rating int
+		Count  int
+	}
+
+	err := r.feedback(ctx, filter).
+		Select("cb.feedback_rating AS rating, COUNT(*) AS count").
+		Group("cb.feedback_rating").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count feedback ratings: %w", err)
+	}
+
+	distribution := make(map[int]int, len(rows))
+	for _, row := range rows {
+		distribution[row.Rating] = row.Count
+	}
+	return distribution, nil
+}
+
+// GetRecentComments returns the latest comments, by occurrence date
+func (r *FeedbackRepository) GetRecentComments(ctx context.Context, filter model.FeedbackFilter, limit int) ([]model.FeedbackComment, error) {
+	var comments []model.FeedbackComment
+
+	err := r.feedback(ctx, filter).
+		Select(`cb.booking_id, cb.occurrence_id, o.schedule_id, cs.class_id, c.class_name, o.trainer_id,
+			cb.member_id, o.occurrence_date, cb.feedback_rating AS rating, cb.feedback_comment AS comment, cb.updated_at`).
+		Where("COALESCE(cb.feedback_comment, '') <> ''").
+		Order("o.occurrence_date DESC, cb.updated_at DESC, cb.booking_id DESC").
+		Limit(limit).
+		Scan(&comments).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feedback comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// GetTrainerRatings returns the rating of every trainer who taught an
+// occurrence that got feedback
+func (r *FeedbackRepository) GetTrainerRatings(ctx context.Context) ([]model.TrainerRating, error) {
+	var ratings []model.TrainerRating
+
+	err := r.feedback(ctx, model.FeedbackFilter{}).
+		Select("o.trainer_id, AVG(cb.feedback_rating)::float AS rating, COUNT(*) AS count").
+		Group("o.trainer_id").
+		Order("o.trainer_id").
+		Scan(&ratings).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate trainer ratings: %w", err)
+	}
+
+	return ratings, nil
+}
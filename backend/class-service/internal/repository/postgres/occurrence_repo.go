@@ -0,0 +1,404 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// occurrenceColumns selects an occurrence with its class and the number of
+// seats taken
+const occurrenceColumns = `o.*, c.class_id, c.class_name, c.capacity,
+	(SELECT COUNT(*) FROM class_bookings cb
+	 WHERE cb.occurrence_id = o.occurrence_id AND cb.attendance_status IN ('booked', 'attended')) AS booked_count`
+
+// dateLayout is the layout of DATE values passed to queries
+const dateLayout = "2006-01-02"
+
+// timezone returns the time zone of the center for AT TIME ZONE, as the
+// dates and times of occurrences are wall-clock times in it
+func timezone() string {
+	return model.Location().String()
+}
+
+// OccurrenceRepository implements model.OccurrenceRepository interface
+type OccurrenceRepository struct {
+	db *gorm.DB
+}
+
+// NewOccurrenceRepository creates a new OccurrenceRepository
+func NewOccurrenceRepository(db *gorm.DB) model.OccurrenceRepository {
+	return &OccurrenceRepository{db: db}
+}
+
+// GetAllPaginated returns paginated occurrences with total count, in
+// chronological order
+func (r *OccurrenceRepository) GetAllPaginated(ctx context.Context, filter model.OccurrenceFilter, offset, limit int) ([]model.OccurrenceResponse, int, error) {
+	var occurrences []model.OccurrenceResponse
+	var total int64
+
+	err := r.filtered(r.occurrences(ctx), filter).Count(&total).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count class occurrences: %w", err)
+	}
+
+	err = r.filtered(r.occurrences(ctx).Select(occurrenceColumns), filter).
+		Order("o.occurrence_date, o.start_time").
+		Limit(limit).Offset(offset).Find(&occurrences).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch class occurrences: %w", err)
+	}
+
+	return occurrences, int(total), nil
+}
+
+// GetAll returns all occurrences the filter selects, in chronological order
+func (r *OccurrenceRepository) GetAll(ctx context.Context, filter model.OccurrenceFilter) ([]model.OccurrenceResponse, error) {
+	var occurrences []model.OccurrenceResponse
+
+	err := r.filtered(r.occurrences(ctx).Select(occurrenceColumns), filter).
+		Order("o.occurrence_date, o.start_time, o.occurrence_id").
+		Find(&occurrences).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch class occurrences: %w", err)
+	}
+
+	return occurrences, nil
+}
+
+// occurrences starts a query on occurrences joined with their class
+func (r *OccurrenceRepository) occurrences(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Table("class_occurrences o").
+		Joins("JOIN class_schedule cs ON o.schedule_id = cs.schedule_id").
+		Joins("JOIN classes c ON cs.class_id = c.class_id")
+}
+
+// filtered applies the filter to a query from occurrences
+func (r *OccurrenceRepository) filtered(query *gorm.DB, filter model.OccurrenceFilter) *gorm.DB {
+	if filter.ScheduleID != 0 {
+		query = query.Where("o.schedule_id = ?", filter.ScheduleID)
+	}
+	if filter.ClassID != 0 {
+		query = query.Where("cs.class_id = ?", filter.ClassID)
+	}
+	if filter.TrainerID != 0 {
+		query = query.Where("o.trainer_id = ?", filter.TrainerID)
+	}
+	if filter.RoomID != 0 {
+		query = query.Where("o.room_id = ?", filter.RoomID)
+	}
+	if filter.Status != "" {
+		query = query.Where("o.status = ?", filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("o.occurrence_date >= ?", filter.From.Format(dateLayout))
+	}
+	if filter.To != nil {
+		query = query.Where("o.occurrence_date <= ?", filter.To.Format(dateLayout))
+	}
+	return query
+}
+
+// GetByID returns an occurrence by its ID
+func (r *OccurrenceRepository) GetByID(ctx context.Context, id int) (model.OccurrenceResponse, error) {
+	return r.first(r.occurrences(ctx).Where("o.occurrence_id = ?", id))
+}
+
+// GetByScheduleDate returns the occurrence of a schedule that takes place on
+// the given date
+func (r *OccurrenceRepository) GetByScheduleDate(ctx context.Context, scheduleID int, date time.Time) (model.OccurrenceResponse, error) {
+	return r.first(r.occurrences(ctx).
+		Where("o.schedule_id = ? AND o.occurrence_date = ?", scheduleID, date.Format(dateLayout)).
+		Order("o.status DESC, o.occurrence_id"))
+}
+
+// first returns the first occurrence found by query
+func (r *OccurrenceRepository) first(query *gorm.DB) (model.OccurrenceResponse, error) {
+	var occurrence model.OccurrenceResponse
+
+	err := query.Select(occurrenceColumns).First(&occurrence).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.OccurrenceResponse{}, errors.New("class occurrence not found")
+		}
+		return model.OccurrenceResponse{}, fmt.Errorf("failed to fetch class occurrence: %w", err)
+	}
+
+	return occurrence, nil
+}
+
+// Generate creates the missing occurrences of active schedules between from
+// and to. Dates that already had an occurrence, even one that was moved or
+// cancelled since, are skipped, and so are dates a closure covers.
+func (r *OccurrenceRepository) Generate(ctx context.Context, scheduleID int, from, to time.Time) (int, error) {
+	result := r.db.WithContext(ctx).Exec(`
+		INSERT INTO class_occurrences (schedule_id, occurrence_date, original_date, start_time, end_time, trainer_id, room_id)
+		SELECT cs.schedule_id, d::date, d::date, cs.start_time, cs.end_time, cs.trainer_id, cs.room_id
+		FROM class_schedule cs
+		CROSS JOIN generate_series(?::date, ?::date, interval '1 day') AS d
+		WHERE cs.status = 'active' AND to_char(d, 'FMDay') = cs.day_of_week
+			AND (? = 0 OR cs.schedule_id = ?)
+			AND NOT EXISTS (SELECT 1 FROM closures cl WHERE `+closureCovers("d::date", "cs.trainer_id", "cs.room_id")+`)
+		ON CONFLICT ON CONSTRAINT unique_occurrence DO NOTHING`,
+		from.Format(dateLayout), to.Format(dateLayout), scheduleID, scheduleID,
+	)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to generate class occurrences: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// SyncSchedule applies a changed schedule to its unmodified occurrences from
+// the given date on. Occurrences that no longer fit the schedule, because it
+// moved to another day or is no longer active, are removed unless members
+// booked or are waiting for them. Occurrences a closure now covers, because
+// the schedule moved to a closed trainer or room, are closed as when the
+// closure is created.
+func (r *OccurrenceRepository) SyncSchedule(ctx context.Context, scheduleID int, from time.Time) error {
+	date := from.Format(dateLayout)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			DELETE FROM class_occurrences o
+			USING class_schedule cs
+			WHERE o.schedule_id = cs.schedule_id AND o.schedule_id = ? AND o.occurrence_date >= ?
+				AND NOT o.is_modified
+				AND (cs.status <> 'active' OR to_char(o.occurrence_date, 'FMDay') <> cs.day_of_week)
+				AND NOT EXISTS (SELECT 1 FROM class_bookings cb WHERE cb.occurrence_id = o.occurrence_id)
+				AND NOT EXISTS (SELECT 1 FROM class_waitlist w
+					WHERE w.occurrence_id = o.occurrence_id AND w.status IN ('waiting', 'offered'))`,
+			scheduleID, date,
+		).Error
+		if err != nil {
+			return fmt.Errorf("failed to remove class occurrences: %w", err)
+		}
+
+		err = tx.Exec(`
+			UPDATE class_occurrences o SET
+				start_time = cs.start_time,
+				end_time = cs.end_time,
+				trainer_id = cs.trainer_id,
+				room_id = cs.room_id,
+				updated_at = NOW()
+			FROM class_schedule cs
+			WHERE o.schedule_id = cs.schedule_id AND o.schedule_id = ? AND o.occurrence_date >= ?
+				AND NOT o.is_modified AND to_char(o.occurrence_date, 'FMDay') = cs.day_of_week`,
+			scheduleID, date,
+		).Error
+		if err != nil {
+			return fmt.Errorf("failed to update class occurrences: %w", err)
+		}
+
+		// A new trainer or room may be off on some of the dates
+		if err := closeCovered(tx, "o.schedule_id = ? AND o.occurrence_date >= ? AND NOT o.is_modified", scheduleID, date); err != nil {
+			return err
+		}
+
+		return moveBookings(tx, "o.schedule_id = ? AND o.occurrence_date >= ? AND NOT o.is_modified", scheduleID, date)
+	})
+}
+
+// Cancel cancels an occurrence. Its bookings are cancelled and its waitlist
+// is closed.
+func (r *OccurrenceRepository) Cancel(ctx context.Context, id int, reason string) (model.Occurrence, error) {
+	var occurrence model.Occurrence
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := cancelOccurrence(tx, id, reason); err != nil {
+			return err
+		}
+		return tx.Where("occurrence_id = ?", id).First(&occurrence).Error
+	})
+	if err != nil {
+		return model.Occurrence{}, err
+	}
+
+	return occurrence, nil
+}
+
+// cancelledBooking is a booking cancelled together with its occurrence
+type cancelledBooking struct {
+	BookingID int
+	MemberID  int
+}
+
+// cancelOccurrence cancels an occurrence within tx, cancelling and refunding
+// its bookings and closing its waitlist. It returns the bookings it
+// cancelled.
+func cancelOccurrence(tx *gorm.DB, id int, reason string) ([]cancelledBooking, error) {
+	if err := lockOccurrence(tx, id); err != nil {
+		return nil, err
+	}
+
+	result := tx.Model(&model.Occurrence{}).
+		Where("occurrence_id = ? AND status = ?", id, model.OccurrenceScheduled).
+		Updates(map[string]interface{}{
+			"status":              model.OccurrenceCancelled,
+			"is_modified":         true,
+			"cancellation_reason": reason,
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to cancel class occurrence: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.New("class occurrence is already cancelled")
+	}
+
+	var bookings []cancelledBooking
+	err := tx.Raw(`
+		UPDATE class_bookings SET attendance_status = 'cancelled', updated_at = NOW()
+		WHERE occurrence_id = ? AND attendance_status = 'booked'
+		RETURNING booking_id, member_id`, id,
+	).Scan(&bookings).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel bookings: %w", err)
+	}
+
+	// Members get back the credits of classes that do not take place
+	for _, booking := range bookings {
+		if err := refundCredits(tx, booking.BookingID); err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Model(&model.WaitlistEntry{}).
+		Where("occurrence_id = ? AND status IN (?)", id, []string{model.WaitlistWaiting, model.WaitlistOffered}).
+		Updates(map[string]interface{}{
+			"status":           model.WaitlistCancelled,
+			"offer_expires_at": nil,
+		}).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to close waitlist: %w", err)
+	}
+
+	return bookings, nil
+}
+
+// Reschedule moves an occurrence to another date, time, trainer or room. The
+// bookings and waitlist entries of the occurrence move with it. An occurrence
+// that would double-book its trainer or room is not moved and a
+// *model.ScheduleConflictError is returned.
+func (r *OccurrenceRepository) Reschedule(ctx context.Context, id int, occurrence model.Occurrence) (model.Occurrence, error) {
+	var updated model.Occurrence
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockOccurrence(tx, id); err != nil {
+			return err
+		}
+		if err := lockTrainerAndRoom(tx, occurrence.TrainerID, occurrence.RoomID); err != nil {
+			return err
+		}
+
+		conflicts, err := findOccurrenceConflicts(tx, id, occurrence)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return &model.ScheduleConflictError{Conflicts: conflicts}
+		}
+
+		result := tx.Model(&updated).
+			Where("occurrence_id = ? AND status = ?", id, model.OccurrenceScheduled).
+			Updates(map[string]interface{}{
+				"occurrence_date": occurrence.OccurrenceDate.Format(dateLayout),
+				"start_time":      occurrence.StartTime,
+				"end_time":        occurrence.EndTime,
+				"trainer_id":      occurrence.TrainerID,
+				"room_id":         occurrence.RoomID,
+				"is_modified":     true,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to reschedule class occurrence: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("cancelled class occurrences cannot be rescheduled")
+		}
+
+		if err := moveBookings(tx, "o.occurrence_id = ?", id); err != nil {
+			return err
+		}
+
+		return tx.Where("occurrence_id = ?", id).First(&updated).Error
+	})
+	if err != nil {
+		return model.Occurrence{}, err
+	}
+
+	return updated, nil
+}
+
+// findOccurrenceConflicts returns what occurrence would clash with if moved,
+// leaving out the occurrence id itself: scheduled occurrences on its date,
+// and active schedules whose occurrence on that date is not generated yet
+// and would not fall in a closure. Both overlap in time and share the trainer
+// or room.
+func findOccurrenceConflicts(tx *gorm.DB, id int, occurrence model.Occurrence) ([]model.ScheduleConflict, error) {
+	var conflicts []model.ScheduleConflict
+
+	date := occurrence.OccurrenceDate.Format(dateLayout)
+	trainer, room := occurrence.TrainerID, occurrence.RoomID
+	err := tx.Raw(`
+		SELECT o.schedule_id, c.class_id, c.class_name, o.trainer_id, o.room_id,
+			to_char(o.occurrence_date, 'FMDay') AS day_of_week, o.start_time, o.end_time,
+			o.trainer_id = ? AS trainer_conflict, o.room_id = ? AS room_conflict
+		FROM class_occurrences o
+		JOIN class_schedule cs ON o.schedule_id = cs.schedule_id
+		JOIN classes c ON cs.class_id = c.class_id
+		WHERE o.status = 'scheduled' AND o.occurrence_date = ? AND o.occurrence_id <> ?
+			AND o.start_time < ?::time AND o.end_time > ?::time
+			AND (o.trainer_id = ? OR o.room_id = ?)
+		UNION ALL
+		SELECT cs.schedule_id, c.class_id, c.class_name, cs.trainer_id, cs.room_id,
+			cs.day_of_week, cs.start_time, cs.end_time,
+			cs.trainer_id = ? AS trainer_conflict, cs.room_id = ? AS room_conflict
+		FROM class_schedule cs
+		JOIN classes c ON cs.class_id = c.class_id
+		CROSS JOIN (SELECT ?::date AS d) moved
+		WHERE cs.status = 'active' AND cs.day_of_week = to_char(moved.d, 'FMDay')
+			AND cs.start_time < ?::time AND cs.end_time > ?::time
+			AND (cs.trainer_id = ? OR cs.room_id = ?)
+			AND NOT EXISTS (SELECT 1 FROM class_occurrences o
+				WHERE o.schedule_id = cs.schedule_id AND o.original_date = moved.d)
+			AND NOT EXISTS (SELECT 1 FROM closures cl WHERE `+closureCovers("moved.d", "cs.trainer_id", "cs.room_id")+`)
+		ORDER BY start_time, schedule_id`,
+		trainer, room, date, id, occurrence.EndTime, occurrence.StartTime, trainer, room,
+		trainer, room, date, occurrence.EndTime, occurrence.StartTime, trainer, room,
+	).Scan(&conflicts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to check occurrence conflicts: %w", err)
+	}
+
+	return conflicts, nil
+}
+
+// moveBookings sets the booking date of open bookings and waitlist entries
+// to the current start of their occurrence, for the occurrences o matched by
+// where
+func moveBookings(tx *gorm.DB, where string, args ...interface{}) error {
+	err := tx.Exec(`
+		UPDATE class_bookings cb SET booking_date = (o.occurrence_date + o.start_time) AT TIME ZONE ?, updated_at = NOW()
+		FROM class_occurrences o
+		WHERE cb.occurrence_id = o.occurrence_id AND cb.attendance_status = 'booked' AND `+where,
+		append([]interface{}{timezone()}, args...)...,
+	).Error
+	if err != nil {
+		return fmt.Errorf("failed to move bookings: %w", err)
+	}
+
+	err = tx.Exec(`
+		UPDATE class_waitlist w SET booking_date = (o.occurrence_date + o.start_time) AT TIME ZONE ?, updated_at = NOW()
+		FROM class_occurrences o
+		WHERE w.occurrence_id = o.occurrence_id AND w.status IN ('waiting', 'offered') AND `+where,
+		append([]interface{}{timezone()}, args...)...,
+	).Error
+	if err != nil {
+		return fmt.Errorf("failed to move waitlist entries: %w", err)
+	}
+
+	return nil
+}
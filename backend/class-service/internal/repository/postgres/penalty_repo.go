@@ -0,0 +1,320 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PenaltyRepository implements model.PenaltyRepository interface
+type PenaltyRepository struct {
+	db *gorm.DB
+}
+
+// NewPenaltyRepository creates a new PenaltyRepository
+func NewPenaltyRepository(db *gorm.DB) model.PenaltyRepository {
+	return &PenaltyRepository{db: db}
+}
+
+// GetPolicy returns the cancellation policy of a class
+func (r *PenaltyRepository) GetPolicy(ctx context.Context, classID int) (model.CancellationPolicy, error) {
+	var policy model.CancellationPolicy
+
+	err := r.db.WithContext(ctx).Where("class_id = ?", classID).First(&policy).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.DefaultPolicy(classID), nil
+		}
+		return model.CancellationPolicy{}, fmt.Errorf("failed to fetch cancellation policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// SavePolicy creates or replaces the cancellation policy of a class
+func (r *PenaltyRepository) SavePolicy(ctx context.Context, policy model.CancellationPolicy) (model.CancellationPolicy, error) {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "class_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"cancellation_cutoff_minutes", "late_cancel_fee", "late_cancel_credits",
+			"no_show_fee", "no_show_credits", "no_show_limit", "no_show_period_days",
+			"suspension_days", "credit_cost", "updated_at",
+		}),
+	}).Create(&policy).Error
+	if err != nil {
+		return model.CancellationPolicy{}, fmt.Errorf("failed to save cancellation policy: %w", err)
+	}
+
+	return r.GetPolicy(ctx, policy.ClassID)
+}
+
+// Record saves a penalty, or returns the one the booking already has of the
+// same type
+func (r *PenaltyRepository) Record(ctx context.Context, penalty model.Penalty) (model.Penalty, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&penalty)
+	if result.Error != nil {
+		return model.Penalty{}, fmt.Errorf("failed to record penalty: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		var existing model.Penalty
+		err := r.db.WithContext(ctx).
+			Where("booking_id = ? AND penalty_type = ?", penalty.BookingID, penalty.PenaltyType).
+			First(&existing).Error
+		if err != nil {
+			return model.Penalty{}, fmt.Errorf("failed to fetch penalty: %w", err)
+		}
+		return existing, nil
+	}
+
+	return penalty, nil
+}
+
+// GetPenaltiesPaginated returns paginated penalties with total count, newest
+// first
+func (r *PenaltyRepository) GetPenaltiesPaginated(ctx context.Context, filter model.PenaltyFilter, offset, limit int) ([]model.Penalty, int, error) {
+	var penalties []model.Penalty
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&model.Penalty{})
+	if filter.MemberID != 0 {
+		query = query.Where("member_id = ?", filter.MemberID)
+	}
+	if filter.BookingID != 0 {
+		query = query.Where("booking_id = ?", filter.BookingID)
+	}
+	if filter.ClassID != 0 {
+		query = query.Where("class_id = ?", filter.ClassID)
+	}
+	if filter.PenaltyType != "" {
+		query = query.Where("penalty_type = ?", filter.PenaltyType)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count penalties: %w", err)
+	}
+
+	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&penalties).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch penalties: %w", err)
+	}
+
+	return penalties, int(total), nil
+}
+
+// GetPenaltyByID returns a penalty by its ID
+func (r *PenaltyRepository) GetPenaltyByID(ctx context.Context, id int) (model.Penalty, error) {
+	var penalty model.Penalty
+
+	err := r.db.WithContext(ctx).Where("penalty_id = ?", id).First(&penalty).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Penalty{}, errors.New("penalty not found")
+		}
+		return model.Penalty{}, fmt.Errorf("failed to fetch penalty: %w", err)
+	}
+
+	return penalty, nil
+}
+
+// Waive waives an active penalty whose fee has not been posted. A pending
+// fee is dropped with it.
+func (r *PenaltyRepository) Waive(ctx context.Context, id int) (model.Penalty, error) {
+	existing, err := r.GetPenaltyByID(ctx, id)
+	if err != nil {
+		return model.Penalty{}, err
+	}
+
+	result := r.db.WithContext(ctx).Model(&model.Penalty{}).
+		Where("penalty_id = ? AND status = ? AND charge_status <> ?", id, model.PenaltyActive, model.ChargePosted).
+		Updates(map[string]interface{}{
+			"status":        model.PenaltyWaived,
+			"charge_status": model.ChargeNone,
+		})
+	if result.Error != nil {
+		return model.Penalty{}, fmt.Errorf("failed to waive penalty: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if existing.Status == model.PenaltyWaived {
+			return model.Penalty{}, errors.New("penalty is already waived")
+		}
+		return model.Penalty{}, errors.New("penalty fee was already charged")
+	}
+
+	return r.GetPenaltyByID(ctx, id)
+}
+
+// WaiveReversed waives an active penalty whose posted fee was reversed in the
+// payment service
+func (r *PenaltyRepository) WaiveReversed(ctx context.Context, id int) (model.Penalty, error) {
+	result := r.db.WithContext(ctx).Model(&model.Penalty{}).
+		Where("penalty_id = ? AND status = ? AND charge_status = ?", id, model.PenaltyActive, model.ChargePosted).
+		Updates(map[string]interface{}{
+			"status":        model.PenaltyWaived,
+			"charge_status": model.ChargeReversed,
+		})
+	if result.Error != nil {
+		return model.Penalty{}, fmt.Errorf("failed to waive penalty: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.Penalty{}, errors.New("penalty is already waived")
+	}
+
+	return r.GetPenaltyByID(ctx, id)
+}
+
+// GetPendingCharges returns active penalties recorded before the given time
+// whose fee still has to be posted
+func (r *PenaltyRepository) GetPendingCharges(ctx context.Context, before time.Time, limit int) ([]model.Penalty, error) {
+	var penalties []model.Penalty
+
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND charge_status = ? AND created_at < ?", model.PenaltyActive, model.ChargePending, before).
+		Order("penalty_id").Limit(limit).
+		Find(&penalties).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pending penalty charges: %w", err)
+	}
+
+	return penalties, nil
+}
+
+// MarkCharged records the payment a penalty fee was posted as. It fails if
+// the penalty was waived, or its fee recorded, while the fee was posted.
+func (r *PenaltyRepository) MarkCharged(ctx context.Context, id int, paymentID int) error {
+	result := r.db.WithContext(ctx).Model(&model.Penalty{}).
+		Where("penalty_id = ? AND status = ? AND charge_status = ?", id, model.PenaltyActive, model.ChargePending).
+		Updates(map[string]interface{}{
+			"charge_status": model.ChargePosted,
+			"payment_id":    paymentID,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to mark penalty as charged: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("penalty charge is no longer pending")
+	}
+	return nil
+}
+
+// CountNoShows counts the no-shows of a member in occurrences that started
+// after since. No-shows whose penalty was waived, and no-shows before the
+// start of the member's latest suspension, are not counted.
+func (r *PenaltyRepository) CountNoShows(ctx context.Context, memberID int, since time.Time) (int, error) {
+	var count int
+
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT COUNT(*)
+		FROM class_bookings b
+		JOIN class_occurrences o ON b.occurrence_id = o.occurrence_id
+		WHERE b.member_id = ? AND b.attendance_status = 'no_show'
+			AND (o.occurrence_date + o.start_time) AT TIME ZONE ? >= ?
+			AND (o.occurrence_date + o.start_time) AT TIME ZONE ? >= COALESCE(
+				(SELECT MAX(starts_at) FROM member_suspensions WHERE member_id = b.member_id), '-infinity')
+			AND NOT EXISTS (
+				SELECT 1 FROM booking_penalties p
+				WHERE p.booking_id = b.booking_id AND p.penalty_type = 'no_show' AND p.status = 'waived')`,
+		memberID, timezone(), since, timezone(),
+	).Scan(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count no-shows: %w", err)
+	}
+
+	return count, nil
+}
+
+// Suspend saves a suspension
+func (r *PenaltyRepository) Suspend(ctx context.Context, suspension model.Suspension) (model.Suspension, error) {
+	if err := r.db.WithContext(ctx).Create(&suspension).Error; err != nil {
+		return model.Suspension{}, fmt.Errorf("failed to suspend member: %w", err)
+	}
+	return suspension, nil
+}
+
+// GetActiveSuspension returns the suspension a member is under, the one
+// ending last if there are several
+func (r *PenaltyRepository) GetActiveSuspension(ctx context.Context, memberID int) (model.Suspension, error) {
+	var suspension model.Suspension
+
+	err := r.db.WithContext(ctx).
+		Where("member_id = ? AND lifted_at IS NULL AND starts_at <= NOW() AND ends_at > NOW()", memberID).
+		Order("ends_at DESC").
+		First(&suspension).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Suspension{}, errors.New("member is not suspended")
+		}
+		return model.Suspension{}, fmt.Errorf("failed to fetch suspension: %w", err)
+	}
+
+	return suspension, nil
+}
+
+// GetSuspensionsPaginated returns paginated suspensions with total count,
+// newest first
+func (r *PenaltyRepository) GetSuspensionsPaginated(ctx context.Context, filter model.SuspensionFilter, offset, limit int) ([]model.Suspension, int, error) {
+	var suspensions []model.Suspension
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&model.Suspension{})
+	if filter.MemberID != 0 {
+		query = query.Where("member_id = ?", filter.MemberID)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("lifted_at IS NULL AND starts_at <= NOW() AND ends_at > NOW()")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count suspensions: %w", err)
+	}
+
+	err := query.Order("starts_at DESC").Limit(limit).Offset(offset).Find(&suspensions).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch suspensions: %w", err)
+	}
+
+	return suspensions, int(total), nil
+}
+
+// Lift ends a suspension that is still in force
+func (r *PenaltyRepository) Lift(ctx context.Context, id int) (model.Suspension, error) {
+	var suspension model.Suspension
+
+	err := r.db.WithContext(ctx).Where("suspension_id = ?", id).First(&suspension).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Suspension{}, errors.New("suspension not found")
+		}
+		return model.Suspension{}, fmt.Errorf("failed to fetch suspension: %w", err)
+	}
+	audit.Before(ctx, suspension)
+
+	result := r.db.WithContext(ctx).Model(&suspension).
+		Where("lifted_at IS NULL AND ends_at > NOW()").
+		Update("lifted_at", time.Now())
+	if result.Error != nil {
+		return model.Suspension{}, fmt.Errorf("failed to lift suspension: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.Suspension{}, errors.New("suspension is not in force")
+	}
+
+	// Fetch the lifted suspension
+	err = r.db.WithContext(ctx).Where("suspension_id = ?", id).First(&suspension).Error
+	if err != nil {
+		return model.Suspension{}, fmt.Errorf("failed to fetch suspension: %w", err)
+	}
+
+	return suspension, nil
+}
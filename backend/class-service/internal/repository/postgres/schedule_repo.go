@@ -111,23 +111,43 @@ func (r *ScheduleRepository) GetByClassID(ctx context.Context, classID int) ([]m
 	return schedules, nil
 }
 
-// Create adds a new schedule
+// Create adds a new schedule. An active schedule that would double-book its
+// trainer or room is not saved and a *model.ScheduleConflictError is
+// returned.
 func (r *ScheduleRepository) Create(ctx context.Context, schedule model.Schedule) (model.Schedule, error) {
-	err := r.db.WithContext(ctx).Create(&schedule).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkScheduleConflicts(tx, schedule); err != nil {
+			return err
+		}
+		if err := tx.Create(&schedule).Error; err != nil {
+			return fmt.Errorf("failed to create schedule: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return model.Schedule{}, fmt.Errorf("failed to create schedule: %w", err)
+		return model.Schedule{}, err
 	}
 
 	return schedule, nil
 }
 
-// Update modifies an existing schedule
+// Update modifies an existing schedule. An active schedule that would
+// double-book its trainer or room is not saved and a
+// *model.ScheduleConflictError is returned.
 func (r *ScheduleRepository) Update(ctx context.Context, id int, schedule model.Schedule) (model.Schedule, error) {
 	schedule.ScheduleID = id
 
-	err := r.db.WithContext(ctx).Save(&schedule).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkScheduleConflicts(tx, schedule); err != nil {
+			return err
+		}
+		if err := tx.Save(&schedule).Error; err != nil {
+			return fmt.Errorf("failed to update schedule: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return model.Schedule{}, fmt.Errorf("failed to update schedule: %w", err)
+		return model.Schedule{}, err
 	}
 
 	return schedule, nil
@@ -158,3 +178,80 @@ func (r *ScheduleRepository) HasBookings(ctx context.Context, id int) (bool, err
 
 	return count > 0, nil
 }
+
+// FindConflicts returns the active schedules that overlap with check on the
+// same day and share its trainer or room. Schedules that end exactly when the
+// other starts do not overlap.
+func (r *ScheduleRepository) FindConflicts(ctx context.Context, check model.ScheduleConflictCheck) ([]model.ScheduleConflict, error) {
+	return findScheduleConflicts(r.db.WithContext(ctx), check)
+}
+
+// Advisory lock namespaces of the trainer and room locks. The two-key locks
+// do not share a key space with single-key locks such as noShowLockKey.
+const (
+	trainerLockSpace = 7350601
+	roomLockSpace    = 7350602
+)
+
+// lockTrainerAndRoom takes the locks of a trainer and a room until tx ends,
+// so that schedules and occurrences booking them are checked for conflicts
+// and saved one at a time. The trainer is always locked first to keep
+// callers from deadlocking.
+func lockTrainerAndRoom(tx *gorm.DB, trainerID, roomID int) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", trainerLockSpace, trainerID).Error; err != nil {
+		return fmt.Errorf("failed to lock trainer: %w", err)
+	}
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", roomLockSpace, roomID).Error; err != nil {
+		return fmt.Errorf("failed to lock room: %w", err)
+	}
+	return nil
+}
+
+// checkScheduleConflicts locks the trainer and room of an active schedule
+// within tx and returns a *model.ScheduleConflictError if the schedule would
+// double-book either of them
+func checkScheduleConflicts(tx *gorm.DB, schedule model.Schedule) error {
+	if schedule.Status != "active" {
+		return nil
+	}
+	if err := lockTrainerAndRoom(tx, schedule.TrainerID, schedule.RoomID); err != nil {
+		return err
+	}
+
+	conflicts, err := findScheduleConflicts(tx, model.ScheduleConflictCheck{
+		ScheduleID: schedule.ScheduleID,
+		TrainerID:  schedule.TrainerID,
+		RoomID:     schedule.RoomID,
+		DayOfWeek:  schedule.DayOfWeek,
+		StartTime:  schedule.StartTime,
+		EndTime:    schedule.EndTime,
+	})
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &model.ScheduleConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// findScheduleConflicts returns the active schedules that clash with check
+func findScheduleConflicts(db *gorm.DB, check model.ScheduleConflictCheck) ([]model.ScheduleConflict, error) {
+	var conflicts []model.ScheduleConflict
+
+	err := db.Table("class_schedule cs").
+		Select(`cs.schedule_id, cs.class_id, c.class_name, cs.trainer_id, cs.room_id,
+			cs.day_of_week, cs.start_time, cs.end_time,
+			cs.trainer_id = ? AS trainer_conflict, cs.room_id = ? AS room_conflict`, check.TrainerID, check.RoomID).
+		Joins("JOIN classes c ON cs.class_id = c.class_id").
+		Where("cs.status = 'active' AND cs.day_of_week = ? AND cs.schedule_id <> ?", check.DayOfWeek, check.ScheduleID).
+		Where("cs.start_time < ?::time AND cs.end_time > ?::time", check.EndTime, check.StartTime).
+		Where("(cs.trainer_id = ? OR cs.room_id = ?)", check.TrainerID, check.RoomID).
+		Order("cs.start_time, cs.schedule_id").
+		Find(&conflicts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to check schedule conflicts: %w", err)
+	}
+
+	return conflicts, nil
+}
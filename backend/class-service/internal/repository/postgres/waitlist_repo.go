@@ -0,0 +1,377 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// waitlistColumns selects an entry with its current place in line and the
+// class it is waiting for
+const waitlistColumns = `w.*,
+	CASE WHEN w.status = 'waiting' THEN (
+		SELECT COUNT(*) FROM class_waitlist w2
+		WHERE w2.occurrence_id = w.occurrence_id AND w2.status = 'waiting' AND w2.position <= w.position
+	) ELSE 0 END AS queue_position,
+	c.class_name, to_char(o.occurrence_date, 'FMDay') AS day_of_week, o.start_time`
+
+// WaitlistRepository implements model.WaitlistRepository interface
+type WaitlistRepository struct {
+	db *gorm.DB
+}
+
+// NewWaitlistRepository creates a new WaitlistRepository
+func NewWaitlistRepository(db *gorm.DB) model.WaitlistRepository {
+	return &WaitlistRepository{db: db}
+}
+
+// GetAllPaginated returns paginated waitlist entries with total count,
+// newest first
+func (r *WaitlistRepository) GetAllPaginated(ctx context.Context, filter model.WaitlistFilter, offset, limit int) ([]model.WaitlistResponse, int, error) {
+	var entries []model.WaitlistResponse
+	var total int64
+
+	err := r.filtered(r.db.WithContext(ctx).Table("class_waitlist w"), filter).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count waitlist entries: %w", err)
+	}
+
+	query := r.db.WithContext(ctx).Table("class_waitlist w").
+		Select(waitlistColumns).
+		Joins("JOIN class_occurrences o ON w.occurrence_id = o.occurrence_id").
+		Joins("JOIN class_schedule cs ON o.schedule_id = cs.schedule_id").
+		Joins("JOIN classes c ON cs.class_id = c.class_id")
+
+	err = r.filtered(query, filter).
+		Order("w.created_at DESC").
+		Limit(limit).Offset(offset).Find(&entries).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch waitlist entries: %w", err)
+	}
+
+	return entries, int(total), nil
+}
+
+// filtered applies the filter to a query on class_waitlist aliased as w
+func (r *WaitlistRepository) filtered(query *gorm.DB, filter model.WaitlistFilter) *gorm.DB {
+	if filter.ScheduleID != 0 {
+		query = query.Where("w.schedule_id = ?", filter.ScheduleID)
+	}
+	if filter.OccurrenceID != 0 {
+		query = query.Where("w.occurrence_id = ?", filter.OccurrenceID)
+	}
+	if filter.MemberID != 0 {
+		query = query.Where("w.member_id = ?", filter.MemberID)
+	}
+	if filter.Status != "" {
+		query = query.Where("w.status = ?", filter.Status)
+	}
+	return query
+}
+
+// GetByID returns a waitlist entry by its ID
+func (r *WaitlistRepository) GetByID(ctx context.Context, id int) (model.WaitlistResponse, error) {
+	var entry model.WaitlistResponse
+
+	err := r.db.WithContext(ctx).Table("class_waitlist w").
+		Select(waitlistColumns).
+		Joins("JOIN class_occurrences o ON w.occurrence_id = o.occurrence_id").
+		Joins("JOIN class_schedule cs ON o.schedule_id = cs.schedule_id").
+		Joins("JOIN classes c ON cs.class_id = c.class_id").
+		Where("w.waitlist_id = ?", id).
+		First(&entry).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.WaitlistResponse{}, errors.New("waitlist entry not found")
+		}
+		return model.WaitlistResponse{}, fmt.Errorf("failed to fetch waitlist entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// Join adds a member to the end of the waitlist of an occurrence
+func (r *WaitlistRepository) Join(ctx context.Context, entry model.WaitlistEntry) (model.WaitlistEntry, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locking the occurrence serializes position numbering
+		if err := lockOccurrence(tx, entry.OccurrenceID); err != nil {
+			return err
+		}
+
+		var last int
+		err := tx.Table("class_waitlist").
+			Select("COALESCE(MAX(position), 0)").
+			Where("occurrence_id = ?", entry.OccurrenceID).
+			Scan(&last).Error
+		if err != nil {
+			return fmt.Errorf("failed to get waitlist position: %w", err)
+		}
+
+		entry.Position = last + 1
+		entry.Status = model.WaitlistWaiting
+		return tx.Create(&entry).Error
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "idx_waitlist_active_member") {
+			return model.WaitlistEntry{}, errors.New("member is already on the waitlist for this class")
+		}
+		if err.Error() == "class occurrence not found" {
+			return model.WaitlistEntry{}, err
+		}
+		return model.WaitlistEntry{}, fmt.Errorf("failed to join waitlist: %w", err)
+	}
+
+	return entry, nil
+}
+
+// Leave withdraws a waiting or offered entry from the waitlist
+func (r *WaitlistRepository) Leave(ctx context.Context, id int) (model.WaitlistEntry, error) {
+	var entry model.WaitlistEntry
+
+	result := r.db.WithContext(ctx).Model(&entry).
+		Where("waitlist_id = ? AND status IN (?)", id, []string{model.WaitlistWaiting, model.WaitlistOffered}).
+		Updates(map[string]interface{}{
+			"status":           model.WaitlistLeft,
+			"offer_expires_at": nil,
+		})
+	if result.Error != nil {
+		return model.WaitlistEntry{}, fmt.Errorf("failed to leave waitlist: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.WaitlistEntry{}, errors.New("only waiting or offered entries can leave the waitlist")
+	}
+
+	// Fetch the updated entry
+	err := r.db.WithContext(ctx).Where("waitlist_id = ?", id).First(&entry).Error
+	if err != nil {
+		return model.WaitlistEntry{}, fmt.Errorf("failed to fetch waitlist entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// PromoteNext fills free seats of an occurrence from the front of its
+// waitlist. Entries of members who cannot pay the credits of the class are
+// expired and returned with the promoted ones.
+func (r *WaitlistRepository) PromoteNext(ctx context.Context, occurrenceID int, offerWindow time.Duration) ([]model.WaitlistEntry, error) {
+	var promoted []model.WaitlistEntry
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockOccurrence(tx, occurrenceID); err != nil {
+			return err
+		}
+
+		for {
+			held, capacity, err := heldSeats(tx, occurrenceID)
+			if err != nil {
+				return err
+			}
+			if held >= capacity {
+				return nil
+			}
+
+			var entry model.WaitlistEntry
+			err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("occurrence_id = ? AND status = ?", occurrenceID, model.WaitlistWaiting).
+				Order("position").
+				First(&entry).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to fetch next waitlist entry: %w", err)
+			}
+
+			if offerWindow > 0 {
+				expiresAt := time.Now().Add(offerWindow)
+				entry.Status = model.WaitlistOffered
+				entry.OfferExpiresAt = &expiresAt
+			} else {
+				// A member without the credits for the class loses their
+				// place to the next in line
+				if err := tx.SavePoint("promote").Error; err != nil {
+					return fmt.Errorf("failed to promote waitlist entry: %w", err)
+				}
+				booking, err := bookSeat(tx, entry)
+				switch {
+				case err != nil && err.Error() == "member has not enough class credits":
+					if err := tx.RollbackTo("promote").Error; err != nil {
+						return fmt.Errorf("failed to promote waitlist entry: %w", err)
+					}
+					entry.Status = model.WaitlistExpired
+				case err != nil:
+					return err
+				default:
+					entry.Status = model.WaitlistPromoted
+					entry.BookingID = &booking.BookingID
+				}
+			}
+
+			err = tx.Model(&entry).Updates(map[string]interface{}{
+				"status":           entry.Status,
+				"offer_expires_at": entry.OfferExpiresAt,
+				"booking_id":       entry.BookingID,
+			}).Error
+			if err != nil {
+				return fmt.Errorf("failed to promote waitlist entry: %w", err)
+			}
+			promoted = append(promoted, entry)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return promoted, nil
+}
+
+// ConfirmOffer books the seat offered to a waitlist entry
+func (r *WaitlistRepository) ConfirmOffer(ctx context.Context, id int) (model.Booking, error) {
+	var booking model.Booking
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var entry model.WaitlistEntry
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("waitlist_id = ?", id).
+			First(&entry).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("waitlist entry not found")
+			}
+			return fmt.Errorf("failed to fetch waitlist entry: %w", err)
+		}
+
+		if entry.Status != model.WaitlistOffered || entry.OfferExpiresAt == nil || !entry.OfferExpiresAt.After(time.Now()) {
+			return errors.New("no open seat offer for this waitlist entry")
+		}
+
+		booking, err = bookSeat(tx, entry)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&entry).Updates(map[string]interface{}{
+			"status":     model.WaitlistPromoted,
+			"booking_id": booking.BookingID,
+		}).Error
+	})
+	if err != nil {
+		return model.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// ExpireOffers marks unconfirmed offers past their expiry as expired
+func (r *WaitlistRepository) ExpireOffers(ctx context.Context) ([]int, error) {
+	var occurrenceIDs []int
+
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE class_waitlist SET status = ?, updated_at = NOW()
+		WHERE status = ? AND offer_expires_at <= NOW()
+		RETURNING occurrence_id`,
+		model.WaitlistExpired, model.WaitlistOffered,
+	).Scan(&occurrenceIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire waitlist offers: %w", err)
+	}
+
+	return uniqueInts(occurrenceIDs), nil
+}
+
+// lockOccurrence locks an occurrence row for the rest of the transaction, so
+// seat counts of the occurrence do not change underneath it
+func lockOccurrence(tx *gorm.DB, occurrenceID int) error {
+	var id int
+	err := tx.Raw("SELECT occurrence_id FROM class_occurrences WHERE occurrence_id = ? FOR UPDATE", occurrenceID).
+		Scan(&id).Error
+	if err != nil {
+		return fmt.Errorf("failed to lock class occurrence: %w", err)
+	}
+	if id == 0 {
+		return errors.New("class occurrence not found")
+	}
+	return nil
+}
+
+// heldSeats returns the number of seats taken by bookings and open waitlist
+// offers of an occurrence, and its capacity. Cancelled and started
+// occurrences have no capacity left to hand out.
+func heldSeats(tx *gorm.DB, occurrenceID int) (int, int, error) {
+	var seats struct {
+		Held     int
+		Capacity int
+	}
+
+	err := tx.Raw(`
+		SELECT
+			(SELECT COUNT(*) FROM class_bookings
+			 WHERE occurrence_id = o.occurrence_id AND attendance_status IN ('booked', 'attended')) +
+			(SELECT COUNT(*) FROM class_waitlist
+			 WHERE occurrence_id = o.occurrence_id AND status = 'offered' AND offer_expires_at > NOW()) AS held,
+			CASE WHEN o.status = 'scheduled' AND (o.occurrence_date + o.start_time) AT TIME ZONE ? > NOW()
+				THEN c.capacity ELSE 0 END AS capacity
+		FROM class_occurrences o
+		JOIN class_schedule cs ON o.schedule_id = cs.schedule_id
+		JOIN classes c ON cs.class_id = c.class_id
+		WHERE o.occurrence_id = ?`, timezone(), occurrenceID,
+	).Scan(&seats).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count held seats: %w", err)
+	}
+
+	return seats.Held, seats.Capacity, nil
+}
+
+// bookSeat creates the booking for a promoted waitlist entry at the current
+// time of its occurrence and charges its credits. A booking the member
+// cancelled earlier for the same occurrence is booked again, since a member
+// has at most one booking per occurrence.
+func bookSeat(tx *gorm.DB, entry model.WaitlistEntry) (model.Booking, error) {
+	var booking model.Booking
+
+	err := tx.Raw(`
+		INSERT INTO class_bookings (schedule_id, occurrence_id, member_id, booking_date, attendance_status)
+		SELECT o.schedule_id, o.occurrence_id, ?, (o.occurrence_date + o.start_time) AT TIME ZONE ?, 'booked'
+		FROM class_occurrences o
+		WHERE o.occurrence_id = ?
+		ON CONFLICT ON CONSTRAINT unique_booking DO UPDATE SET
+			booking_date = EXCLUDED.booking_date,
+			attendance_status = 'booked',
+			feedback_rating = NULL,
+			feedback_comment = NULL,
+			updated_at = NOW()
+		RETURNING *`,
+		entry.MemberID, timezone(), entry.OccurrenceID,
+	).Scan(&booking).Error
+	if err != nil {
+		return model.Booking{}, fmt.Errorf("failed to create booking: %w", err)
+	}
+
+	if err := chargeCredits(tx, booking); err != nil {
+		return model.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// uniqueInts returns the distinct values of ids in their original order
+func uniqueInts(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/repository/postgres"
 	"gorm.io/gorm"
@@ -8,17 +9,33 @@ import (
 
 // Repository is a factory for all repositories
 type Repository struct {
-	ClassRepo    model.ClassRepository
-	ScheduleRepo model.ScheduleRepository
-	BookingRepo  model.BookingRepository
+	ClassRepo      model.ClassRepository
+	ScheduleRepo   model.ScheduleRepository
+	OccurrenceRepo model.OccurrenceRepository
+	BookingRepo    model.BookingRepository
+	WaitlistRepo   model.WaitlistRepository
+	PenaltyRepo    model.PenaltyRepository
+	CreditRepo     model.CreditRepository
+	CalendarRepo   model.CalendarRepository
+	FeedbackRepo   model.FeedbackRepository
+	ClosureRepo    model.ClosureRepository
+	AuditRepo      audit.Store
 }
 
 // NewRepositories creates a new repository factory with all repositories
 func NewRepositories(db *gorm.DB) *Repository {
 	return &Repository{
-		ClassRepo:    postgres.NewClassRepository(db),
-		ScheduleRepo: postgres.NewScheduleRepository(db),
-		BookingRepo:  postgres.NewBookingRepository(db),
+		ClassRepo:      postgres.NewClassRepository(db),
+		ScheduleRepo:   postgres.NewScheduleRepository(db),
+		OccurrenceRepo: postgres.NewOccurrenceRepository(db),
+		BookingRepo:    postgres.NewBookingRepository(db),
+		WaitlistRepo:   postgres.NewWaitlistRepository(db),
+		PenaltyRepo:    postgres.NewPenaltyRepository(db),
+		CreditRepo:     postgres.NewCreditRepository(db),
+		CalendarRepo:   postgres.NewCalendarRepository(db),
+		FeedbackRepo:   postgres.NewFeedbackRepository(db),
+		ClosureRepo:    postgres.NewClosureRepository(db),
+		AuditRepo:      postgres.NewAuditRepository(db),
 	}
 }
 
@@ -32,7 +49,48 @@ func NewScheduleRepository(db *gorm.DB) model.ScheduleRepository {
 	return postgres.NewScheduleRepository(db)
 }
 
+// NewOccurrenceRepository creates a new class occurrence repository
+func NewOccurrenceRepository(db *gorm.DB) model.OccurrenceRepository {
+	return postgres.NewOccurrenceRepository(db)
+}
+
 // NewBookingRepository creates a new booking repository
 func NewBookingRepository(db *gorm.DB) model.BookingRepository {
 	return postgres.NewBookingRepository(db)
 }
+
+// NewWaitlistRepository creates a new waitlist repository
+func NewWaitlistRepository(db *gorm.DB) model.WaitlistRepository {
+	return postgres.NewWaitlistRepository(db)
+}
+
+// NewPenaltyRepository creates a new cancellation policy and penalty
+// repository
+func NewPenaltyRepository(db *gorm.DB) model.PenaltyRepository {
+	return postgres.NewPenaltyRepository(db)
+}
+
+// NewCreditRepository creates a new class credit repository
+func NewCreditRepository(db *gorm.DB) model.CreditRepository {
+	return postgres.NewCreditRepository(db)
+}
+
+// NewCalendarRepository creates a new calendar feed repository
+func NewCalendarRepository(db *gorm.DB) model.CalendarRepository {
+	return postgres.NewCalendarRepository(db)
+}
+
+// NewFeedbackRepository creates a new booking feedback repository
+func NewFeedbackRepository(db *gorm.DB) model.FeedbackRepository {
+	return postgres.NewFeedbackRepository(db)
+}
+
+// NewClosureRepository creates a new closure repository
+func NewClosureRepository(db *gorm.DB) model.ClosureRepository {
+	return postgres.NewClosureRepository(db)
+}
+
+// NewAuditRepository creates a new audit log repository
+func NewAuditRepository(db *gorm.DB) audit.Store {
+	return postgres.NewAuditRepository(db)
+}
@@ -1,12 +1,13 @@
 package server
 
 import (
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/handler"
 	"github.com/gin-gonic/gin"
 )
 
 // setupRoutes configures all API routes for the application
-func setupRoutes(router *gin.Engine, handler *handler.Handler) {
+func setupRoutes(router *gin.Engine, handler *handler.Handler, auditStore audit.Store) {
 	// Health check endpoint
 	router.GET("/health", handler.HealthCheck)
 
@@ -21,6 +22,9 @@ func setupRoutes(router *gin.Engine, handler *handler.Handler) {
 			classes.POST("", handler.ClassHandler.CreateClass)
 			classes.PUT("/:id", handler.ClassHandler.UpdateClass)
 			classes.DELETE("/:id", handler.ClassHandler.DeleteClass)
+			classes.GET("/:id/policy", handler.ClassHandler.GetPolicy)
+			classes.PUT("/:id/policy", handler.ClassHandler.UpdatePolicy)
+			classes.GET("/:id/feedback", handler.BookingHandler.GetClassFeedback)
 		}
 
 		// Schedule routes
@@ -29,9 +33,25 @@ func setupRoutes(router *gin.Engine, handler *handler.Handler) {
 			schedules.GET("", handler.ScheduleHandler.GetSchedules)
 			schedules.GET("/:id", handler.ScheduleHandler.GetScheduleByID)
 			schedules.GET("/class/:class_id", handler.ScheduleHandler.GetSchedulesByClassID)
+			schedules.GET("/conflicts", handler.ScheduleHandler.CheckConflicts)
 			schedules.POST("", handler.ScheduleHandler.CreateSchedule)
 			schedules.PUT("/:id", handler.ScheduleHandler.UpdateSchedule)
 			schedules.DELETE("/:id", handler.ScheduleHandler.DeleteSchedule)
+			schedules.GET("/:id/feedback", handler.BookingHandler.GetScheduleFeedback)
+
+			// iCalendar timetables of the active schedules
+			schedules.GET("/calendar", handler.CalendarHandler.GetScheduleCalendar)
+			schedules.GET("/calendar/trainers/:trainer_id", handler.CalendarHandler.GetTrainerCalendar)
+			schedules.GET("/calendar/rooms/:room_id", handler.CalendarHandler.GetRoomCalendar)
+		}
+
+		// Dated occurrences of the schedules
+		occurrences := api.Group("/occurrences")
+		{
+			occurrences.GET("", handler.OccurrenceHandler.GetOccurrences)
+			occurrences.GET("/:id", handler.OccurrenceHandler.GetOccurrenceByID)
+			occurrences.PUT("/:id", handler.OccurrenceHandler.RescheduleOccurrence)
+			occurrences.POST("/:id/cancel", handler.OccurrenceHandler.CancelOccurrence)
 		}
 
 		// Booking routes
@@ -44,6 +64,59 @@ func setupRoutes(router *gin.Engine, handler *handler.Handler) {
 			bookings.PUT("/:id/status", handler.BookingHandler.UpdateBooking)
 			bookings.POST("/:id/feedback", handler.BookingHandler.AddFeedback)
 			bookings.DELETE("/:id", handler.BookingHandler.DeleteBooking)
+
+			// Waitlists of fully booked classes
+			bookings.GET("/waitlist", handler.BookingHandler.GetWaitlist)
+			bookings.POST("/waitlist", handler.BookingHandler.JoinWaitlist)
+			bookings.GET("/waitlist/:id", handler.BookingHandler.GetWaitlistEntry)
+			bookings.POST("/waitlist/:id/confirm", handler.BookingHandler.ConfirmWaitlistOffer)
+			bookings.DELETE("/waitlist/:id", handler.BookingHandler.LeaveWaitlist)
+
+			// Late-cancel and no-show penalties, and the suspensions they lead to
+			bookings.GET("/penalties", handler.BookingHandler.GetPenalties)
+			bookings.GET("/penalties/:id", handler.BookingHandler.GetPenalty)
+			bookings.POST("/penalties/:id/waive", handler.BookingHandler.WaivePenalty)
+			bookings.GET("/suspensions", handler.BookingHandler.GetSuspensions)
+			bookings.POST("/suspensions/:id/lift", handler.BookingHandler.LiftSuspension)
+
+			// Class credits members book with, from memberships and packs
+			bookings.GET("/credits/members/:member_id", handler.BookingHandler.GetCreditBalance)
+			bookings.GET("/credits/members/:member_id/history", handler.BookingHandler.GetCreditHistory)
+			bookings.POST("/credits/grants", handler.BookingHandler.GrantCredits)
+			bookings.GET("/credits/plans", handler.BookingHandler.GetCreditPlans)
+			bookings.PUT("/credits/plans/:membership_id", handler.BookingHandler.SaveCreditPlan)
+			bookings.DELETE("/credits/plans/:membership_id", handler.BookingHandler.DeleteCreditPlan)
+
+			// Summaries of the feedback members gave, and the trainer ratings
+			// synced from it to the staff service
+			bookings.GET("/feedback", handler.BookingHandler.GetFeedbackSummary)
+			bookings.GET("/feedback/trainers/:trainer_id", handler.BookingHandler.GetTrainerFeedback)
+			bookings.POST("/feedback/trainers/sync", handler.BookingHandler.SyncTrainerRatings)
+
+			// iCalendar of a member's bookings, and the tokenised feeds calendar
+			// apps subscribe to
+			bookings.GET("/calendar/members/:member_id", handler.CalendarHandler.GetMemberCalendar)
+			bookings.POST("/calendar/feeds", handler.CalendarHandler.CreateFeed)
+			bookings.GET("/calendar/feeds", handler.CalendarHandler.GetFeeds)
+			bookings.GET("/calendar/feeds/:id", handler.CalendarHandler.GetFeed)
+			bookings.DELETE("/calendar/feeds/:id", handler.CalendarHandler.RevokeFeed)
 		}
+
+		// Days the center, a room or a trainer holds no classes
+		closures := api.Group("/closures")
+		{
+			closures.GET("", handler.ClosureHandler.GetClosures)
+			closures.GET("/:id", handler.ClosureHandler.GetClosure)
+			closures.POST("", handler.ClosureHandler.CreateClosure)
+			closures.GET("/:id/bookings", handler.ClosureHandler.GetClosureBookings)
+			closures.DELETE("/:id", handler.ClosureHandler.DeleteClosure)
+		}
+
+		// Calendar feeds opened with their token instead of a login. The
+		// gateway routes this path without the auth middleware.
+		api.GET("/calendar/:token", handler.CalendarHandler.GetFeedCalendar)
+
+		// Audit trail of changes made through this service
+		api.GET("/audit/classes", audit.ListHandler(auditStore))
 	}
 }
@@ -7,8 +7,10 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/config"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/handler"
+	"github.com/FurkanArikk/fitness-center/backend/identity"
 	"github.com/gin-gonic/gin"
 )
 
@@ -19,16 +21,18 @@ type Server struct {
 }
 
 // NewServer creates a new server instance
-func NewServer(cfg *config.Config, h *handler.Handler) *Server {
+func NewServer(cfg *config.Config, h *handler.Handler, auditStore audit.Store) *Server {
 	router := gin.Default()
 
 	// Add middleware
 	router.Use(corsMiddleware())
 	router.Use(contentTypeMiddleware())
 	router.Use(loggingMiddleware())
+	router.Use(identity.Identify())
+	router.Use(audit.Middleware(auditStore, identity.Actor))
 
 	// Set up routes using the function from router.go
-	setupRoutes(router, h)
+	setupRoutes(router, h, auditStore)
 
 	srv := &Server{
 		router: router,
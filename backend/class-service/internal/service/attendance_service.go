@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// noShowBatch limits the bookings marked as no-shows in one transaction
+const noShowBatch = 200
+
+// MarkNoShows marks bookings that are still booked after their occurrence
+// ended, plus a grace period for staff to record attendance, as no-shows.
+// Each no-show is penalized under the policy of its class, like one recorded
+// by staff. Penalties that could not be applied stay pending and are retried
+// on the next run. When another replica is running the job, nothing is
+// marked.
+func (s *BookingServiceImpl) MarkNoShows(ctx context.Context, grace time.Duration) (int, error) {
+	policies := make(map[int]model.CancellationPolicy)
+	attempted := make(map[int]bool)
+	marked := 0
+
+	for {
+		bookings, locked, err := s.repo.MarkNoShows(ctx, grace, noShowBatch)
+		if err != nil {
+			return marked, err
+		}
+		if !locked {
+			return marked, nil
+		}
+
+		s.penalizeNoShows(ctx, bookings, policies, attempted)
+
+		marked += len(bookings)
+		if len(bookings) < noShowBatch {
+			break
+		}
+	}
+
+	pending, err := s.repo.GetPendingPenalties(ctx, noShowBatch)
+	if err != nil {
+		log.Printf("Failed to get no-shows with pending penalties: %v", err)
+		return marked, nil
+	}
+	s.penalizeNoShows(ctx, pending, policies, attempted)
+	return marked, nil
+}
+
+// penalizeNoShows applies the penalties of no-show bookings not attempted
+// yet in this run and clears them from pending once applied. Bookings whose
+// penalty fails stay pending.
+func (s *BookingServiceImpl) penalizeNoShows(ctx context.Context, bookings []model.Booking, policies map[int]model.CancellationPolicy, attempted map[int]bool) {
+	for _, booking := range bookings {
+		if attempted[booking.BookingID] {
+			continue
+		}
+		attempted[booking.BookingID] = true
+
+		policy, ok := policies[booking.OccurrenceID]
+		if !ok {
+			var err error
+			policy, _, err = s.bookingPolicy(ctx, booking)
+			if err != nil {
+				log.Printf("Failed to get cancellation policy of booking %d: %v", booking.BookingID, err)
+				continue
+			}
+			policies[booking.OccurrenceID] = policy
+		}
+
+		if _, err := s.applyPenalty(ctx, booking, policy, model.PenaltyNoShow); err != nil {
+			log.Printf("Failed to apply no_show penalty of booking %d, retrying on the next run: %v", booking.BookingID, err)
+			continue
+		}
+		if err := s.repo.ClearPendingPenalty(ctx, booking.BookingID); err != nil {
+			log.Printf("Failed to clear pending penalty of booking %d: %v", booking.BookingID, err)
+		}
+	}
+}
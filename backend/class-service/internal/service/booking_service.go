@@ -4,18 +4,50 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
 )
 
 // BookingServiceImpl implements model.BookingService interface
 type BookingServiceImpl struct {
-	repo model.BookingRepository
+	repo           model.BookingRepository
+	waitlistRepo   model.WaitlistRepository
+	occurrenceRepo model.OccurrenceRepository
+	penaltyRepo    model.PenaltyRepository
+	creditRepo     model.CreditRepository
+	feedbackRepo   model.FeedbackRepository
+	closureRepo    model.ClosureRepository
+	references     model.ReferenceChecker
+	memberships    model.MembershipSource
+	charges        model.ChargePoster
+	ratings        model.RatingPublisher
+	offerWindow    time.Duration
 }
 
-// NewBookingService creates a new BookingService
-func NewBookingService(repo model.BookingRepository) model.BookingService {
-	return &BookingServiceImpl{repo: repo}
+// NewBookingService creates a new BookingService. Seats freed by a
+// cancellation go to the first waitlisted member, who has offerWindow to
+// confirm them, or is booked straight away if offerWindow is zero. Penalty
+// fees are posted through charges; a nil charges only records them.
+// Membership credits are granted from the memberships members hold; a nil
+// memberships grants none. Trainer ratings are published through ratings
+// when feedback is given; a nil ratings keeps them in this service.
+func NewBookingService(repo model.BookingRepository, waitlistRepo model.WaitlistRepository, occurrenceRepo model.OccurrenceRepository, penaltyRepo model.PenaltyRepository, creditRepo model.CreditRepository, feedbackRepo model.FeedbackRepository, closureRepo model.ClosureRepository, references model.ReferenceChecker, memberships model.MembershipSource, charges model.ChargePoster, ratings model.RatingPublisher, offerWindow time.Duration) model.BookingService {
+	return &BookingServiceImpl{
+		repo:           repo,
+		waitlistRepo:   waitlistRepo,
+		occurrenceRepo: occurrenceRepo,
+		penaltyRepo:    penaltyRepo,
+		creditRepo:     creditRepo,
+		feedbackRepo:   feedbackRepo,
+		closureRepo:    closureRepo,
+		references:     references,
+		memberships:    memberships,
+		charges:        charges,
+		ratings:        ratings,
+		offerWindow:    offerWindow,
+	}
 }
 
 // GetBookings returns all bookings
@@ -28,33 +60,86 @@ func (s *BookingServiceImpl) GetBookingByID(ctx context.Context, id int) (model.
 	return s.repo.GetByID(ctx, id)
 }
 
-// GetBookingsPaginated returns paginated bookings
-func (s *BookingServiceImpl) GetBookingsPaginated(ctx context.Context, status string, date string, offset, limit int) ([]model.BookingResponse, int, error) {
-	return s.repo.GetAllPaginated(ctx, status, date, offset, limit)
+// GetBookingsPaginated returns paginated bookings, limited to one member or
+// occurrence when memberID or occurrenceID is set
+func (s *BookingServiceImpl) GetBookingsPaginated(ctx context.Context, status string, date string, memberID, occurrenceID int, offset, limit int) ([]model.BookingResponse, int, error) {
+	return s.repo.GetAllPaginated(ctx, status, date, memberID, occurrenceID, offset, limit)
 }
 
 // CreateBooking creates a new booking
 func (s *BookingServiceImpl) CreateBooking(ctx context.Context, req model.BookingRequest) (model.Booking, error) {
-	// Check capacity
-	currentCount, capacity, err := s.repo.CheckCapacity(ctx, req.ScheduleID)
+	// Release seats held by expired waitlist offers
+	if err := s.ExpireWaitlistOffers(ctx); err != nil {
+		return model.Booking{}, err
+	}
+
+	occurrence, err := s.bookableOccurrence(ctx, req.OccurrenceID, req.ScheduleID, req.BookingDate)
 	if err != nil {
 		return model.Booking{}, err
 	}
 
-	if currentCount >= capacity {
-		return model.Booking{}, errors.New("class is already at full capacity")
+	if err := s.checkNotSuspended(ctx, req.MemberID); err != nil {
+		return model.Booking{}, err
 	}
 
+	if err := s.references.CheckMember(ctx, req.MemberID); err != nil {
+		return model.Booking{}, err
+	}
+
+	if _, err := s.prepareCredits(ctx, req.MemberID, occurrence.ClassID); err != nil {
+		return model.Booking{}, err
+	}
+
+	// The repository checks the capacity and takes the credits of the class
+	// while it holds a lock on the occurrence, so two members cannot both
+	// take the last seat
 	booking := model.Booking{
-		ScheduleID:  req.ScheduleID,
-		MemberID:    req.MemberID,
-		BookingDate: req.BookingDate,
+		ScheduleID:   occurrence.ScheduleID,
+		OccurrenceID: occurrence.OccurrenceID,
+		MemberID:     req.MemberID,
+		BookingDate:  occurrence.StartsAt(),
 	}
 
 	return s.repo.Create(ctx, booking)
 }
 
-// UpdateBookingStatus updates a booking's attendance status
+// bookableOccurrence returns the occurrence a booking or waitlist request is
+// for, given by its ID or by a schedule and the date it takes place on. Only
+// upcoming occurrences that were not cancelled and that no closure covers can
+// be booked.
+func (s *BookingServiceImpl) bookableOccurrence(ctx context.Context, occurrenceID, scheduleID int, date time.Time) (model.OccurrenceResponse, error) {
+	var occurrence model.OccurrenceResponse
+	var err error
+
+	switch {
+	case occurrenceID != 0:
+		occurrence, err = s.occurrenceRepo.GetByID(ctx, occurrenceID)
+	case scheduleID != 0 && !date.IsZero():
+		occurrence, err = s.occurrenceRepo.GetByScheduleDate(ctx, scheduleID, date)
+	default:
+		return model.OccurrenceResponse{}, errors.New("occurrence_id or schedule_id and booking_date are required")
+	}
+	if err != nil {
+		return model.OccurrenceResponse{}, err
+	}
+
+	if occurrence.Status == model.OccurrenceCancelled {
+		return model.OccurrenceResponse{}, errors.New("class occurrence is cancelled")
+	}
+	if !occurrence.StartsAt().After(time.Now()) {
+		return model.OccurrenceResponse{}, errors.New("class occurrence has already started")
+	}
+	if err := checkNotClosed(ctx, s.closureRepo, occurrence.OccurrenceDate, occurrence.TrainerID, occurrence.RoomID); err != nil {
+		return model.OccurrenceResponse{}, err
+	}
+
+	return occurrence, nil
+}
+
+// UpdateBookingStatus updates a booking's attendance status. Cancelling a
+// booked seat inside the cancellation cutoff, and marking a booking as a
+// no-show, are penalized under the policy of the class. Correcting a no-show,
+// or a cancellation back to booked or attended, waives the penalty it got.
 func (s *BookingServiceImpl) UpdateBookingStatus(ctx context.Context, id int, status string) (model.Booking, error) {
 	// Validate status
 	validStatuses := map[string]bool{
@@ -68,7 +153,40 @@ func (s *BookingServiceImpl) UpdateBookingStatus(ctx context.Context, id int, st
 		return model.Booking{}, fmt.Errorf("invalid status: %s", status)
 	}
 
-	return s.repo.UpdateStatus(ctx, id, status)
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return model.Booking{}, err
+	}
+	audit.Before(ctx, existing)
+
+	policy, startsAt, err := s.bookingPolicy(ctx, existing.Booking)
+	if err != nil {
+		return model.Booking{}, err
+	}
+
+	booking, err := s.repo.UpdateStatus(ctx, id, existing.AttendanceStatus, status)
+	if err != nil {
+		return model.Booking{}, err
+	}
+
+	switch {
+	case status == "cancelled" && existing.AttendanceStatus == "booked":
+		if policy.IsLateCancel(startsAt, time.Now()) {
+			s.penalize(ctx, booking, policy, model.PenaltyLateCancel)
+		}
+	case status == "no_show" && existing.AttendanceStatus != "no_show":
+		s.penalize(ctx, booking, policy, model.PenaltyNoShow)
+	case existing.AttendanceStatus == "no_show" && status != "no_show":
+		// The member was marked as a no-show by mistake
+		s.waiveBookingPenalties(ctx, booking, model.PenaltyNoShow)
+	case existing.AttendanceStatus == "cancelled" && (status == "booked" || status == "attended"):
+		s.waiveBookingPenalties(ctx, booking, model.PenaltyLateCancel)
+	}
+
+	if status == "cancelled" && existing.AttendanceStatus != "cancelled" {
+		s.promoteWaitlist(ctx, booking.OccurrenceID)
+	}
+	return booking, nil
 }
 
 // AddBookingFeedback adds feedback to a booking
@@ -83,20 +201,46 @@ func (s *BookingServiceImpl) AddBookingFeedback(ctx context.Context, id int, req
 		return model.Booking{}, errors.New("feedback can only be provided for attended classes")
 	}
 
-	return s.repo.AddFeedback(ctx, id, req.Rating, req.Comment)
+	updated, err := s.repo.AddFeedback(ctx, id, req.Rating, req.Comment)
+	if err != nil {
+		return model.Booking{}, err
+	}
+
+	s.syncTrainerRating(ctx, booking.TrainerID)
+	return updated, nil
 }
 
-// CancelBooking cancels a booking
-func (s *BookingServiceImpl) CancelBooking(ctx context.Context, id int) (model.Booking, error) {
+// CancelBooking cancels a booking and refunds its credits. Cancelling inside
+// the cancellation cutoff of the class is penalized; the penalty is returned
+// with the booking.
+func (s *BookingServiceImpl) CancelBooking(ctx context.Context, id int) (model.Booking, *model.Penalty, error) {
 	// Get the booking to check status
 	booking, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return model.Booking{}, err
+		return model.Booking{}, nil, err
 	}
 
 	if booking.AttendanceStatus != "booked" {
-		return model.Booking{}, errors.New("only bookings with 'booked' status can be cancelled")
+		return model.Booking{}, nil, errors.New("only bookings with 'booked' status can be cancelled")
+	}
+	audit.Before(ctx, booking)
+
+	policy, startsAt, err := s.bookingPolicy(ctx, booking.Booking)
+	if err != nil {
+		return model.Booking{}, nil, err
+	}
+
+	cancelled, err := s.repo.Cancel(ctx, id)
+	if err != nil {
+		return model.Booking{}, nil, err
+	}
+
+	var penalty *model.Penalty
+	if policy.IsLateCancel(startsAt, time.Now()) {
+		penalty = s.penalize(ctx, cancelled, policy, model.PenaltyLateCancel)
 	}
 
-	return s.repo.Cancel(ctx, id)
+	// The freed seat goes to the waitlist
+	s.promoteWaitlist(ctx, cancelled.OccurrenceID)
+	return cancelled, penalty, nil
 }
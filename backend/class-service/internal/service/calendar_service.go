@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/calendar"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// calendarRefresh is how often calendar apps are asked to reload a feed
+const calendarRefresh = time.Hour
+
+// calendarUIDDomain makes the UIDs of calendar events globally unique
+const calendarUIDDomain = "class-service.fitness-center"
+
+// feedTokenBytes is the number of random bytes in a feed token
+const feedTokenBytes = 32
+
+// weekdays maps the day_of_week of schedules to weekdays
+var weekdays = map[string]time.Weekday{
+	"Sunday":    time.Sunday,
+	"Monday":    time.Monday,
+	"Tuesday":   time.Tuesday,
+	"Wednesday": time.Wednesday,
+	"Thursday":  time.Thursday,
+	"Friday":    time.Friday,
+	"Saturday":  time.Saturday,
+}
+
+// CalendarServiceImpl implements model.CalendarService interface
+type CalendarServiceImpl struct {
+	repo           model.CalendarRepository
+	scheduleRepo   model.ScheduleRepository
+	occurrenceRepo model.OccurrenceRepository
+	bookingRepo    model.BookingRepository
+	historyDays    int
+}
+
+// NewCalendarService creates a new CalendarService whose timetables reach
+// historyDays into the past
+func NewCalendarService(repo model.CalendarRepository, scheduleRepo model.ScheduleRepository, occurrenceRepo model.OccurrenceRepository, bookingRepo model.BookingRepository, historyDays int) model.CalendarService {
+	return &CalendarServiceImpl{
+		repo:           repo,
+		scheduleRepo:   scheduleRepo,
+		occurrenceRepo: occurrenceRepo,
+		bookingRepo:    bookingRepo,
+		historyDays:    historyDays,
+	}
+}
+
+// ScheduleCalendar returns the iCalendar of all active schedules
+func (s *CalendarServiceImpl) ScheduleCalendar(ctx context.Context) ([]byte, error) {
+	return s.timetable(ctx, "Class Schedule",
+		func(model.Schedule) bool { return true },
+		func(model.Occurrence) bool { return true })
+}
+
+// TrainerCalendar returns the iCalendar of the classes a trainer teaches.
+// Sessions handed to another trainer are left out and sessions taken over
+// from another trainer are added.
+func (s *CalendarServiceImpl) TrainerCalendar(ctx context.Context, trainerID int) ([]byte, error) {
+	return s.timetable(ctx, fmt.Sprintf("Classes of Trainer %d", trainerID),
+		func(schedule model.Schedule) bool { return schedule.TrainerID == trainerID },
+		func(occurrence model.Occurrence) bool { return occurrence.TrainerID == trainerID })
+}
+
+// RoomCalendar returns the iCalendar of the classes held in a room. Sessions
+// moved to another room are left out and sessions moved into it are added.
+func (s *CalendarServiceImpl) RoomCalendar(ctx context.Context, roomID int) ([]byte, error) {
+	return s.timetable(ctx, fmt.Sprintf("Classes in Room %d", roomID),
+		func(schedule model.Schedule) bool { return schedule.RoomID == roomID },
+		func(occurrence model.Occurrence) bool { return occurrence.RoomID == roomID })
+}
+
+// MemberCalendar returns the iCalendar of the classes a member booked, one
+// event per booking that is not cancelled
+func (s *CalendarServiceImpl) MemberCalendar(ctx context.Context, memberID int) ([]byte, error) {
+	bookings, err := s.bookingRepo.GetByMemberID(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	since := s.since()
+	events := make([]calendar.Event, 0, len(bookings))
+	for _, booking := range bookings {
+		if booking.AttendanceStatus == "cancelled" || booking.EndsAt().Before(since) {
+			continue
+		}
+		events = append(events, calendar.Event{
+			UID:         fmt.Sprintf("booking-%d@%s", booking.BookingID, calendarUIDDomain),
+			Start:       booking.StartsAt(),
+			End:         booking.EndsAt(),
+			Stamp:       stamp(booking.UpdatedAt),
+			Summary:     booking.ClassName,
+			Description: fmt.Sprintf("Trainer %d", booking.TrainerID),
+			Location:    fmt.Sprintf("Room %d", booking.RoomID),
+			Status:      calendar.StatusConfirmed,
+		})
+	}
+
+	return calendar.Calendar{
+		Name:            "My Classes",
+		RefreshInterval: calendarRefresh,
+		TimeZone:        model.Location(),
+		Events:          events,
+	}.Marshal(), nil
+}
+
+// timetable builds the iCalendar of the active schedules includeSchedule
+// selects and of the occurrences includeOccurrence selects. Each schedule is
+// one weekly recurring event. Occurrences that were cancelled, or that
+// includeOccurrence leaves out, are excluded from it; occurrences that were
+// moved or changed override it. Occurrences that are not part of a selected
+// schedule are added as single events.
+func (s *CalendarServiceImpl) timetable(ctx context.Context, name string, includeSchedule func(model.Schedule) bool, includeOccurrence func(model.Occurrence) bool) ([]byte, error) {
+	schedules, err := s.scheduleRepo.GetAll(ctx, "active")
+	if err != nil {
+		return nil, err
+	}
+
+	since := s.since()
+	occurrences, err := s.occurrenceRepo.GetAll(ctx, model.OccurrenceFilter{From: &since})
+	if err != nil {
+		return nil, err
+	}
+
+	bySchedule := make(map[int][]model.OccurrenceResponse)
+	for _, occurrence := range occurrences {
+		bySchedule[occurrence.ScheduleID] = append(bySchedule[occurrence.ScheduleID], occurrence)
+	}
+
+	var events []calendar.Event
+	inSeries := make(map[int]bool)
+	for _, schedule := range schedules {
+		if !includeSchedule(schedule.Schedule) {
+			continue
+		}
+		series, covered := scheduleEvents(schedule, bySchedule[schedule.ScheduleID], includeOccurrence)
+		events = append(events, series...)
+		for _, id := range covered {
+			inSeries[id] = true
+		}
+	}
+
+	for _, occurrence := range occurrences {
+		if inSeries[occurrence.OccurrenceID] || occurrence.Status == model.OccurrenceCancelled ||
+			!includeOccurrence(occurrence.Occurrence) {
+			continue
+		}
+		events = append(events, occurrenceEvent(occurrence, fmt.Sprintf("occurrence-%d@%s", occurrence.OccurrenceID, calendarUIDDomain), nil))
+	}
+
+	return calendar.Calendar{
+		Name:            name,
+		RefreshInterval: calendarRefresh,
+		TimeZone:        model.Location(),
+		Events:          events,
+	}.Marshal(), nil
+}
+
+// scheduleEvents returns the recurring event of a schedule and the events
+// overriding its sessions, together with the IDs of the occurrences they
+// cover. Occurrences on another weekday than the schedule's, left from
+// before it was moved, are not covered.
+func scheduleEvents(schedule model.ScheduleResponse, occurrences []model.OccurrenceResponse, includeOccurrence func(model.Occurrence) bool) ([]calendar.Event, []int) {
+	weekday, ok := weekdays[schedule.DayOfWeek]
+	if !ok {
+		return nil, nil
+	}
+
+	sessions := make(map[string]model.OccurrenceResponse)
+	var first, last time.Time
+	for _, occurrence := range occurrences {
+		date := occurrence.OriginalDate
+		if date.Weekday() != weekday {
+			continue
+		}
+		sessions[date.Format("2006-01-02")] = occurrence
+		if first.IsZero() || date.Before(first) {
+			first = date
+		}
+		if date.After(last) {
+			last = date
+		}
+	}
+
+	// A schedule without occurrences yet starts on its next weekday
+	if first.IsZero() {
+		today := model.Today()
+		first = today.AddDate(0, 0, (int(weekday)-int(today.Weekday())+7)%7)
+		last = first.AddDate(0, 0, -7)
+	}
+
+	uid := fmt.Sprintf("schedule-%d@%s", schedule.ScheduleID, calendarUIDDomain)
+	series := calendar.Event{
+		UID:         uid,
+		Start:       schedule.StartsOn(first),
+		End:         schedule.EndsOn(first),
+		Stamp:       stamp(schedule.UpdatedAt),
+		Summary:     schedule.ClassName,
+		Description: fmt.Sprintf("Trainer %d", schedule.TrainerID),
+		Location:    fmt.Sprintf("Room %d", schedule.RoomID),
+		Status:      calendar.StatusConfirmed,
+		RRule:       "FREQ=WEEKLY;BYDAY=" + calendar.Weekday(weekday),
+	}
+
+	var overrides []calendar.Event
+	var covered []int
+	for date := first; !date.After(last); date = date.AddDate(0, 0, 7) {
+		session := schedule.StartsOn(date)
+		occurrence, ok := sessions[date.Format("2006-01-02")]
+		if !ok {
+			series.ExDates = append(series.ExDates, session)
+			continue
+		}
+		covered = append(covered, occurrence.OccurrenceID)
+
+		switch {
+		case occurrence.Status == model.OccurrenceCancelled || !includeOccurrence(occurrence.Occurrence):
+			series.ExDates = append(series.ExDates, session)
+		case !occurrence.StartsAt().Equal(session) || !occurrence.EndsAt().Equal(schedule.EndsOn(date)) ||
+			occurrence.TrainerID != schedule.TrainerID || occurrence.RoomID != schedule.RoomID:
+			overrides = append(overrides, occurrenceEvent(occurrence, uid, &session))
+		}
+	}
+
+	return append([]calendar.Event{series}, overrides...), covered
+}
+
+// occurrenceEvent returns the event of a single occurrence. recurrenceID is
+// the session of the schedule it replaces, or nil for a standalone event.
+func occurrenceEvent(occurrence model.OccurrenceResponse, uid string, recurrenceID *time.Time) calendar.Event {
+	return calendar.Event{
+		UID:          uid,
+		Start:        occurrence.StartsAt(),
+		End:          occurrence.EndsAt(),
+		Stamp:        stamp(occurrence.UpdatedAt),
+		Summary:      occurrence.ClassName,
+		Description:  fmt.Sprintf("Trainer %d", occurrence.TrainerID),
+		Location:     fmt.Sprintf("Room %d", occurrence.RoomID),
+		Status:       calendar.StatusConfirmed,
+		RecurrenceID: recurrenceID,
+	}
+}
+
+// since returns the first date the timetables include
+func (s *CalendarServiceImpl) since() time.Time {
+	return model.Today().AddDate(0, 0, -s.historyDays)
+}
+
+// stamp returns the DTSTAMP of an event last changed at updatedAt
+func stamp(updatedAt time.Time) time.Time {
+	if updatedAt.IsZero() {
+		return time.Now()
+	}
+	return updatedAt
+}
+
+// FeedCalendar returns the iCalendar of the feed a token opens
+func (s *CalendarServiceImpl) FeedCalendar(ctx context.Context, token string) ([]byte, error) {
+	feed, err := s.repo.GetByTokenHash(ctx, hashFeedToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	var subjectID int
+	if feed.SubjectID != nil {
+		subjectID = *feed.SubjectID
+	}
+
+	switch feed.FeedType {
+	case model.FeedSchedule:
+		return s.ScheduleCalendar(ctx)
+	case model.FeedTrainer:
+		return s.TrainerCalendar(ctx, subjectID)
+	case model.FeedRoom:
+		return s.RoomCalendar(ctx, subjectID)
+	case model.FeedMember:
+		return s.MemberCalendar(ctx, subjectID)
+	}
+	return nil, errors.New("calendar feed not found")
+}
+
+// CreateFeed creates a feed with a new random token. Only the hash of the
+// token is stored.
+func (s *CalendarServiceImpl) CreateFeed(ctx context.Context, req model.CalendarFeedRequest) (model.CalendarFeed, string, error) {
+	feed := model.CalendarFeed{FeedType: req.FeedType, CreatedBy: req.CreatedBy}
+
+	switch req.FeedType {
+	case model.FeedSchedule:
+	case model.FeedTrainer, model.FeedRoom, model.FeedMember:
+		if req.SubjectID <= 0 {
+			return model.CalendarFeed{}, "", errors.New("calendar feed subject ID is required")
+		}
+		subjectID := req.SubjectID
+		feed.SubjectID = &subjectID
+	default:
+		return model.CalendarFeed{}, "", errors.New("invalid calendar feed type")
+	}
+
+	raw := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return model.CalendarFeed{}, "", fmt.Errorf("failed to generate calendar feed token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	feed.TokenHash = hashFeedToken(token)
+
+	feed, err := s.repo.Create(ctx, feed)
+	if err != nil {
+		return model.CalendarFeed{}, "", err
+	}
+	return feed, token, nil
+}
+
+// GetFeedsPaginated returns paginated calendar feeds
+func (s *CalendarServiceImpl) GetFeedsPaginated(ctx context.Context, filter model.CalendarFeedFilter, offset, limit int) ([]model.CalendarFeed, int, error) {
+	return s.repo.GetAllPaginated(ctx, filter, offset, limit)
+}
+
+// GetFeed returns a calendar feed by its ID
+func (s *CalendarServiceImpl) GetFeed(ctx context.Context, id int) (model.CalendarFeed, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// RevokeFeed revokes a calendar feed
+func (s *CalendarServiceImpl) RevokeFeed(ctx context.Context, id int) (model.CalendarFeed, error) {
+	if existing, err := s.repo.GetByID(ctx, id); err == nil {
+		audit.Before(ctx, existing)
+	}
+	return s.repo.Revoke(ctx, id)
+}
+
+// hashFeedToken returns the hex SHA-256 hash a feed token is stored as
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
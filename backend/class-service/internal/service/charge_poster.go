@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/client"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/config"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// ChargePosterImpl implements model.ChargePoster with the client of the
+// payment service
+type ChargePosterImpl struct {
+	cfg      config.PenaltyConfig
+	payments *client.PaymentClient
+}
+
+// NewChargePoster creates a new ChargePoster. It returns nil when the payment
+// service has no base URL, in which case fees are recorded but not charged.
+func NewChargePoster(clientCfg config.ServiceClientConfig, cfg config.PenaltyConfig) model.ChargePoster {
+	if clientCfg.BaseURL == "" {
+		return nil
+	}
+	return &ChargePosterImpl{cfg: cfg, payments: client.NewPaymentClient(clientCfg)}
+}
+
+// PostCharge creates a pending payment for the fee of a penalty. The invoice
+// number refers back to the penalty. If a payment with that invoice number
+// exists, because an earlier post went through but was not recorded, it is
+// returned instead of posting the fee twice.
+func (s *ChargePosterImpl) PostCharge(ctx context.Context, penalty model.Penalty) (int, error) {
+	invoice := fmt.Sprintf("CLASS-PENALTY-%d", penalty.PenaltyID)
+
+	existing, err := s.payments.FindPaymentByInvoice(ctx, invoice)
+	switch {
+	case err == nil:
+		return existing.PaymentID, nil
+	case !errors.Is(err, client.ErrNotFound):
+		return 0, err
+	}
+
+	description := fmt.Sprintf("%s fee for class booking %d", penaltyLabel(penalty.PenaltyType), penalty.BookingID)
+
+	req := client.PaymentRequest{
+		MemberID:      penalty.MemberID,
+		Amount:        penalty.Fee,
+		PaymentMethod: s.cfg.PaymentMethod,
+		PaymentStatus: client.PaymentPending,
+		InvoiceNumber: &invoice,
+		Description:   &description,
+	}
+	if s.cfg.PaymentTypeID != 0 {
+		req.PaymentTypeID = &s.cfg.PaymentTypeID
+	}
+
+	payment, err := s.payments.CreatePayment(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	return payment.PaymentID, nil
+}
+
+// ReverseCharge refunds the payment the fee of a penalty was posted as
+func (s *ChargePosterImpl) ReverseCharge(ctx context.Context, penalty model.Penalty) error {
+	if penalty.PaymentID == nil {
+		return fmt.Errorf("penalty %d has no payment", penalty.PenaltyID)
+	}
+	return s.payments.RefundPayment(ctx, *penalty.PaymentID)
+}
+
+// penaltyLabel returns the name of a penalty type used in payment
+// descriptions
+func penaltyLabel(penaltyType string) string {
+	if penaltyType == model.PenaltyNoShow {
+		return "No-show"
+	}
+	return "Late cancellation"
+}
@@ -4,17 +4,19 @@ import (
 	"context"
 	"errors"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
 )
 
 // ClassServiceImpl implements model.ClassService interface
 type ClassServiceImpl struct {
-	repo model.ClassRepository
+	repo        model.ClassRepository
+	penaltyRepo model.PenaltyRepository
 }
 
 // NewClassService creates a new ClassService
-func NewClassService(repo model.ClassRepository) model.ClassService {
-	return &ClassServiceImpl{repo: repo}
+func NewClassService(repo model.ClassRepository, penaltyRepo model.PenaltyRepository) model.ClassService {
+	return &ClassServiceImpl{repo: repo, penaltyRepo: penaltyRepo}
 }
 
 // GetClasses returns all classes
@@ -57,6 +59,9 @@ func (s *ClassServiceImpl) UpdateClass(ctx context.Context, id int, req model.Cl
 		IsActive:    req.IsActive,
 	}
 
+	if existing, err := s.repo.GetByID(ctx, id); err == nil {
+		audit.Before(ctx, existing)
+	}
 	return s.repo.Update(ctx, id, class)
 }
 
@@ -72,5 +77,44 @@ func (s *ClassServiceImpl) DeleteClass(ctx context.Context, id int) error {
 		return errors.New("cannot delete class that is used in schedules")
 	}
 
+	if existing, err := s.repo.GetByID(ctx, id); err == nil {
+		audit.Before(ctx, existing)
+	}
 	return s.repo.Delete(ctx, id)
 }
+
+// GetPolicy returns the cancellation policy of a class
+func (s *ClassServiceImpl) GetPolicy(ctx context.Context, classID int) (model.CancellationPolicy, error) {
+	if _, err := s.repo.GetByID(ctx, classID); err != nil {
+		return model.CancellationPolicy{}, err
+	}
+	return s.penaltyRepo.GetPolicy(ctx, classID)
+}
+
+// UpdatePolicy sets the cancellation policy of a class. Unset rolling period
+// and suspension lengths fall back to the defaults.
+func (s *ClassServiceImpl) UpdatePolicy(ctx context.Context, classID int, req model.PolicyRequest) (model.CancellationPolicy, error) {
+	if _, err := s.repo.GetByID(ctx, classID); err != nil {
+		return model.CancellationPolicy{}, err
+	}
+
+	policy := model.DefaultPolicy(classID)
+	policy.CancellationCutoffMinutes = req.CancellationCutoffMinutes
+	policy.LateCancelFee = req.LateCancelFee
+	policy.LateCancelCredits = req.LateCancelCredits
+	policy.NoShowFee = req.NoShowFee
+	policy.NoShowCredits = req.NoShowCredits
+	policy.NoShowLimit = req.NoShowLimit
+	policy.CreditCost = req.CreditCost
+	if req.NoShowPeriodDays != 0 {
+		policy.NoShowPeriodDays = req.NoShowPeriodDays
+	}
+	if req.SuspensionDays != 0 {
+		policy.SuspensionDays = req.SuspensionDays
+	}
+
+	if existing, err := s.penaltyRepo.GetPolicy(ctx, classID); err == nil {
+		audit.Before(ctx, existing)
+	}
+	return s.penaltyRepo.SavePolicy(ctx, policy)
+}
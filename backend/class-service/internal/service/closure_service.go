@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// ClosureServiceImpl implements model.ClosureService interface
+type ClosureServiceImpl struct {
+	repo           model.ClosureRepository
+	occurrenceRepo model.OccurrenceRepository
+	horizonDays    int
+}
+
+// NewClosureService creates a new ClosureService. Occurrences a deleted
+// closure held back are generated again horizonDays ahead.
+func NewClosureService(repo model.ClosureRepository, occurrenceRepo model.OccurrenceRepository, horizonDays int) model.ClosureService {
+	return &ClosureServiceImpl{repo: repo, occurrenceRepo: occurrenceRepo, horizonDays: horizonDays}
+}
+
+// CreateClosure creates a closure. Upcoming classes it covers are removed, or
+// cancelled together with their bookings when members booked them; the
+// cancelled bookings are returned so their members can be told.
+func (s *ClosureServiceImpl) CreateClosure(ctx context.Context, closure model.Closure) (model.ClosureResult, error) {
+	switch closure.Scope {
+	case model.ClosureCenter:
+		if closure.SubjectID != nil {
+			return model.ClosureResult{}, errors.New("center closures cannot have a subject ID")
+		}
+	case model.ClosureRoom, model.ClosureTrainer:
+		if closure.SubjectID == nil || *closure.SubjectID <= 0 {
+			return model.ClosureResult{}, errors.New("closure subject ID is required")
+		}
+	default:
+		return model.ClosureResult{}, errors.New("invalid closure scope")
+	}
+
+	if closure.EndDate.Before(closure.StartDate) {
+		return model.ClosureResult{}, errors.New("end date must not be before start date")
+	}
+	if closure.EndDate.Before(model.Today()) {
+		return model.ClosureResult{}, errors.New("closure cannot end in the past")
+	}
+
+	result, err := s.repo.Create(ctx, closure)
+	if err != nil {
+		return model.ClosureResult{}, err
+	}
+
+	if result.RemovedOccurrences > 0 || result.CancelledOccurrences > 0 {
+		log.Printf("Closure %d removed %d and cancelled %d class occurrences, cancelling %d bookings",
+			result.ClosureID, result.RemovedOccurrences, result.CancelledOccurrences, len(result.CancelledBookings))
+	}
+	return result, nil
+}
+
+// GetClosure returns a closure by its ID
+func (s *ClosureServiceImpl) GetClosure(ctx context.Context, id int) (model.Closure, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// GetClosuresPaginated returns paginated closures
+func (s *ClosureServiceImpl) GetClosuresPaginated(ctx context.Context, filter model.ClosureFilter, offset, limit int) ([]model.Closure, int, error) {
+	return s.repo.GetAllPaginated(ctx, filter, offset, limit)
+}
+
+// GetClosureBookings returns the bookings a closure cancelled
+func (s *ClosureServiceImpl) GetClosureBookings(ctx context.Context, id int) ([]model.ClosedBooking, error) {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetBookings(ctx, id)
+}
+
+// DeleteClosure deletes a closure and generates the occurrences it held back.
+// Classes it cancelled stay cancelled, as members were told they would not
+// take place.
+func (s *ClosureServiceImpl) DeleteClosure(ctx context.Context, id int) error {
+	if existing, err := s.repo.GetByID(ctx, id); err == nil {
+		audit.Before(ctx, existing)
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	// The hourly generation catches up if this fails
+	from, to := occurrenceHorizon(s.horizonDays)
+	if _, err := s.occurrenceRepo.Generate(ctx, 0, from, to); err != nil {
+		log.Printf("Failed to generate class occurrences after deleting closure %d: %v", id, err)
+	}
+	return nil
+}
+
+// checkNotClosed rejects a class on a date a closure covers for its trainer
+// or room
+func checkNotClosed(ctx context.Context, closures model.ClosureRepository, date time.Time, trainerID, roomID int) error {
+	covering, err := closures.GetCovering(ctx, date, trainerID, roomID)
+	if err != nil {
+		return err
+	}
+	if len(covering) > 0 {
+		return errors.New("class occurrence falls in a closure")
+	}
+	return nil
+}
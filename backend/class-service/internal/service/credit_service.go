@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// GetCreditBalance returns the credits a member can book with. The credits
+// of their membership for the current month are granted first.
+func (s *BookingServiceImpl) GetCreditBalance(ctx context.Context, memberID int) (model.CreditBalance, error) {
+	s.grantMembershipCredits(ctx, memberID)
+	return s.creditRepo.GetBalance(ctx, memberID)
+}
+
+// GetCreditHistoryPaginated returns the paginated credit ledger of a member
+func (s *BookingServiceImpl) GetCreditHistoryPaginated(ctx context.Context, memberID int, offset, limit int) ([]model.CreditTransaction, int, error) {
+	return s.creditRepo.GetTransactionsPaginated(ctx, memberID, offset, limit)
+}
+
+// GrantCredits grants a member a class pack or credits given by staff.
+// Membership credits are granted by their plan.
+func (s *BookingServiceImpl) GrantCredits(ctx context.Context, grant model.CreditGrant) (model.CreditGrant, error) {
+	if grant.Source != model.GrantPack && grant.Source != model.GrantManual {
+		return model.CreditGrant{}, errors.New("credits can only be granted as a pack or manually")
+	}
+	if grant.ExpiresAt != nil && !grant.ExpiresAt.After(time.Now()) {
+		return model.CreditGrant{}, errors.New("expiry must be in the future")
+	}
+	return s.creditRepo.Grant(ctx, grant)
+}
+
+// GetCreditPlans returns the monthly credit plans of all memberships
+func (s *BookingServiceImpl) GetCreditPlans(ctx context.Context) ([]model.CreditPlan, error) {
+	return s.creditRepo.ListPlans(ctx)
+}
+
+// SaveCreditPlan sets the monthly credits of a membership. Members holding
+// it get the new number from the next month they are granted credits.
+func (s *BookingServiceImpl) SaveCreditPlan(ctx context.Context, plan model.CreditPlan) (model.CreditPlan, error) {
+	if existing, err := s.creditRepo.GetPlan(ctx, plan.MembershipID); err == nil {
+		audit.Before(ctx, existing)
+	}
+	return s.creditRepo.SavePlan(ctx, plan)
+}
+
+// DeleteCreditPlan stops granting monthly credits to a membership
+func (s *BookingServiceImpl) DeleteCreditPlan(ctx context.Context, membershipID int) error {
+	if existing, err := s.creditRepo.GetPlan(ctx, membershipID); err == nil {
+		audit.Before(ctx, existing)
+	}
+	return s.creditRepo.DeletePlan(ctx, membershipID)
+}
+
+// prepareCredits returns the credits a booking of a class costs. When it
+// costs any, the member is granted the credits of their membership for the
+// current month first.
+func (s *BookingServiceImpl) prepareCredits(ctx context.Context, memberID, classID int) (int, error) {
+	policy, err := s.penaltyRepo.GetPolicy(ctx, classID)
+	if err != nil {
+		return 0, err
+	}
+
+	if policy.CreditCost > 0 {
+		s.grantMembershipCredits(ctx, memberID)
+	}
+	return policy.CreditCost, nil
+}
+
+// grantMembershipCredits grants a member the credits of their membership's
+// plan for the current month, once per month. The credits expire at the end
+// of the month, or of the membership if it ends earlier. Failures are logged
+// rather than returned, so members can still book with the credits they
+// have.
+func (s *BookingServiceImpl) grantMembershipCredits(ctx context.Context, memberID int) {
+	if s.memberships == nil {
+		return
+	}
+
+	membership, active, err := s.memberships.ActiveMembership(ctx, memberID)
+	if err != nil {
+		log.Printf("Failed to get membership of member %d: %v", memberID, err)
+		return
+	}
+	if !active {
+		return
+	}
+
+	plan, err := s.creditRepo.GetPlan(ctx, membership.MembershipID)
+	if err != nil {
+		if err.Error() != "credit plan not found" {
+			log.Printf("Failed to get credit plan of membership %d: %v", membership.MembershipID, err)
+		}
+		return
+	}
+
+	now := time.Now().In(model.Location())
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, model.Location())
+	expiresAt := month.AddDate(0, 1, 0)
+	// A membership runs until the end of its last day
+	end := membership.EndDate
+	if ends := time.Date(end.Year(), end.Month(), end.Day()+1, 0, 0, 0, 0, model.Location()); ends.Before(expiresAt) {
+		expiresAt = ends
+	}
+	reference := fmt.Sprintf("membership-%d-%s", membership.ID, month.Format("2006-01"))
+
+	_, err = s.creditRepo.Grant(ctx, model.CreditGrant{
+		MemberID:    memberID,
+		Source:      model.GrantMembership,
+		Reference:   &reference,
+		Credits:     plan.CreditsPerMonth,
+		ValidFrom:   month,
+		ExpiresAt:   &expiresAt,
+		Description: fmt.Sprintf("Membership credits for %s", month.Format("January 2006")),
+	})
+	if err != nil {
+		log.Printf("Failed to grant membership credits to member %d: %v", memberID, err)
+	}
+}
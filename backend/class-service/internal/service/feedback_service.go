@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// GetFeedbackSummary returns the count, average and distribution of the
+// ratings the filter selects, with up to comments of the latest comments
+func (s *BookingServiceImpl) GetFeedbackSummary(ctx context.Context, filter model.FeedbackFilter, comments int) (model.FeedbackSummary, error) {
+	distribution, err := s.feedbackRepo.GetDistribution(ctx, filter)
+	if err != nil {
+		return model.FeedbackSummary{}, err
+	}
+
+	summary := summarize(distribution)
+	if comments > 0 {
+		summary.RecentComments, err = s.feedbackRepo.GetRecentComments(ctx, filter, comments)
+		if err != nil {
+			return model.FeedbackSummary{}, err
+		}
+	}
+	if summary.RecentComments == nil {
+		summary.RecentComments = []model.FeedbackComment{}
+	}
+	return summary, nil
+}
+
+// SyncTrainerRatings pushes the rating of every trainer with feedback to the
+// staff service and returns how many it pushed. Failures are logged and the
+// other trainers are still pushed.
+func (s *BookingServiceImpl) SyncTrainerRatings(ctx context.Context) (int, error) {
+	if s.ratings == nil {
+		return 0, nil
+	}
+
+	ratings, err := s.feedbackRepo.GetTrainerRatings(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	synced := 0
+	for _, rating := range ratings {
+		if err := s.ratings.PublishRating(ctx, rating); err != nil {
+			log.Printf("Failed to sync rating of trainer %d: %v", rating.TrainerID, err)
+			continue
+		}
+		synced++
+	}
+	return synced, nil
+}
+
+// syncTrainerRating pushes the rating of a trainer to the staff service after
+// they got feedback. Failures are logged rather than returned, so the
+// feedback is kept; the next sync catches up.
+func (s *BookingServiceImpl) syncTrainerRating(ctx context.Context, trainerID int) {
+	if s.ratings == nil || trainerID == 0 {
+		return
+	}
+
+	distribution, err := s.feedbackRepo.GetDistribution(ctx, model.FeedbackFilter{TrainerID: trainerID})
+	if err != nil {
+		log.Printf("Failed to get rating of trainer %d: %v", trainerID, err)
+		return
+	}
+
+	summary := summarize(distribution)
+	rating := model.TrainerRating{TrainerID: trainerID, Rating: summary.Average, Count: summary.Count}
+	if err := s.ratings.PublishRating(ctx, rating); err != nil {
+		log.Printf("Failed to sync rating of trainer %d: %v", trainerID, err)
+	}
+}
+
+// summarize counts and averages a rating distribution, filling in the
+// ratings from 1 to 5 that were not given
+func summarize(distribution map[int]int) model.FeedbackSummary {
+	summary := model.FeedbackSummary{Distribution: make(map[int]int, 5)}
+
+	total := 0
+	for rating := 1; rating <= 5; rating++ {
+		count := distribution[rating]
+		summary.Distribution[rating] = count
+		summary.Count += count
+		total += rating * count
+	}
+
+	if summary.Count > 0 {
+		summary.Average = math.Round(float64(total)/float64(summary.Count)*100) / 100
+	}
+	return summary
+}
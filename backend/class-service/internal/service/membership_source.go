@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/client"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/config"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// MembershipSourceImpl implements model.MembershipSource with the client of
+// the member service
+type MembershipSourceImpl struct {
+	members *client.MemberClient
+}
+
+// NewMembershipSource creates a new MembershipSource. It returns nil when the
+// member service has no base URL, in which case no membership credits are
+// granted.
+func NewMembershipSource(cfg config.ServiceClientConfig) model.MembershipSource {
+	if cfg.BaseURL == "" {
+		return nil
+	}
+	return &MembershipSourceImpl{members: client.NewMemberClient(cfg)}
+}
+
+// ActiveMembership returns the membership a member holds now
+func (s *MembershipSourceImpl) ActiveMembership(ctx context.Context, memberID int) (model.ActiveMembership, bool, error) {
+	membership, active, err := s.members.GetActiveMembership(ctx, memberID)
+	if err != nil || !active {
+		return model.ActiveMembership{}, false, err
+	}
+
+	endDate, err := time.Parse("2006-01-02", membership.EndDate)
+	if err != nil {
+		return model.ActiveMembership{}, false, fmt.Errorf("invalid end date of membership %d: %w", membership.ID, err)
+	}
+
+	return model.ActiveMembership{
+		ID:           membership.ID,
+		MembershipID: membership.MembershipID,
+		EndDate:      endDate,
+	}, true, nil
+}
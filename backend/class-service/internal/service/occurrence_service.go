@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// OccurrenceServiceImpl implements model.OccurrenceService interface
+type OccurrenceServiceImpl struct {
+	repo        model.OccurrenceRepository
+	closureRepo model.ClosureRepository
+	references  model.ReferenceChecker
+	horizonDays int
+}
+
+// NewOccurrenceService creates a new OccurrenceService that generates
+// occurrences horizonDays ahead
+func NewOccurrenceService(repo model.OccurrenceRepository, closureRepo model.ClosureRepository, references model.ReferenceChecker, horizonDays int) model.OccurrenceService {
+	return &OccurrenceServiceImpl{repo: repo, closureRepo: closureRepo, references: references, horizonDays: horizonDays}
+}
+
+// GetOccurrencesPaginated returns paginated occurrences
+func (s *OccurrenceServiceImpl) GetOccurrencesPaginated(ctx context.Context, filter model.OccurrenceFilter, offset, limit int) ([]model.OccurrenceResponse, int, error) {
+	return s.repo.GetAllPaginated(ctx, filter, offset, limit)
+}
+
+// GetOccurrenceByID returns an occurrence by its ID
+func (s *OccurrenceServiceImpl) GetOccurrenceByID(ctx context.Context, id int) (model.OccurrenceResponse, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// CancelOccurrence cancels a single occurrence without touching its schedule
+func (s *OccurrenceServiceImpl) CancelOccurrence(ctx context.Context, id int, reason string) (model.Occurrence, error) {
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return model.Occurrence{}, err
+	}
+
+	if !existing.StartsAt().After(time.Now()) {
+		return model.Occurrence{}, errors.New("class occurrence has already started")
+	}
+	audit.Before(ctx, existing)
+
+	return s.repo.Cancel(ctx, id, reason)
+}
+
+// RescheduleOccurrence moves a single occurrence without touching its
+// schedule. Later changes to the schedule no longer apply to it. It cannot be
+// moved onto a closure of the center, its room or its trainer.
+func (s *OccurrenceServiceImpl) RescheduleOccurrence(ctx context.Context, id int, req model.OccurrenceRescheduleRequest) (model.Occurrence, error) {
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return model.Occurrence{}, err
+	}
+
+	if existing.Status == model.OccurrenceCancelled {
+		return model.Occurrence{}, errors.New("cancelled class occurrences cannot be rescheduled")
+	}
+	audit.Before(ctx, existing)
+	if !existing.StartsAt().After(time.Now()) {
+		return model.Occurrence{}, errors.New("class occurrence has already started")
+	}
+
+	if err := validateTimes(req.StartTime, req.EndTime); err != nil {
+		return model.Occurrence{}, err
+	}
+
+	occurrence := model.Occurrence{
+		OccurrenceDate: req.OccurrenceDate,
+		StartTime:      req.StartTime,
+		EndTime:        req.EndTime,
+		TrainerID:      existing.TrainerID,
+		RoomID:         existing.RoomID,
+	}
+	if req.TrainerID != 0 {
+		occurrence.TrainerID = req.TrainerID
+	}
+	if req.RoomID != 0 {
+		occurrence.RoomID = req.RoomID
+	}
+
+	if !occurrence.StartsAt().After(time.Now()) {
+		return model.Occurrence{}, errors.New("class occurrence cannot be moved into the past")
+	}
+	if err := checkNotClosed(ctx, s.closureRepo, occurrence.OccurrenceDate, occurrence.TrainerID, occurrence.RoomID); err != nil {
+		return model.Occurrence{}, err
+	}
+
+	if err := s.references.CheckTrainer(ctx, occurrence.TrainerID); err != nil {
+		return model.Occurrence{}, err
+	}
+	if err := s.references.CheckRoom(ctx, occurrence.RoomID, occurrence.StartTime, occurrence.EndTime); err != nil {
+		return model.Occurrence{}, err
+	}
+
+	return s.repo.Reschedule(ctx, id, occurrence)
+}
+
+// GenerateOccurrences extends the occurrences of all active schedules to the
+// configured horizon
+func (s *OccurrenceServiceImpl) GenerateOccurrences(ctx context.Context) (int, error) {
+	from, to := occurrenceHorizon(s.horizonDays)
+	return s.repo.Generate(ctx, 0, from, to)
+}
+
+// occurrenceHorizon returns the first and last date occurrences are
+// generated for
+func occurrenceHorizon(horizonDays int) (time.Time, time.Time) {
+	today := model.Today()
+	return today, today.AddDate(0, 0, horizonDays)
+}
+
+// validateTimes checks that start and end are times of day in the HH:MM or
+// HH:MM:SS format, and that end is after start
+func validateTimes(start, end string) error {
+	startsAt, startOK := parseClock(start)
+	endsAt, endOK := parseClock(end)
+	if !startOK || !endOK {
+		return errors.New("start and end time must be given as HH:MM or HH:MM:SS")
+	}
+	if !endsAt.After(startsAt) {
+		return errors.New("end time must be after start time")
+	}
+	return nil
+}
+
+// parseClock parses a time of day in the HH:MM:SS or HH:MM format
+func parseClock(clock string) (time.Time, bool) {
+	if t, err := time.Parse("15:04:05", clock); err == nil {
+		return t, true
+	}
+	t, err := time.Parse("15:04", clock)
+	return t, err == nil
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// chargeSweepDelay keeps the pending charge sweep away from penalties whose
+// fee is still being posted by the request that recorded them
+const chargeSweepDelay = time.Minute
+
+// chargeSweepBatch limits the fees posted by one sweep
+const chargeSweepBatch = 100
+
+// GetPenaltiesPaginated returns paginated penalties
+func (s *BookingServiceImpl) GetPenaltiesPaginated(ctx context.Context, filter model.PenaltyFilter, offset, limit int) ([]model.Penalty, int, error) {
+	return s.penaltyRepo.GetPenaltiesPaginated(ctx, filter, offset, limit)
+}
+
+// GetPenalty returns a penalty by its ID
+func (s *BookingServiceImpl) GetPenalty(ctx context.Context, id int) (model.Penalty, error) {
+	return s.penaltyRepo.GetPenaltyByID(ctx, id)
+}
+
+// WaivePenalty waives a penalty and gives back the credits forfeited for it.
+// A fee that was already posted to the payment service is refunded there.
+func (s *BookingServiceImpl) WaivePenalty(ctx context.Context, id int) (model.Penalty, error) {
+	existing, err := s.penaltyRepo.GetPenaltyByID(ctx, id)
+	if err != nil {
+		return model.Penalty{}, err
+	}
+	audit.Before(ctx, existing)
+
+	var penalty model.Penalty
+	if existing.Status == model.PenaltyActive && existing.ChargeStatus == model.ChargePosted {
+		if s.charges == nil {
+			return model.Penalty{}, errors.New("penalty fee was already charged")
+		}
+		if err := s.charges.ReverseCharge(ctx, existing); err != nil {
+			log.Printf("Failed to reverse fee of penalty %d: %v", id, err)
+			return model.Penalty{}, &model.ServiceUnavailableError{Service: "payment"}
+		}
+		penalty, err = s.penaltyRepo.WaiveReversed(ctx, id)
+	} else {
+		penalty, err = s.penaltyRepo.Waive(ctx, id)
+	}
+	if err != nil {
+		return model.Penalty{}, err
+	}
+
+	if penalty.Credits > 0 {
+		if err := s.creditRepo.RestoreForfeit(ctx, penalty.PenaltyID); err != nil {
+			log.Printf("Failed to restore credits of penalty %d: %v", penalty.PenaltyID, err)
+		}
+	}
+	return penalty, nil
+}
+
+// waiveBookingPenalties waives the active penalties of a type a booking got,
+// when its status is corrected so that the penalty no longer applies.
+// Failures are logged, like those of penalize.
+func (s *BookingServiceImpl) waiveBookingPenalties(ctx context.Context, booking model.Booking, penaltyType string) {
+	penalties, _, err := s.penaltyRepo.GetPenaltiesPaginated(ctx, model.PenaltyFilter{
+		BookingID:   booking.BookingID,
+		PenaltyType: penaltyType,
+		Status:      model.PenaltyActive,
+	}, 0, 1)
+	if err != nil {
+		log.Printf("Failed to get %s penalties of booking %d: %v", penaltyType, booking.BookingID, err)
+		return
+	}
+
+	for _, penalty := range penalties {
+		if _, err := s.WaivePenalty(ctx, penalty.PenaltyID); err != nil {
+			log.Printf("Failed to waive %s penalty %d of booking %d: %v", penaltyType, penalty.PenaltyID, booking.BookingID, err)
+			continue
+		}
+		log.Printf("Waived %s penalty %d of booking %d after its status changed to %s", penaltyType, penalty.PenaltyID, booking.BookingID, booking.AttendanceStatus)
+	}
+}
+
+// GetSuspensionsPaginated returns paginated suspensions
+func (s *BookingServiceImpl) GetSuspensionsPaginated(ctx context.Context, filter model.SuspensionFilter, offset, limit int) ([]model.Suspension, int, error) {
+	return s.penaltyRepo.GetSuspensionsPaginated(ctx, filter, offset, limit)
+}
+
+// LiftSuspension ends a suspension early
+func (s *BookingServiceImpl) LiftSuspension(ctx context.Context, id int) (model.Suspension, error) {
+	return s.penaltyRepo.Lift(ctx, id)
+}
+
+// PostPendingCharges posts the fees that could not be posted when their
+// penalty was recorded
+func (s *BookingServiceImpl) PostPendingCharges(ctx context.Context) error {
+	if s.charges == nil {
+		return nil
+	}
+
+	penalties, err := s.penaltyRepo.GetPendingCharges(ctx, time.Now().Add(-chargeSweepDelay), chargeSweepBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, penalty := range penalties {
+		s.postCharge(ctx, &penalty)
+	}
+	return nil
+}
+
+// reverseWaivedCharge refunds a payment posted for a penalty that is no
+// longer charged, unless the penalty was charged through that payment after
+// all by a concurrent post
+func (s *BookingServiceImpl) reverseWaivedCharge(ctx context.Context, penalty model.Penalty, paymentID int) {
+	current, err := s.penaltyRepo.GetPenaltyByID(ctx, penalty.PenaltyID)
+	if err != nil {
+		log.Printf("Failed to get penalty %d: %v", penalty.PenaltyID, err)
+		return
+	}
+	if current.Status == model.PenaltyActive {
+		return
+	}
+
+	penalty.PaymentID = &paymentID
+	if err := s.charges.ReverseCharge(ctx, penalty); err != nil {
+		log.Printf("Failed to reverse payment %d of waived penalty %d: %v", paymentID, penalty.PenaltyID, err)
+		return
+	}
+	log.Printf("Reversed payment %d of penalty %d, which was waived while its fee was posted", paymentID, penalty.PenaltyID)
+}
+
+// checkNotSuspended rejects members who are suspended from booking
+func (s *BookingServiceImpl) checkNotSuspended(ctx context.Context, memberID int) error {
+	suspension, err := s.penaltyRepo.GetActiveSuspension(ctx, memberID)
+	if err != nil {
+		if err.Error() == "member is not suspended" {
+			return nil
+		}
+		return err
+	}
+	return &model.SuspensionError{Until: suspension.EndsAt}
+}
+
+// bookingPolicy returns the cancellation policy that applies to a booking,
+// with the start of its occurrence
+func (s *BookingServiceImpl) bookingPolicy(ctx context.Context, booking model.Booking) (model.CancellationPolicy, time.Time, error) {
+	occurrence, err := s.occurrenceRepo.GetByID(ctx, booking.OccurrenceID)
+	if err != nil {
+		return model.CancellationPolicy{}, time.Time{}, err
+	}
+
+	policy, err := s.penaltyRepo.GetPolicy(ctx, occurrence.ClassID)
+	if err != nil {
+		return model.CancellationPolicy{}, time.Time{}, err
+	}
+
+	return policy, occurrence.StartsAt(), nil
+}
+
+// penalize records the penalty of a cancelled or no-show booking under the
+// policy of its class, takes the credits it forfeits from the member's
+// balance, and suspends members who missed too many classes. It
+// returns nil when the policy has no penalty for the booking. Failures are
+// logged rather than returned, as they must not undo the status change that
+// led to the penalty.
+func (s *BookingServiceImpl) penalize(ctx context.Context, booking model.Booking, policy model.CancellationPolicy, penaltyType string) *model.Penalty {
+	penalty, err := s.applyPenalty(ctx, booking, policy, penaltyType)
+	if err != nil {
+		log.Printf("Failed to apply %s penalty of booking %d: %v", penaltyType, booking.BookingID, err)
+	}
+	return penalty
+}
+
+// applyPenalty is penalize returning the error when the penalty could not be
+// recorded or its credits not forfeited. Applying a penalty again completes
+// it without recording it twice. Fees that could not be posted are left to
+// the pending charge sweep.
+func (s *BookingServiceImpl) applyPenalty(ctx context.Context, booking model.Booking, policy model.CancellationPolicy, penaltyType string) (*model.Penalty, error) {
+	if penaltyType == model.PenaltyNoShow {
+		defer s.suspendIfOverLimit(ctx, booking, policy)
+	}
+
+	fee, credits := policy.PenaltyFor(penaltyType)
+	if fee == 0 && credits == 0 {
+		return nil, nil
+	}
+
+	penalty := model.Penalty{
+		BookingID:    booking.BookingID,
+		OccurrenceID: booking.OccurrenceID,
+		ClassID:      policy.ClassID,
+		MemberID:     booking.MemberID,
+		PenaltyType:  penaltyType,
+		Fee:          fee,
+		Credits:      credits,
+		Status:       model.PenaltyActive,
+		ChargeStatus: model.ChargeNone,
+	}
+	if fee > 0 {
+		penalty.ChargeStatus = model.ChargePending
+	}
+
+	penalty, err := s.penaltyRepo.Record(ctx, penalty)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Recorded %s penalty %d of member %d for booking %d", penaltyType, penalty.PenaltyID, penalty.MemberID, booking.BookingID)
+
+	if penalty.Status == model.PenaltyActive && penalty.Credits > 0 {
+		if err := s.creditRepo.Forfeit(ctx, penalty); err != nil {
+			return &penalty, fmt.Errorf("failed to forfeit credits of penalty %d: %w", penalty.PenaltyID, err)
+		}
+	}
+
+	if penalty.Status == model.PenaltyActive && penalty.ChargeStatus == model.ChargePending && s.charges != nil {
+		s.postCharge(ctx, &penalty)
+	}
+	return &penalty, nil
+}
+
+// suspendIfOverLimit suspends a member whose no-shows within the rolling
+// period of the policy reached its limit
+func (s *BookingServiceImpl) suspendIfOverLimit(ctx context.Context, booking model.Booking, policy model.CancellationPolicy) {
+	if policy.NoShowLimit == 0 {
+		return
+	}
+
+	if err := s.checkNotSuspended(ctx, booking.MemberID); err != nil {
+		var suspensionErr *model.SuspensionError
+		if !errors.As(err, &suspensionErr) {
+			log.Printf("Failed to check suspension of member %d: %v", booking.MemberID, err)
+		}
+		return
+	}
+
+	now := time.Now()
+	count, err := s.penaltyRepo.CountNoShows(ctx, booking.MemberID, now.AddDate(0, 0, -policy.NoShowPeriodDays))
+	if err != nil {
+		log.Printf("Failed to count no-shows of member %d: %v", booking.MemberID, err)
+		return
+	}
+	if count < policy.NoShowLimit {
+		return
+	}
+
+	suspension, err := s.penaltyRepo.Suspend(ctx, model.Suspension{
+		MemberID:    booking.MemberID,
+		ClassID:     policy.ClassID,
+		NoShowCount: count,
+		StartsAt:    now,
+		EndsAt:      now.AddDate(0, 0, policy.SuspensionDays),
+	})
+	if err != nil {
+		log.Printf("Failed to suspend member %d: %v", booking.MemberID, err)
+		return
+	}
+	log.Printf("Suspended member %d until %s after %d no-shows", suspension.MemberID, suspension.EndsAt.Format(time.RFC3339), count)
+}
+
+// postCharge posts the fee of a penalty to the payment service. A failed post
+// leaves the fee pending for the next sweep. A fee posted for a penalty that
+// was waived in the meantime is reversed straight away.
+func (s *BookingServiceImpl) postCharge(ctx context.Context, penalty *model.Penalty) {
+	paymentID, err := s.charges.PostCharge(ctx, *penalty)
+	if err != nil {
+		log.Printf("Failed to post fee of penalty %d: %v", penalty.PenaltyID, err)
+		return
+	}
+
+	if err := s.penaltyRepo.MarkCharged(ctx, penalty.PenaltyID, paymentID); err != nil {
+		log.Printf("Failed to record payment %d of penalty %d: %v", paymentID, penalty.PenaltyID, err)
+		if err.Error() == "penalty charge is no longer pending" {
+			s.reverseWaivedCharge(ctx, *penalty, paymentID)
+		}
+		return
+	}
+	penalty.ChargeStatus = model.ChargePosted
+	penalty.PaymentID = &paymentID
+}
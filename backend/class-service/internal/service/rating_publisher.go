@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/client"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/config"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// RatingPublisherImpl implements model.RatingPublisher with the client of the
+// staff service
+type RatingPublisherImpl struct {
+	staff *client.StaffClient
+}
+
+// NewRatingPublisher creates a new RatingPublisher. It returns nil when the
+// staff service has no base URL, or when there is no token URL to get the
+// service token the staff service requires for ratings. Trainer ratings are
+// then not synced.
+func NewRatingPublisher(cfg config.ServiceClientConfig) model.RatingPublisher {
+	if cfg.BaseURL == "" {
+		return nil
+	}
+	if cfg.Credentials.TokenURL == "" {
+		log.Printf("Warning: CLASS_SERVICE_TOKEN_URL is not set, trainer ratings are not synced to the staff service")
+		return nil
+	}
+	return &RatingPublisherImpl{staff: client.NewStaffClient(cfg)}
+}
+
+// PublishRating sets the rating of a trainer in the staff service, rounded to
+// the two decimals it keeps
+func (s *RatingPublisherImpl) PublishRating(ctx context.Context, rating model.TrainerRating) error {
+	_, err := s.staff.UpdateTrainerRating(ctx, rating.TrainerID, client.TrainerRating{
+		Rating:      math.Round(rating.Rating*100) / 100,
+		RatingCount: rating.Count,
+	})
+	return err
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/client"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/config"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// ReferenceCheckerImpl implements model.ReferenceChecker with the clients of
+// the member, staff and facility services. Services without a base URL are
+// not asked, and their IDs are accepted as they are.
+type ReferenceCheckerImpl struct {
+	cfg        config.ClientsConfig
+	members    *client.MemberClient
+	staff      *client.StaffClient
+	facilities *client.FacilityClient
+}
+
+// NewReferenceChecker creates a new ReferenceChecker
+func NewReferenceChecker(cfg config.ClientsConfig) model.ReferenceChecker {
+	checker := &ReferenceCheckerImpl{cfg: cfg}
+	if cfg.Member.BaseURL != "" {
+		checker.members = client.NewMemberClient(cfg.Member)
+	}
+	if cfg.Staff.BaseURL != "" {
+		checker.staff = client.NewStaffClient(cfg.Staff)
+	}
+	if cfg.Facility.BaseURL != "" {
+		checker.facilities = client.NewFacilityClient(cfg.Facility)
+	}
+	return checker
+}
+
+// CheckMember makes sure the member exists, is active and has a valid
+// membership
+func (s *ReferenceCheckerImpl) CheckMember(ctx context.Context, memberID int) error {
+	if s.members == nil {
+		return nil
+	}
+
+	member, err := s.members.GetMember(ctx, memberID)
+	if err != nil {
+		return s.failure("member", s.cfg.Member, err, "member not found")
+	}
+	if member.Status != client.MemberActive {
+		return &model.ReferenceError{Message: "member is not active"}
+	}
+
+	active, err := s.members.HasActiveMembership(ctx, memberID)
+	if err != nil {
+		return s.failure("member", s.cfg.Member, err, "member not found")
+	}
+	if !active {
+		return &model.ReferenceError{Message: "member has no valid membership"}
+	}
+	return nil
+}
+
+// CheckTrainer makes sure the trainer exists and is active
+func (s *ReferenceCheckerImpl) CheckTrainer(ctx context.Context, trainerID int) error {
+	if s.staff == nil {
+		return nil
+	}
+
+	trainer, err := s.staff.GetTrainer(ctx, trainerID)
+	if err != nil {
+		return s.failure("staff", s.cfg.Staff, err, "trainer not found or not active")
+	}
+	if !trainer.IsActive {
+		return &model.ReferenceError{Message: "trainer not found or not active"}
+	}
+	return nil
+}
+
+// CheckRoom makes sure the room exists, is open and that its opening hours
+// cover the class
+func (s *ReferenceCheckerImpl) CheckRoom(ctx context.Context, roomID int, startTime, endTime string) error {
+	if s.facilities == nil {
+		return nil
+	}
+
+	room, err := s.facilities.GetFacility(ctx, roomID)
+	if err != nil {
+		return s.failure("facility", s.cfg.Facility, err, "room not found")
+	}
+	if room.IsDeleted || room.Status != client.FacilityActive {
+		return &model.ReferenceError{Message: "room is not open"}
+	}
+
+	// Rooms without readable opening hours are taken to be open all day
+	opens, opensOK := parseClock(room.OpeningHour)
+	closes, closesOK := parseClock(room.ClosingHour)
+	starts, startsOK := parseClock(startTime)
+	ends, endsOK := parseClock(endTime)
+	if opensOK && closesOK && startsOK && endsOK && (starts.Before(opens) || ends.After(closes)) {
+		return &model.ReferenceError{Message: "class time is outside the opening hours of the room"}
+	}
+	return nil
+}
+
+// failure turns an error of a service client into the error of a check.
+// notFound is the message for IDs the service does not know. When the
+// service is unavailable the check fails, unless the client is configured to
+// fail open.
+func (s *ReferenceCheckerImpl) failure(service string, cfg config.ServiceClientConfig, err error, notFound string) error {
+	switch {
+	case errors.Is(err, client.ErrNotFound):
+		return &model.ReferenceError{Message: notFound}
+	case errors.Is(err, client.ErrUnavailable) && cfg.FailOpen:
+		log.Printf("Warning: %s service check skipped: %v", service, err)
+		return nil
+	case errors.Is(err, client.ErrUnavailable):
+		log.Printf("Error: %s service check failed: %v", service, err)
+		return &model.ServiceUnavailableError{Service: service}
+	default:
+		return err
+	}
+}
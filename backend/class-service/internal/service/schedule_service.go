@@ -4,20 +4,28 @@ import (
 	"context"
 	"errors"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
 )
 
 // ScheduleServiceImpl implements model.ScheduleService interface
 type ScheduleServiceImpl struct {
-	repo      model.ScheduleRepository
-	classRepo model.ClassRepository
+	repo           model.ScheduleRepository
+	classRepo      model.ClassRepository
+	occurrenceRepo model.OccurrenceRepository
+	references     model.ReferenceChecker
+	horizonDays    int
 }
 
-// NewScheduleService creates a new ScheduleService
-func NewScheduleService(repo model.ScheduleRepository, classRepo model.ClassRepository) model.ScheduleService {
+// NewScheduleService creates a new ScheduleService. Occurrences of new and
+// changed schedules are generated horizonDays ahead.
+func NewScheduleService(repo model.ScheduleRepository, classRepo model.ClassRepository, occurrenceRepo model.OccurrenceRepository, references model.ReferenceChecker, horizonDays int) model.ScheduleService {
 	return &ScheduleServiceImpl{
-		repo:      repo,
-		classRepo: classRepo,
+		repo:           repo,
+		classRepo:      classRepo,
+		occurrenceRepo: occurrenceRepo,
+		references:     references,
+		horizonDays:    horizonDays,
 	}
 }
 
@@ -63,7 +71,21 @@ func (s *ScheduleServiceImpl) CreateSchedule(ctx context.Context, req model.Sche
 		Status:    req.Status,
 	}
 
-	return s.repo.Create(ctx, schedule)
+	if err := s.checkSchedule(ctx, schedule); err != nil {
+		return model.Schedule{}, err
+	}
+
+	created, err := s.repo.Create(ctx, schedule)
+	if err != nil {
+		return model.Schedule{}, err
+	}
+
+	from, to := occurrenceHorizon(s.horizonDays)
+	if _, err := s.occurrenceRepo.Generate(ctx, created.ScheduleID, from, to); err != nil {
+		return model.Schedule{}, err
+	}
+
+	return created, nil
 }
 
 // UpdateSchedule updates an existing schedule
@@ -78,6 +100,15 @@ func (s *ScheduleServiceImpl) UpdateSchedule(ctx context.Context, id int, req mo
 		return model.Schedule{}, errors.New("cannot schedule an inactive class")
 	}
 
+	// Keep the current status if none is given, as it decides whether
+	// occurrences are generated
+	if existing, err := s.repo.GetByID(ctx, id); err == nil {
+		audit.Before(ctx, existing)
+		if req.Status == "" {
+			req.Status = existing.Status
+		}
+	}
+
 	schedule := model.Schedule{
 		ClassID:   req.ClassID,
 		TrainerID: req.TrainerID,
@@ -88,7 +119,26 @@ func (s *ScheduleServiceImpl) UpdateSchedule(ctx context.Context, id int, req mo
 		Status:    req.Status,
 	}
 
-	return s.repo.Update(ctx, id, schedule)
+	if err := s.checkSchedule(ctx, schedule); err != nil {
+		return model.Schedule{}, err
+	}
+
+	updated, err := s.repo.Update(ctx, id, schedule)
+	if err != nil {
+		return model.Schedule{}, err
+	}
+
+	// Upcoming occurrences follow the schedule, unless they were changed one
+	// by one
+	from, to := occurrenceHorizon(s.horizonDays)
+	if err := s.occurrenceRepo.SyncSchedule(ctx, id, from); err != nil {
+		return model.Schedule{}, err
+	}
+	if _, err := s.occurrenceRepo.Generate(ctx, id, from, to); err != nil {
+		return model.Schedule{}, err
+	}
+
+	return updated, nil
 }
 
 // GetSchedulesPaginated returns paginated schedules
@@ -108,5 +158,34 @@ func (s *ScheduleServiceImpl) DeleteSchedule(ctx context.Context, id int) error
 		return errors.New("cannot delete schedule with existing bookings")
 	}
 
+	if existing, err := s.repo.GetByID(ctx, id); err == nil {
+		audit.Before(ctx, existing)
+	}
 	return s.repo.Delete(ctx, id)
 }
+
+// CheckConflicts reports the active schedules a planned schedule would clash
+// with, without saving anything
+func (s *ScheduleServiceImpl) CheckConflicts(ctx context.Context, check model.ScheduleConflictCheck) ([]model.ScheduleConflict, error) {
+	if err := validateTimes(check.StartTime, check.EndTime); err != nil {
+		return nil, err
+	}
+	return s.repo.FindConflicts(ctx, check)
+}
+
+// checkSchedule validates the times of a schedule and makes sure the trainer
+// and room of an active schedule are available. Double-booking is checked by
+// the repository while saving, so that concurrent changes cannot both pass.
+func (s *ScheduleServiceImpl) checkSchedule(ctx context.Context, schedule model.Schedule) error {
+	if err := validateTimes(schedule.StartTime, schedule.EndTime); err != nil {
+		return err
+	}
+	if schedule.Status != "active" {
+		return nil
+	}
+
+	if err := s.references.CheckTrainer(ctx, schedule.TrainerID); err != nil {
+		return err
+	}
+	return s.references.CheckRoom(ctx, schedule.RoomID, schedule.StartTime, schedule.EndTime)
+}
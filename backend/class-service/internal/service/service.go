@@ -1,22 +1,34 @@
 package service
 
 import (
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/config"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
 	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/repository"
 )
 
 // Service is a factory for all services
 type Service struct {
-	ClassService    model.ClassService
-	ScheduleService model.ScheduleService
-	BookingService  model.BookingService
+	ClassService      model.ClassService
+	ScheduleService   model.ScheduleService
+	OccurrenceService model.OccurrenceService
+	BookingService    model.BookingService
+	CalendarService   model.CalendarService
+	ClosureService    model.ClosureService
 }
 
 // NewServices creates a new service factory with all services
-func NewServices(repo *repository.Repository) *Service {
+func NewServices(repo *repository.Repository, scheduleCfg config.ScheduleConfig, bookingCfg config.BookingConfig, penaltyCfg config.PenaltyConfig, calendarCfg config.CalendarConfig, clientsCfg config.ClientsConfig) *Service {
+	references := NewReferenceChecker(clientsCfg)
+	memberships := NewMembershipSource(clientsCfg.Member)
+	charges := NewChargePoster(clientsCfg.Payment, penaltyCfg)
+	ratings := NewRatingPublisher(clientsCfg.Staff)
+
 	return &Service{
-		ClassService:    NewClassService(repo.ClassRepo),
-		ScheduleService: NewScheduleService(repo.ScheduleRepo, repo.ClassRepo),
-		BookingService:  NewBookingService(repo.BookingRepo),
+		ClassService:      NewClassService(repo.ClassRepo, repo.PenaltyRepo),
+		ScheduleService:   NewScheduleService(repo.ScheduleRepo, repo.ClassRepo, repo.OccurrenceRepo, references, scheduleCfg.OccurrenceHorizonDays),
+		OccurrenceService: NewOccurrenceService(repo.OccurrenceRepo, repo.ClosureRepo, references, scheduleCfg.OccurrenceHorizonDays),
+		BookingService:    NewBookingService(repo.BookingRepo, repo.WaitlistRepo, repo.OccurrenceRepo, repo.PenaltyRepo, repo.CreditRepo, repo.FeedbackRepo, repo.ClosureRepo, references, memberships, charges, ratings, bookingCfg.WaitlistOfferWindow),
+		CalendarService:   NewCalendarService(repo.CalendarRepo, repo.ScheduleRepo, repo.OccurrenceRepo, repo.BookingRepo, calendarCfg.HistoryDays),
+		ClosureService:    NewClosureService(repo.ClosureRepo, repo.OccurrenceRepo, scheduleCfg.OccurrenceHorizonDays),
 	}
 }
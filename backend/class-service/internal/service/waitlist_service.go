@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// JoinWaitlist puts a member at the end of the waitlist of a fully booked
+// occurrence
+func (s *BookingServiceImpl) JoinWaitlist(ctx context.Context, req model.WaitlistRequest) (model.WaitlistResponse, error) {
+	if err := s.ExpireWaitlistOffers(ctx); err != nil {
+		return model.WaitlistResponse{}, err
+	}
+
+	occurrence, err := s.bookableOccurrence(ctx, req.OccurrenceID, req.ScheduleID, req.BookingDate)
+	if err != nil {
+		return model.WaitlistResponse{}, err
+	}
+
+	if err := s.checkNotSuspended(ctx, req.MemberID); err != nil {
+		return model.WaitlistResponse{}, err
+	}
+
+	if err := s.references.CheckMember(ctx, req.MemberID); err != nil {
+		return model.WaitlistResponse{}, err
+	}
+
+	// Members are charged when they get a seat, but have to be able to pay
+	// for it when they join
+	cost, err := s.prepareCredits(ctx, req.MemberID, occurrence.ClassID)
+	if err != nil {
+		return model.WaitlistResponse{}, err
+	}
+	if cost > 0 {
+		balance, err := s.creditRepo.GetBalance(ctx, req.MemberID)
+		if err != nil {
+			return model.WaitlistResponse{}, err
+		}
+		if balance.Balance < cost {
+			return model.WaitlistResponse{}, errors.New("member has not enough class credits")
+		}
+	}
+
+	currentCount, capacity, err := s.repo.CheckCapacity(ctx, occurrence.OccurrenceID)
+	if err != nil {
+		return model.WaitlistResponse{}, err
+	}
+	if currentCount < capacity {
+		return model.WaitlistResponse{}, errors.New("class has free seats, book it directly")
+	}
+
+	bookings, err := s.repo.GetByMemberID(ctx, req.MemberID)
+	if err != nil {
+		return model.WaitlistResponse{}, err
+	}
+	for _, booking := range bookings {
+		if booking.OccurrenceID == occurrence.OccurrenceID && (booking.AttendanceStatus == "booked" || booking.AttendanceStatus == "attended") {
+			return model.WaitlistResponse{}, errors.New("member already has a booking for this class")
+		}
+	}
+
+	entry, err := s.waitlistRepo.Join(ctx, model.WaitlistEntry{
+		ScheduleID:   occurrence.ScheduleID,
+		OccurrenceID: occurrence.OccurrenceID,
+		MemberID:     req.MemberID,
+		BookingDate:  occurrence.StartsAt(),
+	})
+	if err != nil {
+		return model.WaitlistResponse{}, err
+	}
+
+	return s.waitlistRepo.GetByID(ctx, entry.WaitlistID)
+}
+
+// GetWaitlistPaginated returns paginated waitlist entries, including entries
+// that are no longer waiting
+func (s *BookingServiceImpl) GetWaitlistPaginated(ctx context.Context, filter model.WaitlistFilter, offset, limit int) ([]model.WaitlistResponse, int, error) {
+	if err := s.ExpireWaitlistOffers(ctx); err != nil {
+		return nil, 0, err
+	}
+	return s.waitlistRepo.GetAllPaginated(ctx, filter, offset, limit)
+}
+
+// GetWaitlistEntry returns a waitlist entry by its ID
+func (s *BookingServiceImpl) GetWaitlistEntry(ctx context.Context, id int) (model.WaitlistResponse, error) {
+	if err := s.ExpireWaitlistOffers(ctx); err != nil {
+		return model.WaitlistResponse{}, err
+	}
+	return s.waitlistRepo.GetByID(ctx, id)
+}
+
+// LeaveWaitlist withdraws a member from the waitlist. A seat they were
+// offered goes to the next member in line.
+func (s *BookingServiceImpl) LeaveWaitlist(ctx context.Context, id int) (model.WaitlistEntry, error) {
+	existing, err := s.waitlistRepo.GetByID(ctx, id)
+	if err != nil {
+		return model.WaitlistEntry{}, err
+	}
+	audit.Before(ctx, existing)
+
+	entry, err := s.waitlistRepo.Leave(ctx, id)
+	if err != nil {
+		return model.WaitlistEntry{}, err
+	}
+
+	if existing.Status == model.WaitlistOffered {
+		s.promoteWaitlist(ctx, entry.OccurrenceID)
+	}
+	return entry, nil
+}
+
+// ConfirmWaitlistOffer books the seat offered to a waitlisted member
+func (s *BookingServiceImpl) ConfirmWaitlistOffer(ctx context.Context, id int) (model.Booking, error) {
+	if err := s.ExpireWaitlistOffers(ctx); err != nil {
+		return model.Booking{}, err
+	}
+
+	entry, err := s.waitlistRepo.GetByID(ctx, id)
+	if err != nil {
+		return model.Booking{}, err
+	}
+	occurrence, err := s.occurrenceRepo.GetByID(ctx, entry.OccurrenceID)
+	if err != nil {
+		return model.Booking{}, err
+	}
+	if _, err := s.prepareCredits(ctx, entry.MemberID, occurrence.ClassID); err != nil {
+		return model.Booking{}, err
+	}
+
+	return s.waitlistRepo.ConfirmOffer(ctx, id)
+}
+
+// ExpireWaitlistOffers expires seat offers that were not confirmed in time
+// and offers the seats to the next members in line
+func (s *BookingServiceImpl) ExpireWaitlistOffers(ctx context.Context) error {
+	occurrenceIDs, err := s.waitlistRepo.ExpireOffers(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, occurrenceID := range occurrenceIDs {
+		s.promoteWaitlist(ctx, occurrenceID)
+	}
+	return nil
+}
+
+// promoteWaitlist hands free seats of an occurrence to its waitlist. Failures
+// are logged rather than returned, as they must not undo the cancellation
+// that freed the seat; the next cancellation or expiry sweep retries.
+func (s *BookingServiceImpl) promoteWaitlist(ctx context.Context, occurrenceID int) {
+	promoted, err := s.waitlistRepo.PromoteNext(ctx, occurrenceID, s.offerWindow)
+	if err != nil {
+		log.Printf("Failed to promote waitlist of class occurrence %d: %v", occurrenceID, err)
+		return
+	}
+
+	for _, entry := range promoted {
+		log.Printf("Waitlist entry %d of member %d for class occurrence %d %s", entry.WaitlistID, entry.MemberID, occurrenceID, entry.Status)
+	}
+}
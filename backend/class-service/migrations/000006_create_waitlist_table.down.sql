@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_waitlist_offer_expires_at;
+DROP INDEX IF EXISTS idx_waitlist_member_id;
+DROP INDEX IF EXISTS idx_waitlist_schedule_position;
+DROP INDEX IF EXISTS idx_waitlist_active_member;
+DROP TABLE IF EXISTS class_waitlist;
@@ -0,0 +1,24 @@
+-- Members waiting for a seat in a full class. Entries are never deleted so the
+-- table also serves as the waitlist history of a member.
+CREATE TABLE IF NOT EXISTS class_waitlist (
+  waitlist_id SERIAL PRIMARY KEY,
+  schedule_id INTEGER NOT NULL,
+  member_id INTEGER NOT NULL,
+  booking_date TIMESTAMP WITH TIME ZONE NOT NULL,
+  position INTEGER NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'waiting',
+  offer_expires_at TIMESTAMP WITH TIME ZONE,
+  booking_id INTEGER,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  CONSTRAINT fk_waitlist_schedule FOREIGN KEY (schedule_id) REFERENCES class_schedule (schedule_id) ON DELETE CASCADE,
+  CONSTRAINT fk_waitlist_booking FOREIGN KEY (booking_id) REFERENCES class_bookings (booking_id) ON DELETE SET NULL,
+  CONSTRAINT chk_waitlist_status CHECK (status IN ('waiting', 'offered', 'promoted', 'expired', 'left'))
+);
+
+-- A member can only be waiting once per schedule
+CREATE UNIQUE INDEX IF NOT EXISTS idx_waitlist_active_member ON class_waitlist(schedule_id, member_id)
+  WHERE status IN ('waiting', 'offered');
+CREATE INDEX IF NOT EXISTS idx_waitlist_schedule_position ON class_waitlist(schedule_id, position);
+CREATE INDEX IF NOT EXISTS idx_waitlist_member_id ON class_waitlist(member_id);
+CREATE INDEX IF NOT EXISTS idx_waitlist_offer_expires_at ON class_waitlist(offer_expires_at) WHERE status = 'offered';
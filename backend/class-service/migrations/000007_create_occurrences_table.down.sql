@@ -0,0 +1,27 @@
+-- Restoring unique_booking fails if a member booked several occurrences of the
+-- same schedule
+DROP INDEX IF EXISTS idx_waitlist_occurrence_position;
+DROP INDEX IF EXISTS idx_waitlist_active_member;
+ALTER TABLE class_waitlist DROP CONSTRAINT IF EXISTS fk_waitlist_occurrence;
+ALTER TABLE class_waitlist DROP COLUMN IF EXISTS occurrence_id;
+UPDATE class_waitlist SET status = 'left' WHERE status = 'cancelled';
+ALTER TABLE class_waitlist DROP CONSTRAINT IF EXISTS chk_waitlist_status;
+ALTER TABLE class_waitlist ADD CONSTRAINT chk_waitlist_status
+  CHECK (status IN ('waiting', 'offered', 'promoted', 'expired', 'left'));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_waitlist_active_member ON class_waitlist(schedule_id, member_id)
+  WHERE status IN ('waiting', 'offered');
+CREATE INDEX IF NOT EXISTS idx_waitlist_schedule_position ON class_waitlist(schedule_id, position);
+
+DROP TRIGGER IF EXISTS trg_booking_occurrence ON class_bookings;
+DROP FUNCTION IF EXISTS assign_booking_occurrence();
+DROP INDEX IF EXISTS idx_bookings_occurrence_id;
+ALTER TABLE class_bookings DROP CONSTRAINT IF EXISTS unique_booking;
+ALTER TABLE class_bookings DROP CONSTRAINT IF EXISTS fk_booking_occurrence;
+ALTER TABLE class_bookings DROP COLUMN IF EXISTS occurrence_id;
+ALTER TABLE class_bookings ADD CONSTRAINT unique_booking UNIQUE (schedule_id, member_id);
+
+DROP INDEX IF EXISTS idx_occurrences_status;
+DROP INDEX IF EXISTS idx_occurrences_room_date;
+DROP INDEX IF EXISTS idx_occurrences_trainer_date;
+DROP INDEX IF EXISTS idx_occurrences_date;
+DROP TABLE IF EXISTS class_occurrences;
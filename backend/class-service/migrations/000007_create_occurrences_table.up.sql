@@ -0,0 +1,115 @@
+-- Dated sessions of the weekly schedules. Occurrences are generated for a
+-- rolling horizon; original_date is the date the schedule put the session on,
+-- so a rescheduled occurrence is not generated a second time.
+CREATE TABLE IF NOT EXISTS class_occurrences (
+  occurrence_id SERIAL PRIMARY KEY,
+  schedule_id INTEGER NOT NULL,
+  occurrence_date DATE NOT NULL,
+  original_date DATE NOT NULL,
+  start_time TIME NOT NULL,
+  end_time TIME NOT NULL,
+  trainer_id INTEGER NOT NULL,
+  room_id INTEGER NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+  is_modified BOOLEAN NOT NULL DEFAULT FALSE,
+  cancellation_reason VARCHAR(255),
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  CONSTRAINT fk_occurrence_schedule FOREIGN KEY (schedule_id) REFERENCES class_schedule (schedule_id) ON DELETE CASCADE,
+  CONSTRAINT unique_occurrence UNIQUE (schedule_id, original_date),
+  CONSTRAINT chk_occurrence_status CHECK (status IN ('scheduled', 'cancelled'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_occurrences_date ON class_occurrences(occurrence_date);
+CREATE INDEX IF NOT EXISTS idx_occurrences_trainer_date ON class_occurrences(trainer_id, occurrence_date);
+CREATE INDEX IF NOT EXISTS idx_occurrences_room_date ON class_occurrences(room_id, occurrence_date);
+CREATE INDEX IF NOT EXISTS idx_occurrences_status ON class_occurrences(status);
+
+-- Bookings belong to an occurrence. Existing bookings get the occurrence of
+-- their booking date.
+ALTER TABLE class_bookings ADD COLUMN IF NOT EXISTS occurrence_id INTEGER;
+
+INSERT INTO class_occurrences (schedule_id, occurrence_date, original_date, start_time, end_time, trainer_id, room_id)
+SELECT DISTINCT cb.schedule_id, cb.booking_date::date, cb.booking_date::date, cs.start_time, cs.end_time, cs.trainer_id, cs.room_id
+FROM class_bookings cb
+JOIN class_schedule cs ON cb.schedule_id = cs.schedule_id
+WHERE cb.occurrence_id IS NULL
+ON CONFLICT ON CONSTRAINT unique_occurrence DO NOTHING;
+
+UPDATE class_bookings cb SET occurrence_id = o.occurrence_id
+FROM class_occurrences o
+WHERE cb.occurrence_id IS NULL AND o.schedule_id = cb.schedule_id AND o.original_date = cb.booking_date::date;
+
+ALTER TABLE class_bookings ALTER COLUMN occurrence_id SET NOT NULL;
+ALTER TABLE class_bookings DROP CONSTRAINT IF EXISTS fk_booking_occurrence;
+ALTER TABLE class_bookings ADD CONSTRAINT fk_booking_occurrence
+  FOREIGN KEY (occurrence_id) REFERENCES class_occurrences (occurrence_id) ON DELETE CASCADE;
+
+-- A member books each occurrence at most once, instead of each schedule once
+ALTER TABLE class_bookings DROP CONSTRAINT IF EXISTS unique_booking;
+ALTER TABLE class_bookings ADD CONSTRAINT unique_booking UNIQUE (occurrence_id, member_id);
+CREATE INDEX IF NOT EXISTS idx_bookings_occurrence_id ON class_bookings(occurrence_id);
+
+-- Bookings inserted without an occurrence, such as the sample data, are
+-- attached to the occurrence on their booking date, which is created if needed
+CREATE OR REPLACE FUNCTION assign_booking_occurrence()
+RETURNS TRIGGER AS $$
+BEGIN
+  SELECT occurrence_id INTO NEW.occurrence_id
+  FROM class_occurrences
+  WHERE schedule_id = NEW.schedule_id AND occurrence_date = NEW.booking_date::date
+  ORDER BY occurrence_id
+  LIMIT 1;
+
+  IF NEW.occurrence_id IS NULL THEN
+    INSERT INTO class_occurrences (schedule_id, occurrence_date, original_date, start_time, end_time, trainer_id, room_id)
+    SELECT schedule_id, NEW.booking_date::date, NEW.booking_date::date, start_time, end_time, trainer_id, room_id
+    FROM class_schedule
+    WHERE schedule_id = NEW.schedule_id
+    ON CONFLICT ON CONSTRAINT unique_occurrence DO NOTHING;
+
+    SELECT occurrence_id INTO NEW.occurrence_id
+    FROM class_occurrences
+    WHERE schedule_id = NEW.schedule_id AND original_date = NEW.booking_date::date;
+  END IF;
+
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_booking_occurrence ON class_bookings;
+CREATE TRIGGER trg_booking_occurrence
+BEFORE INSERT ON class_bookings
+FOR EACH ROW
+WHEN (NEW.occurrence_id IS NULL)
+EXECUTE FUNCTION assign_booking_occurrence();
+
+-- Waitlists are kept per occurrence as well
+ALTER TABLE class_waitlist ADD COLUMN IF NOT EXISTS occurrence_id INTEGER;
+
+INSERT INTO class_occurrences (schedule_id, occurrence_date, original_date, start_time, end_time, trainer_id, room_id)
+SELECT DISTINCT w.schedule_id, w.booking_date::date, w.booking_date::date, cs.start_time, cs.end_time, cs.trainer_id, cs.room_id
+FROM class_waitlist w
+JOIN class_schedule cs ON w.schedule_id = cs.schedule_id
+WHERE w.occurrence_id IS NULL
+ON CONFLICT ON CONSTRAINT unique_occurrence DO NOTHING;
+
+UPDATE class_waitlist w SET occurrence_id = o.occurrence_id
+FROM class_occurrences o
+WHERE w.occurrence_id IS NULL AND o.schedule_id = w.schedule_id AND o.original_date = w.booking_date::date;
+
+ALTER TABLE class_waitlist ALTER COLUMN occurrence_id SET NOT NULL;
+ALTER TABLE class_waitlist DROP CONSTRAINT IF EXISTS fk_waitlist_occurrence;
+ALTER TABLE class_waitlist ADD CONSTRAINT fk_waitlist_occurrence
+  FOREIGN KEY (occurrence_id) REFERENCES class_occurrences (occurrence_id) ON DELETE CASCADE;
+
+-- Entries of a cancelled occurrence are closed with the 'cancelled' status
+ALTER TABLE class_waitlist DROP CONSTRAINT IF EXISTS chk_waitlist_status;
+ALTER TABLE class_waitlist ADD CONSTRAINT chk_waitlist_status
+  CHECK (status IN ('waiting', 'offered', 'promoted', 'expired', 'left', 'cancelled'));
+
+DROP INDEX IF EXISTS idx_waitlist_active_member;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_waitlist_active_member ON class_waitlist(occurrence_id, member_id)
+  WHERE status IN ('waiting', 'offered');
+DROP INDEX IF EXISTS idx_waitlist_schedule_position;
+CREATE INDEX IF NOT EXISTS idx_waitlist_occurrence_position ON class_waitlist(occurrence_id, position);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_suspensions_member_ends_at;
+DROP TABLE IF EXISTS member_suspensions;
+
+DROP INDEX IF EXISTS idx_penalties_pending_charge;
+DROP INDEX IF EXISTS idx_penalties_member_id;
+DROP TABLE IF EXISTS booking_penalties;
+
+DROP TABLE IF EXISTS class_policies;
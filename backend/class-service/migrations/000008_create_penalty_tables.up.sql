@@ -0,0 +1,63 @@
+-- Cancellation policy of a class. Classes without a policy can be cancelled
+-- at any time and no-shows have no consequence.
+CREATE TABLE IF NOT EXISTS class_policies (
+  class_id INTEGER PRIMARY KEY,
+  cancellation_cutoff_minutes INTEGER NOT NULL DEFAULT 0,
+  late_cancel_fee DECIMAL(10,2) NOT NULL DEFAULT 0,
+  late_cancel_credits INTEGER NOT NULL DEFAULT 0,
+  no_show_fee DECIMAL(10,2) NOT NULL DEFAULT 0,
+  no_show_credits INTEGER NOT NULL DEFAULT 0,
+  no_show_limit INTEGER NOT NULL DEFAULT 0,
+  no_show_period_days INTEGER NOT NULL DEFAULT 30,
+  suspension_days INTEGER NOT NULL DEFAULT 7,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  CONSTRAINT fk_policy_class FOREIGN KEY (class_id) REFERENCES classes (class_id) ON DELETE CASCADE,
+  CONSTRAINT chk_policy_values CHECK (
+    cancellation_cutoff_minutes >= 0 AND late_cancel_fee >= 0 AND late_cancel_credits >= 0 AND
+    no_show_fee >= 0 AND no_show_credits >= 0 AND no_show_limit >= 0 AND
+    no_show_period_days > 0 AND suspension_days > 0)
+);
+
+-- Penalties for late cancellations and no-shows. Fees are posted to the
+-- payment service as pending payments; charge_status tracks whether that
+-- happened.
+CREATE TABLE IF NOT EXISTS booking_penalties (
+  penalty_id SERIAL PRIMARY KEY,
+  booking_id INTEGER NOT NULL,
+  occurrence_id INTEGER NOT NULL,
+  class_id INTEGER NOT NULL,
+  member_id INTEGER NOT NULL,
+  penalty_type VARCHAR(20) NOT NULL,
+  fee DECIMAL(10,2) NOT NULL DEFAULT 0,
+  credits INTEGER NOT NULL DEFAULT 0,
+  status VARCHAR(20) NOT NULL DEFAULT 'active',
+  charge_status VARCHAR(20) NOT NULL DEFAULT 'none',
+  payment_id INTEGER,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  CONSTRAINT fk_penalty_booking FOREIGN KEY (booking_id) REFERENCES class_bookings (booking_id) ON DELETE CASCADE,
+  CONSTRAINT unique_penalty UNIQUE (booking_id, penalty_type),
+  CONSTRAINT chk_penalty_type CHECK (penalty_type IN ('late_cancel', 'no_show')),
+  CONSTRAINT chk_penalty_status CHECK (status IN ('active', 'waived')),
+  CONSTRAINT chk_penalty_charge_status CHECK (charge_status IN ('none', 'pending', 'posted'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_penalties_member_id ON booking_penalties(member_id);
+CREATE INDEX IF NOT EXISTS idx_penalties_pending_charge ON booking_penalties(penalty_id) WHERE charge_status = 'pending';
+
+-- Members suspended from booking after too many no-shows. Lifted suspensions
+-- are kept as history.
+CREATE TABLE IF NOT EXISTS member_suspensions (
+  suspension_id SERIAL PRIMARY KEY,
+  member_id INTEGER NOT NULL,
+  class_id INTEGER NOT NULL,
+  no_show_count INTEGER NOT NULL,
+  starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  lifted_at TIMESTAMP WITH TIME ZONE,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_suspensions_member_ends_at ON member_suspensions(member_id, ends_at);
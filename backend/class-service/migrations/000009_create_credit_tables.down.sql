@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_credit_transactions_penalty_id;
+DROP INDEX IF EXISTS idx_credit_transactions_booking_id;
+DROP INDEX IF EXISTS idx_credit_transactions_member_id;
+DROP TABLE IF EXISTS credit_transactions;
+
+DROP INDEX IF EXISTS idx_credit_grants_member_id;
+DROP TABLE IF EXISTS credit_grants;
+
+DROP TABLE IF EXISTS credit_plans;
+
+ALTER TABLE class_policies DROP CONSTRAINT IF EXISTS chk_policy_credit_cost;
+ALTER TABLE class_policies DROP COLUMN IF EXISTS credit_cost;
@@ -0,0 +1,58 @@
+-- Credits a booking of the class costs. Classes that cost 0 credits can be
+-- booked without any.
+ALTER TABLE class_policies ADD COLUMN IF NOT EXISTS credit_cost INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE class_policies DROP CONSTRAINT IF EXISTS chk_policy_credit_cost;
+ALTER TABLE class_policies ADD CONSTRAINT chk_policy_credit_cost CHECK (credit_cost >= 0);
+
+-- Monthly class credits that come with a membership of the member service
+CREATE TABLE IF NOT EXISTS credit_plans (
+  membership_id INTEGER PRIMARY KEY,
+  credits_per_month INTEGER NOT NULL,
+  description VARCHAR(255),
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  CONSTRAINT chk_plan_credits CHECK (credits_per_month > 0)
+);
+
+-- Credits granted to a member, from a membership, a purchased pack or by
+-- staff. remaining is what is left of the grant to book with.
+CREATE TABLE IF NOT EXISTS credit_grants (
+  grant_id SERIAL PRIMARY KEY,
+  member_id INTEGER NOT NULL,
+  source VARCHAR(20) NOT NULL,
+  reference VARCHAR(100),
+  credits INTEGER NOT NULL,
+  remaining INTEGER NOT NULL,
+  valid_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP WITH TIME ZONE,
+  description VARCHAR(255),
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  CONSTRAINT unique_grant_reference UNIQUE (member_id, source, reference),
+  CONSTRAINT chk_grant_source CHECK (source IN ('membership', 'pack', 'manual')),
+  CONSTRAINT chk_grant_credits CHECK (credits > 0 AND remaining >= 0 AND remaining <= credits)
+);
+
+CREATE INDEX IF NOT EXISTS idx_credit_grants_member_id ON credit_grants(member_id, expires_at);
+
+-- Ledger of every change to the credits of a member. Amounts are negative
+-- for credits taken from a grant.
+CREATE TABLE IF NOT EXISTS credit_transactions (
+  transaction_id SERIAL PRIMARY KEY,
+  member_id INTEGER NOT NULL,
+  grant_id INTEGER NOT NULL,
+  booking_id INTEGER,
+  penalty_id INTEGER,
+  transaction_type VARCHAR(20) NOT NULL,
+  amount INTEGER NOT NULL,
+  description VARCHAR(255),
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  CONSTRAINT fk_transaction_grant FOREIGN KEY (grant_id) REFERENCES credit_grants (grant_id) ON DELETE CASCADE,
+  CONSTRAINT fk_transaction_booking FOREIGN KEY (booking_id) REFERENCES class_bookings (booking_id) ON DELETE SET NULL,
+  CONSTRAINT fk_transaction_penalty FOREIGN KEY (penalty_id) REFERENCES booking_penalties (penalty_id) ON DELETE SET NULL,
+  CONSTRAINT chk_transaction_type CHECK (transaction_type IN ('grant', 'debit', 'refund', 'forfeit', 'restore'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_credit_transactions_member_id ON credit_transactions(member_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_credit_transactions_booking_id ON credit_transactions(booking_id);
+CREATE INDEX IF NOT EXISTS idx_credit_transactions_penalty_id ON credit_transactions(penalty_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_calendar_feeds_subject;
+DROP TABLE IF EXISTS calendar_feeds;
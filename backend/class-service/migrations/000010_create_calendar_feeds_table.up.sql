@@ -0,0 +1,17 @@
+-- Calendar feeds that calendar apps subscribe to with a secret token instead
+-- of a login. Only the SHA-256 hash of the token is stored.
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+  feed_id SERIAL PRIMARY KEY,
+  token_hash CHAR(64) NOT NULL,
+  feed_type VARCHAR(20) NOT NULL,
+  subject_id INTEGER,
+  created_by VARCHAR(100),
+  last_accessed_at TIMESTAMP WITH TIME ZONE,
+  revoked_at TIMESTAMP WITH TIME ZONE,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  CONSTRAINT unique_calendar_token UNIQUE (token_hash),
+  CONSTRAINT chk_calendar_feed_type CHECK (feed_type IN ('schedule', 'trainer', 'room', 'member')),
+  CONSTRAINT chk_calendar_feed_subject CHECK ((feed_type = 'schedule') = (subject_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_calendar_feeds_subject ON calendar_feeds(feed_type, subject_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS closure_bookings;
+DROP INDEX IF EXISTS idx_closures_subject;
+DROP INDEX IF EXISTS idx_closures_dates;
+DROP TABLE IF EXISTS closures;
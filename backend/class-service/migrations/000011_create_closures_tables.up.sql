@@ -0,0 +1,30 @@
+-- Days on which the whole center, a room or a trainer holds no classes.
+-- subject_id is the room or trainer, and NULL for the center.
+CREATE TABLE IF NOT EXISTS closures (
+  closure_id SERIAL PRIMARY KEY,
+  scope VARCHAR(20) NOT NULL,
+  subject_id INTEGER,
+  start_date DATE NOT NULL,
+  end_date DATE NOT NULL,
+  reason VARCHAR(255) NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  CONSTRAINT chk_closure_scope CHECK (scope IN ('center', 'room', 'trainer')),
+  CONSTRAINT chk_closure_subject CHECK ((scope = 'center') = (subject_id IS NULL)),
+  CONSTRAINT chk_closure_dates CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_closures_dates ON closures(start_date, end_date);
+CREATE INDEX IF NOT EXISTS idx_closures_subject ON closures(scope, subject_id);
+
+-- Bookings cancelled because of a closure, so their members can be told
+CREATE TABLE IF NOT EXISTS closure_bookings (
+  closure_id INTEGER NOT NULL,
+  booking_id INTEGER NOT NULL,
+  occurrence_id INTEGER NOT NULL,
+  member_id INTEGER NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+  PRIMARY KEY (closure_id, booking_id),
+  CONSTRAINT fk_closure_booking_closure FOREIGN KEY (closure_id) REFERENCES closures (closure_id) ON DELETE CASCADE,
+  CONSTRAINT fk_closure_booking_booking FOREIGN KEY (booking_id) REFERENCES class_bookings (booking_id) ON DELETE CASCADE
+);
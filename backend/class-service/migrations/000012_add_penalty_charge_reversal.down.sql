@@ -0,0 +1,4 @@
+UPDATE booking_penalties SET charge_status = 'posted' WHERE charge_status = 'reversed';
+ALTER TABLE booking_penalties DROP CONSTRAINT IF EXISTS chk_penalty_charge_status;
+ALTER TABLE booking_penalties ADD CONSTRAINT chk_penalty_charge_status
+  CHECK (charge_status IN ('none', 'pending', 'posted'));
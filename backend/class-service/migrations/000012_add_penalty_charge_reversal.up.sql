@@ -0,0 +1,5 @@
+-- Fees of penalties waived after they were posted are reversed in the payment
+-- service, by marking the payment as refunded
+ALTER TABLE booking_penalties DROP CONSTRAINT IF EXISTS chk_penalty_charge_status;
+ALTER TABLE booking_penalties ADD CONSTRAINT chk_penalty_charge_status
+  CHECK (charge_status IN ('none', 'pending', 'posted', 'reversed'));
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_bookings_penalty_pending;
+ALTER TABLE class_bookings DROP COLUMN IF EXISTS penalty_pending;
@@ -0,0 +1,5 @@
+-- Bookings the no-show job marked whose penalty has not been applied yet.
+-- The job applies it after marking them and retries it on its next runs
+-- until it succeeds, so a failure does not lose the penalty.
+ALTER TABLE class_bookings ADD COLUMN IF NOT EXISTS penalty_pending BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS idx_bookings_penalty_pending ON class_bookings(booking_id) WHERE penalty_pending;
@@ -1,5 +1,16 @@
 -- This script drops all tables in the fitness_class_db database
+DROP TABLE IF EXISTS closure_bookings CASCADE;
+DROP TABLE IF EXISTS closures CASCADE;
+DROP TABLE IF EXISTS calendar_feeds CASCADE;
+DROP TABLE IF EXISTS credit_transactions CASCADE;
+DROP TABLE IF EXISTS credit_grants CASCADE;
+DROP TABLE IF EXISTS credit_plans CASCADE;
+DROP TABLE IF EXISTS member_suspensions CASCADE;
+DROP TABLE IF EXISTS booking_penalties CASCADE;
+DROP TABLE IF EXISTS class_policies CASCADE;
+DROP TABLE IF EXISTS class_waitlist CASCADE;
 DROP TABLE IF EXISTS class_bookings CASCADE;
+DROP TABLE IF EXISTS class_occurrences CASCADE;
 DROP TABLE IF EXISTS class_schedule CASCADE;
 DROP TABLE IF EXISTS classes CASCADE;
 
@@ -11,3 +22,8 @@ DROP INDEX IF EXISTS idx_class_bookings_schedule_id;
 DROP INDEX IF EXISTS idx_class_bookings_member_id;
 DROP INDEX IF EXISTS idx_unique_booking;
 DROP INDEX IF EXISTS idx_bookings_date;
+DROP FUNCTION IF EXISTS assign_booking_occurrence();
+
+-- Drop the audit log
+DROP TABLE IF EXISTS audit_log CASCADE;
+DROP FUNCTION IF EXISTS prevent_audit_log_change();
@@ -10,6 +10,7 @@ import (
 type BookingResponse struct {
 	BookingID        int       `json:"booking_id"`
 	ScheduleID       int       `json:"schedule_id"`
+	OccurrenceID     int       `json:"occurrence_id"`
 	MemberID         int       `json:"member_id"`
 	BookingDate      time.Time `json:"booking_date"`
 	AttendanceStatus string    `json:"attendance_status"`
@@ -19,15 +20,21 @@ type BookingResponse struct {
 	UpdatedAt        time.Time `json:"updated_at"`
 	ClassName        string    `json:"class_name,omitempty"`
 	DayOfWeek        string    `json:"day_of_week,omitempty"`
+	OccurrenceDate   string    `json:"occurrence_date,omitempty"`
 	StartTime        string    `json:"start_time,omitempty"`
+	EndTime          string    `json:"end_time,omitempty"`
 	TrainerID        int       `json:"trainer_id,omitempty"`
+	RoomID           int       `json:"room_id,omitempty"`
 }
 
-// BookingCreateRequest represents the request for creating a booking
+// BookingCreateRequest represents the request for creating a booking. The
+// class occurrence is given by occurrence_id, or by schedule_id and the
+// booking_date it takes place on.
 type BookingCreateRequest struct {
-	ScheduleID  int       `json:"schedule_id" binding:"required"`
-	MemberID    int       `json:"member_id" binding:"required"`
-	BookingDate time.Time `json:"booking_date" binding:"required"`
+	OccurrenceID int       `json:"occurrence_id"`
+	ScheduleID   int       `json:"schedule_id"`
+	MemberID     int       `json:"member_id" binding:"required"`
+	BookingDate  time.Time `json:"booking_date"`
 }
 
 // BookingStatusUpdateRequest represents the request for updating a booking status
@@ -44,9 +51,10 @@ type BookingFeedbackRequest struct {
 // ToModel converts BookingCreateRequest to model.BookingRequest
 func (r *BookingCreateRequest) ToModel() model.BookingRequest {
 	return model.BookingRequest{
-		ScheduleID:  r.ScheduleID,
-		MemberID:    r.MemberID,
-		BookingDate: r.BookingDate,
+		OccurrenceID: r.OccurrenceID,
+		ScheduleID:   r.ScheduleID,
+		MemberID:     r.MemberID,
+		BookingDate:  r.BookingDate,
 	}
 }
 
@@ -70,6 +78,7 @@ func BookingResponseFromModel(model model.Booking) BookingResponse {
 	return BookingResponse{
 		BookingID:        model.BookingID,
 		ScheduleID:       model.ScheduleID,
+		OccurrenceID:     model.OccurrenceID,
 		MemberID:         model.MemberID,
 		BookingDate:      model.BookingDate,
 		AttendanceStatus: model.AttendanceStatus,
@@ -85,6 +94,7 @@ func BookingResponseFromBookingResponse(model model.BookingResponse) BookingResp
 	return BookingResponse{
 		BookingID:        model.BookingID,
 		ScheduleID:       model.ScheduleID,
+		OccurrenceID:     model.OccurrenceID,
 		MemberID:         model.MemberID,
 		BookingDate:      model.BookingDate,
 		AttendanceStatus: model.AttendanceStatus,
@@ -94,8 +104,11 @@ func BookingResponseFromBookingResponse(model model.BookingResponse) BookingResp
 		UpdatedAt:        model.UpdatedAt,
 		ClassName:        model.ClassName,
 		DayOfWeek:        model.DayOfWeek,
+		OccurrenceDate:   formatDate(model.OccurrenceDate),
 		StartTime:        model.StartTime,
+		EndTime:          model.EndTime,
 		TrainerID:        model.TrainerID,
+		RoomID:           model.RoomID,
 	}
 }
 
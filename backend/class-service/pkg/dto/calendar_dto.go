@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// CalendarFeedResponse represents the response for calendar feed data
+type CalendarFeedResponse struct {
+	FeedID         int        `json:"feed_id"`
+	FeedType       string     `json:"feed_type"`
+	SubjectID      *int       `json:"subject_id,omitempty"`
+	CreatedBy      string     `json:"created_by,omitempty"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// CalendarFeedCreatedResponse represents the response for a new calendar
+// feed. The token and the URLs that contain it are only given out once.
+type CalendarFeedCreatedResponse struct {
+	CalendarFeedResponse
+	Token     string `json:"token"`
+	TokenPath string `json:"token_path"`
+	TokenURL  string `json:"token_url,omitempty"`
+}
+
+// CalendarFeedRequest represents the request for creating a calendar feed.
+// The subject is the trainer, room or member of the feed.
+type CalendarFeedRequest struct {
+	FeedType  string `json:"feed_type" binding:"required,oneof=schedule trainer room member"`
+	SubjectID int    `json:"subject_id" binding:"min=0"`
+}
+
+// ToModel converts CalendarFeedRequest to model.CalendarFeedRequest
+func (r *CalendarFeedRequest) ToModel() model.CalendarFeedRequest {
+	return model.CalendarFeedRequest{
+		FeedType:  r.FeedType,
+		SubjectID: r.SubjectID,
+	}
+}
+
+// CalendarFeedResponseFromModel converts model.CalendarFeed to CalendarFeedResponse
+func CalendarFeedResponseFromModel(model model.CalendarFeed) CalendarFeedResponse {
+	return CalendarFeedResponse{
+		FeedID:         model.FeedID,
+		FeedType:       model.FeedType,
+		SubjectID:      model.SubjectID,
+		CreatedBy:      model.CreatedBy,
+		LastAccessedAt: model.LastAccessedAt,
+		RevokedAt:      model.RevokedAt,
+		CreatedAt:      model.CreatedAt,
+	}
+}
+
+// CalendarFeedResponseListFromModel converts a list of model.CalendarFeed to a list of CalendarFeedResponse
+func CalendarFeedResponseListFromModel(models []model.CalendarFeed) []CalendarFeedResponse {
+	responses := make([]CalendarFeedResponse, len(models))
+	for i, model := range models {
+		responses[i] = CalendarFeedResponseFromModel(model)
+	}
+	return responses
+}
@@ -0,0 +1,136 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// ClosureResponse represents the response for closure data
+type ClosureResponse struct {
+	ClosureID int       `json:"closure_id"`
+	Scope     string    `json:"scope"`
+	SubjectID *int      `json:"subject_id,omitempty"`
+	StartDate string    `json:"start_date"`
+	EndDate   string    `json:"end_date"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ClosureCreatedResponse represents the response for a new closure, with the
+// classes it removed or cancelled and the members whose bookings it
+// cancelled
+type ClosureCreatedResponse struct {
+	ClosureResponse
+	RemovedOccurrences   int                     `json:"removed_occurrences"`
+	CancelledOccurrences int                     `json:"cancelled_occurrences"`
+	CancelledBookings    []ClosedBookingResponse `json:"cancelled_bookings"`
+	AffectedMemberIDs    []int                   `json:"affected_member_ids"`
+}
+
+// ClosedBookingResponse represents the response for a booking cancelled by a
+// closure
+type ClosedBookingResponse struct {
+	BookingID      int    `json:"booking_id"`
+	OccurrenceID   int    `json:"occurrence_id"`
+	MemberID       int    `json:"member_id"`
+	ClassName      string `json:"class_name"`
+	OccurrenceDate string `json:"occurrence_date"`
+	StartTime      string `json:"start_time"`
+}
+
+// ClosureRequest represents the request for creating a closure. The subject
+// is the room or trainer, and is left out for the center.
+type ClosureRequest struct {
+	Scope     string `json:"scope" binding:"required,oneof=center room trainer"`
+	SubjectID *int   `json:"subject_id" binding:"omitempty,min=1"`
+	StartDate string `json:"start_date" binding:"required"`
+	EndDate   string `json:"end_date" binding:"required"`
+	Reason    string `json:"reason" binding:"required,max=255"`
+}
+
+// ToModel converts ClosureRequest to model.Closure
+func (r *ClosureRequest) ToModel() (model.Closure, error) {
+	startDate, err := time.Parse(DateLayout, r.StartDate)
+	if err != nil {
+		return model.Closure{}, errors.New("start_date must be given as YYYY-MM-DD")
+	}
+	endDate, err := time.Parse(DateLayout, r.EndDate)
+	if err != nil {
+		return model.Closure{}, errors.New("end_date must be given as YYYY-MM-DD")
+	}
+
+	return model.Closure{
+		Scope:     r.Scope,
+		SubjectID: r.SubjectID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Reason:    r.Reason,
+	}, nil
+}
+
+// ClosureResponseFromModel converts model.Closure to ClosureResponse
+func ClosureResponseFromModel(model model.Closure) ClosureResponse {
+	return ClosureResponse{
+		ClosureID: model.ClosureID,
+		Scope:     model.Scope,
+		SubjectID: model.SubjectID,
+		StartDate: model.StartDate.Format(DateLayout),
+		EndDate:   model.EndDate.Format(DateLayout),
+		Reason:    model.Reason,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
+
+// ClosureResponseListFromModel converts a list of model.Closure to a list of ClosureResponse
+func ClosureResponseListFromModel(models []model.Closure) []ClosureResponse {
+	responses := make([]ClosureResponse, len(models))
+	for i, model := range models {
+		responses[i] = ClosureResponseFromModel(model)
+	}
+	return responses
+}
+
+// ClosureCreatedResponseFromModel converts model.ClosureResult to ClosureCreatedResponse
+func ClosureCreatedResponseFromModel(model model.ClosureResult) ClosureCreatedResponse {
+	return ClosureCreatedResponse{
+		ClosureResponse:      ClosureResponseFromModel(model.Closure),
+		RemovedOccurrences:   model.RemovedOccurrences,
+		CancelledOccurrences: model.CancelledOccurrences,
+		CancelledBookings:    ClosedBookingResponseListFromModel(model.CancelledBookings),
+		AffectedMemberIDs:    AffectedMemberIDs(model.CancelledBookings),
+	}
+}
+
+// ClosedBookingResponseListFromModel converts a list of model.ClosedBooking to a list of ClosedBookingResponse
+func ClosedBookingResponseListFromModel(models []model.ClosedBooking) []ClosedBookingResponse {
+	responses := make([]ClosedBookingResponse, len(models))
+	for i, model := range models {
+		responses[i] = ClosedBookingResponse{
+			BookingID:      model.BookingID,
+			OccurrenceID:   model.OccurrenceID,
+			MemberID:       model.MemberID,
+			ClassName:      model.ClassName,
+			OccurrenceDate: model.OccurrenceDate.Format(DateLayout),
+			StartTime:      model.StartTime,
+		}
+	}
+	return responses
+}
+
+// AffectedMemberIDs returns the members of closed bookings, each once, in the
+// order of their first booking
+func AffectedMemberIDs(models []model.ClosedBooking) []int {
+	seen := make(map[int]bool, len(models))
+	memberIDs := []int{}
+	for _, model := range models {
+		if !seen[model.MemberID] {
+			seen[model.MemberID] = true
+			memberIDs = append(memberIDs, model.MemberID)
+		}
+	}
+	return memberIDs
+}
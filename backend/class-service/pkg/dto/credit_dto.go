@@ -0,0 +1,166 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// CreditGrantResponse represents the response for credit grant data
+type CreditGrantResponse struct {
+	GrantID     int        `json:"grant_id"`
+	MemberID    int        `json:"member_id"`
+	Source      string     `json:"source"`
+	Reference   *string    `json:"reference,omitempty"`
+	Credits     int        `json:"credits"`
+	Remaining   int        `json:"remaining"`
+	ValidFrom   time.Time  `json:"valid_from"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	Description string     `json:"description,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CreditGrantRequest represents the request for granting a member a class
+// pack or credits given by staff
+type CreditGrantRequest struct {
+	MemberID    int        `json:"member_id" binding:"required"`
+	Credits     int        `json:"credits" binding:"required,min=1"`
+	Source      string     `json:"source" binding:"required,oneof=pack manual"`
+	Reference   *string    `json:"reference" binding:"omitempty,max=100"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	Description string     `json:"description" binding:"max=255"`
+}
+
+// ToModel converts CreditGrantRequest to model.CreditGrant
+func (r *CreditGrantRequest) ToModel() model.CreditGrant {
+	return model.CreditGrant{
+		MemberID:    r.MemberID,
+		Credits:     r.Credits,
+		Source:      r.Source,
+		Reference:   r.Reference,
+		ExpiresAt:   r.ExpiresAt,
+		Description: r.Description,
+	}
+}
+
+// CreditGrantResponseFromModel converts model.CreditGrant to CreditGrantResponse
+func CreditGrantResponseFromModel(model model.CreditGrant) CreditGrantResponse {
+	return CreditGrantResponse{
+		GrantID:     model.GrantID,
+		MemberID:    model.MemberID,
+		Source:      model.Source,
+		Reference:   model.Reference,
+		Credits:     model.Credits,
+		Remaining:   model.Remaining,
+		ValidFrom:   model.ValidFrom,
+		ExpiresAt:   model.ExpiresAt,
+		Description: model.Description,
+		CreatedAt:   model.CreatedAt,
+	}
+}
+
+// CreditBalanceResponse represents the response for the credit balance of a
+// member
+type CreditBalanceResponse struct {
+	MemberID int                   `json:"member_id"`
+	Balance  int                   `json:"balance"`
+	Grants   []CreditGrantResponse `json:"grants"`
+}
+
+// CreditBalanceResponseFromModel converts model.CreditBalance to CreditBalanceResponse
+func CreditBalanceResponseFromModel(model model.CreditBalance) CreditBalanceResponse {
+	grants := make([]CreditGrantResponse, len(model.Grants))
+	for i, grant := range model.Grants {
+		grants[i] = CreditGrantResponseFromModel(grant)
+	}
+
+	return CreditBalanceResponse{
+		MemberID: model.MemberID,
+		Balance:  model.Balance,
+		Grants:   grants,
+	}
+}
+
+// CreditTransactionResponse represents the response for an entry of the
+// credit ledger
+type CreditTransactionResponse struct {
+	TransactionID   int       `json:"transaction_id"`
+	MemberID        int       `json:"member_id"`
+	GrantID         int       `json:"grant_id"`
+	BookingID       *int      `json:"booking_id,omitempty"`
+	PenaltyID       *int      `json:"penalty_id,omitempty"`
+	TransactionType string    `json:"transaction_type"`
+	Amount          int       `json:"amount"`
+	Description     string    `json:"description,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// CreditTransactionResponseFromModel converts model.CreditTransaction to CreditTransactionResponse
+func CreditTransactionResponseFromModel(model model.CreditTransaction) CreditTransactionResponse {
+	return CreditTransactionResponse{
+		TransactionID:   model.TransactionID,
+		MemberID:        model.MemberID,
+		GrantID:         model.GrantID,
+		BookingID:       model.BookingID,
+		PenaltyID:       model.PenaltyID,
+		TransactionType: model.TransactionType,
+		Amount:          model.Amount,
+		Description:     model.Description,
+		CreatedAt:       model.CreatedAt,
+	}
+}
+
+// CreditTransactionResponseListFromModel converts a list of model.CreditTransaction to a list of CreditTransactionResponse
+func CreditTransactionResponseListFromModel(models []model.CreditTransaction) []CreditTransactionResponse {
+	responses := make([]CreditTransactionResponse, len(models))
+	for i, model := range models {
+		responses[i] = CreditTransactionResponseFromModel(model)
+	}
+	return responses
+}
+
+// CreditPlanResponse represents the response for the credit plan of a
+// membership
+type CreditPlanResponse struct {
+	MembershipID    int       `json:"membership_id"`
+	CreditsPerMonth int       `json:"credits_per_month"`
+	Description     string    `json:"description,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// CreditPlanRequest represents the request for setting the monthly credits
+// of a membership
+type CreditPlanRequest struct {
+	CreditsPerMonth int    `json:"credits_per_month" binding:"required,min=1"`
+	Description     string `json:"description" binding:"max=255"`
+}
+
+// ToModel converts CreditPlanRequest to model.CreditPlan
+func (r *CreditPlanRequest) ToModel(membershipID int) model.CreditPlan {
+	return model.CreditPlan{
+		MembershipID:    membershipID,
+		CreditsPerMonth: r.CreditsPerMonth,
+		Description:     r.Description,
+	}
+}
+
+// CreditPlanResponseFromModel converts model.CreditPlan to CreditPlanResponse
+func CreditPlanResponseFromModel(model model.CreditPlan) CreditPlanResponse {
+	return CreditPlanResponse{
+		MembershipID:    model.MembershipID,
+		CreditsPerMonth: model.CreditsPerMonth,
+		Description:     model.Description,
+		CreatedAt:       model.CreatedAt,
+		UpdatedAt:       model.UpdatedAt,
+	}
+}
+
+// CreditPlanResponseListFromModel converts a list of model.CreditPlan to a list of CreditPlanResponse
+func CreditPlanResponseListFromModel(models []model.CreditPlan) []CreditPlanResponse {
+	responses := make([]CreditPlanResponse, len(models))
+	for i, model := range models {
+		responses[i] = CreditPlanResponseFromModel(model)
+	}
+	return responses
+}
@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// FeedbackSummaryResponse represents the response for a feedback summary.
+// The distribution has the number of ratings of each value from 1 to 5.
+type FeedbackSummaryResponse struct {
+	ClassID        int                       `json:"class_id,omitempty"`
+	ScheduleID     int                       `json:"schedule_id,omitempty"`
+	TrainerID      int                       `json:"trainer_id,omitempty"`
+	From           string                    `json:"from,omitempty"`
+	To             string                    `json:"to,omitempty"`
+	Count          int                       `json:"count"`
+	Average        float64                   `json:"average"`
+	Distribution   map[int]int               `json:"distribution"`
+	RecentComments []FeedbackCommentResponse `json:"recent_comments"`
+}
+
+// FeedbackCommentResponse represents the response for a feedback comment.
+// The member is left out for members, who only see what was said.
+type FeedbackCommentResponse struct {
+	BookingID      int       `json:"booking_id"`
+	OccurrenceID   int       `json:"occurrence_id"`
+	ScheduleID     int       `json:"schedule_id"`
+	ClassID        int       `json:"class_id"`
+	ClassName      string    `json:"class_name"`
+	TrainerID      int       `json:"trainer_id"`
+	MemberID       int       `json:"member_id,omitempty"`
+	OccurrenceDate string    `json:"occurrence_date"`
+	Rating         int       `json:"rating"`
+	Comment        string    `json:"comment"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// TrainerRatingSyncResponse represents the response for a trainer rating sync
+type TrainerRatingSyncResponse struct {
+	Synced int `json:"synced"`
+}
+
+// FeedbackSummaryResponseFromModel converts model.FeedbackSummary to
+// FeedbackSummaryResponse, for the filter it was made with
+func FeedbackSummaryResponseFromModel(filter model.FeedbackFilter, model model.FeedbackSummary, withMembers bool) FeedbackSummaryResponse {
+	response := FeedbackSummaryResponse{
+		ClassID:        filter.ClassID,
+		ScheduleID:     filter.ScheduleID,
+		TrainerID:      filter.TrainerID,
+		Count:          model.Count,
+		Average:        model.Average,
+		Distribution:   model.Distribution,
+		RecentComments: make([]FeedbackCommentResponse, len(model.RecentComments)),
+	}
+	if filter.From != nil {
+		response.From = filter.From.Format(DateLayout)
+	}
+	if filter.To != nil {
+		response.To = filter.To.Format(DateLayout)
+	}
+
+	for i, comment := range model.RecentComments {
+		response.RecentComments[i] = FeedbackCommentResponse{
+			BookingID:      comment.BookingID,
+			OccurrenceID:   comment.OccurrenceID,
+			ScheduleID:     comment.ScheduleID,
+			ClassID:        comment.ClassID,
+			ClassName:      comment.ClassName,
+			TrainerID:      comment.TrainerID,
+			OccurrenceDate: comment.OccurrenceDate.Format(DateLayout),
+			Rating:         comment.Rating,
+			Comment:        comment.Comment,
+			UpdatedAt:      comment.UpdatedAt,
+		}
+		if withMembers {
+			response.RecentComments[i].MemberID = comment.MemberID
+		}
+	}
+	return response
+}
@@ -0,0 +1,112 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// DateLayout is the layout of dates in requests and responses
+const DateLayout = "2006-01-02"
+
+// OccurrenceResponse represents the response for class occurrence data
+type OccurrenceResponse struct {
+	OccurrenceID       int       `json:"occurrence_id"`
+	ScheduleID         int       `json:"schedule_id"`
+	ClassID            int       `json:"class_id,omitempty"`
+	ClassName          string    `json:"class_name,omitempty"`
+	OccurrenceDate     string    `json:"occurrence_date"`
+	OriginalDate       string    `json:"original_date"`
+	DayOfWeek          string    `json:"day_of_week"`
+	StartTime          string    `json:"start_time"`
+	EndTime            string    `json:"end_time"`
+	TrainerID          int       `json:"trainer_id"`
+	RoomID             int       `json:"room_id"`
+	Status             string    `json:"status"`
+	IsModified         bool      `json:"is_modified"`
+	CancellationReason string    `json:"cancellation_reason,omitempty"`
+	Capacity           int       `json:"capacity,omitempty"`
+	BookedCount        int       `json:"booked_count"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// OccurrenceRescheduleRequest represents the request for moving a single
+// occurrence. Trainer and room stay the same when left out.
+type OccurrenceRescheduleRequest struct {
+	OccurrenceDate string `json:"occurrence_date" binding:"required"`
+	StartTime      string `json:"start_time" binding:"required"`
+	EndTime        string `json:"end_time" binding:"required"`
+	TrainerID      int    `json:"trainer_id"`
+	RoomID         int    `json:"room_id"`
+}
+
+// OccurrenceCancelRequest represents the request for cancelling a single
+// occurrence
+type OccurrenceCancelRequest struct {
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+// ToModel converts OccurrenceRescheduleRequest to model.OccurrenceRescheduleRequest
+func (r *OccurrenceRescheduleRequest) ToModel() (model.OccurrenceRescheduleRequest, error) {
+	date, err := time.Parse(DateLayout, r.OccurrenceDate)
+	if err != nil {
+		return model.OccurrenceRescheduleRequest{}, errors.New("occurrence_date must be given as YYYY-MM-DD")
+	}
+
+	return model.OccurrenceRescheduleRequest{
+		OccurrenceDate: date,
+		StartTime:      r.StartTime,
+		EndTime:        r.EndTime,
+		TrainerID:      r.TrainerID,
+		RoomID:         r.RoomID,
+	}, nil
+}
+
+// OccurrenceResponseFromModel converts model.OccurrenceResponse to OccurrenceResponse
+func OccurrenceResponseFromModel(model model.OccurrenceResponse) OccurrenceResponse {
+	response := OccurrenceResponseFromOccurrence(model.Occurrence)
+	response.ClassID = model.ClassID
+	response.ClassName = model.ClassName
+	response.Capacity = model.Capacity
+	response.BookedCount = model.BookedCount
+	return response
+}
+
+// OccurrenceResponseFromOccurrence converts model.Occurrence to OccurrenceResponse
+func OccurrenceResponseFromOccurrence(model model.Occurrence) OccurrenceResponse {
+	return OccurrenceResponse{
+		OccurrenceID:       model.OccurrenceID,
+		ScheduleID:         model.ScheduleID,
+		OccurrenceDate:     formatDate(model.OccurrenceDate),
+		OriginalDate:       formatDate(model.OriginalDate),
+		DayOfWeek:          model.OccurrenceDate.Weekday().String(),
+		StartTime:          model.StartTime,
+		EndTime:            model.EndTime,
+		TrainerID:          model.TrainerID,
+		RoomID:             model.RoomID,
+		Status:             model.Status,
+		IsModified:         model.IsModified,
+		CancellationReason: model.CancellationReason,
+		CreatedAt:          model.CreatedAt,
+		UpdatedAt:          model.UpdatedAt,
+	}
+}
+
+// OccurrenceResponseListFromModel converts a list of model.OccurrenceResponse to a list of OccurrenceResponse
+func OccurrenceResponseListFromModel(models []model.OccurrenceResponse) []OccurrenceResponse {
+	responses := make([]OccurrenceResponse, len(models))
+	for i, model := range models {
+		responses[i] = OccurrenceResponseFromModel(model)
+	}
+	return responses
+}
+
+// formatDate formats a DATE value, leaving zero dates empty
+func formatDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(DateLayout)
+}
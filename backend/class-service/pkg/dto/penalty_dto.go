@@ -0,0 +1,150 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// PolicyResponse represents the response for the cancellation policy of a
+// class
+type PolicyResponse struct {
+	ClassID                   int     `json:"class_id"`
+	CancellationCutoffMinutes int     `json:"cancellation_cutoff_minutes"`
+	LateCancelFee             float64 `json:"late_cancel_fee"`
+	LateCancelCredits         int     `json:"late_cancel_credits"`
+	NoShowFee                 float64 `json:"no_show_fee"`
+	NoShowCredits             int     `json:"no_show_credits"`
+	NoShowLimit               int     `json:"no_show_limit"`
+	NoShowPeriodDays          int     `json:"no_show_period_days"`
+	SuspensionDays            int     `json:"suspension_days"`
+	CreditCost                int     `json:"credit_cost"`
+}
+
+// PolicyUpdateRequest represents the request for setting the cancellation
+// policy of a class. Zero values turn a rule off; a zero credit cost lets
+// members book without credits.
+type PolicyUpdateRequest struct {
+	CancellationCutoffMinutes int     `json:"cancellation_cutoff_minutes" binding:"min=0"`
+	LateCancelFee             float64 `json:"late_cancel_fee" binding:"min=0"`
+	LateCancelCredits         int     `json:"late_cancel_credits" binding:"min=0"`
+	NoShowFee                 float64 `json:"no_show_fee" binding:"min=0"`
+	NoShowCredits             int     `json:"no_show_credits" binding:"min=0"`
+	NoShowLimit               int     `json:"no_show_limit" binding:"min=0"`
+	NoShowPeriodDays          int     `json:"no_show_period_days" binding:"min=0"`
+	SuspensionDays            int     `json:"suspension_days" binding:"min=0"`
+	CreditCost                int     `json:"credit_cost" binding:"min=0"`
+}
+
+// ToModel converts PolicyUpdateRequest to model.PolicyRequest
+func (r *PolicyUpdateRequest) ToModel() model.PolicyRequest {
+	return model.PolicyRequest{
+		CancellationCutoffMinutes: r.CancellationCutoffMinutes,
+		LateCancelFee:             r.LateCancelFee,
+		LateCancelCredits:         r.LateCancelCredits,
+		NoShowFee:                 r.NoShowFee,
+		NoShowCredits:             r.NoShowCredits,
+		NoShowLimit:               r.NoShowLimit,
+		NoShowPeriodDays:          r.NoShowPeriodDays,
+		SuspensionDays:            r.SuspensionDays,
+		CreditCost:                r.CreditCost,
+	}
+}
+
+// PolicyResponseFromModel converts model.CancellationPolicy to PolicyResponse
+func PolicyResponseFromModel(model model.CancellationPolicy) PolicyResponse {
+	return PolicyResponse{
+		ClassID:                   model.ClassID,
+		CancellationCutoffMinutes: model.CancellationCutoffMinutes,
+		LateCancelFee:             model.LateCancelFee,
+		LateCancelCredits:         model.LateCancelCredits,
+		NoShowFee:                 model.NoShowFee,
+		NoShowCredits:             model.NoShowCredits,
+		NoShowLimit:               model.NoShowLimit,
+		NoShowPeriodDays:          model.NoShowPeriodDays,
+		SuspensionDays:            model.SuspensionDays,
+		CreditCost:                model.CreditCost,
+	}
+}
+
+// PenaltyResponse represents the response for penalty data
+type PenaltyResponse struct {
+	PenaltyID    int       `json:"penalty_id"`
+	BookingID    int       `json:"booking_id"`
+	OccurrenceID int       `json:"occurrence_id"`
+	ClassID      int       `json:"class_id"`
+	MemberID     int       `json:"member_id"`
+	PenaltyType  string    `json:"penalty_type"`
+	Fee          float64   `json:"fee"`
+	Credits      int       `json:"credits"`
+	Status       string    `json:"status"`
+	ChargeStatus string    `json:"charge_status"`
+	PaymentID    *int      `json:"payment_id,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// PenaltyResponseFromModel converts model.Penalty to PenaltyResponse
+func PenaltyResponseFromModel(model model.Penalty) PenaltyResponse {
+	return PenaltyResponse{
+		PenaltyID:    model.PenaltyID,
+		BookingID:    model.BookingID,
+		OccurrenceID: model.OccurrenceID,
+		ClassID:      model.ClassID,
+		MemberID:     model.MemberID,
+		PenaltyType:  model.PenaltyType,
+		Fee:          model.Fee,
+		Credits:      model.Credits,
+		Status:       model.Status,
+		ChargeStatus: model.ChargeStatus,
+		PaymentID:    model.PaymentID,
+		CreatedAt:    model.CreatedAt,
+		UpdatedAt:    model.UpdatedAt,
+	}
+}
+
+// PenaltyResponseListFromModel converts a list of model.Penalty to a list of PenaltyResponse
+func PenaltyResponseListFromModel(models []model.Penalty) []PenaltyResponse {
+	responses := make([]PenaltyResponse, len(models))
+	for i, model := range models {
+		responses[i] = PenaltyResponseFromModel(model)
+	}
+	return responses
+}
+
+// SuspensionResponse represents the response for suspension data
+type SuspensionResponse struct {
+	SuspensionID int        `json:"suspension_id"`
+	MemberID     int        `json:"member_id"`
+	ClassID      int        `json:"class_id"`
+	NoShowCount  int        `json:"no_show_count"`
+	StartsAt     time.Time  `json:"starts_at"`
+	EndsAt       time.Time  `json:"ends_at"`
+	LiftedAt     *time.Time `json:"lifted_at,omitempty"`
+	IsActive     bool       `json:"is_active"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// SuspensionResponseFromModel converts model.Suspension to SuspensionResponse
+func SuspensionResponseFromModel(model model.Suspension) SuspensionResponse {
+	return SuspensionResponse{
+		SuspensionID: model.SuspensionID,
+		MemberID:     model.MemberID,
+		ClassID:      model.ClassID,
+		NoShowCount:  model.NoShowCount,
+		StartsAt:     model.StartsAt,
+		EndsAt:       model.EndsAt,
+		LiftedAt:     model.LiftedAt,
+		IsActive:     model.IsActive(time.Now()),
+		CreatedAt:    model.CreatedAt,
+	}
+}
+
+// SuspensionResponseListFromModel converts a list of model.Suspension to a list of SuspensionResponse
+func SuspensionResponseListFromModel(models []model.Suspension) []SuspensionResponse {
+	responses := make([]SuspensionResponse, len(models))
+	for i, model := range models {
+		responses[i] = SuspensionResponseFromModel(model)
+	}
+	return responses
+}
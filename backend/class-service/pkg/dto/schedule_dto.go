@@ -44,6 +44,31 @@ type ScheduleUpdateRequest struct {
 	Status    string `json:"status"`
 }
 
+// ScheduleConflictCheckRequest represents the query of a dry-run conflict
+// check. schedule_id names the schedule being edited.
+type ScheduleConflictCheckRequest struct {
+	ScheduleID int    `form:"schedule_id"`
+	TrainerID  int    `form:"trainer_id" binding:"required"`
+	RoomID     int    `form:"room_id" binding:"required"`
+	DayOfWeek  string `form:"day_of_week" binding:"required,oneof=Monday Tuesday Wednesday Thursday Friday Saturday Sunday"`
+	StartTime  string `form:"start_time" binding:"required"`
+	EndTime    string `form:"end_time" binding:"required"`
+}
+
+// ScheduleConflictResponse represents a schedule that clashes with another
+type ScheduleConflictResponse struct {
+	ScheduleID      int    `json:"schedule_id"`
+	ClassID         int    `json:"class_id"`
+	ClassName       string `json:"class_name"`
+	TrainerID       int    `json:"trainer_id"`
+	RoomID          int    `json:"room_id"`
+	DayOfWeek       string `json:"day_of_week"`
+	StartTime       string `json:"start_time"`
+	EndTime         string `json:"end_time"`
+	TrainerConflict bool   `json:"trainer_conflict"`
+	RoomConflict    bool   `json:"room_conflict"`
+}
+
 // ToModel converts ScheduleCreateRequest to model.ScheduleRequest
 func (r *ScheduleCreateRequest) ToModel() model.ScheduleRequest {
 	return model.ScheduleRequest{
@@ -112,3 +137,35 @@ func ScheduleResponseListFromModel(models []model.ScheduleResponse) []ScheduleRe
 	}
 	return responses
 }
+
+// ToModel converts ScheduleConflictCheckRequest to model.ScheduleConflictCheck
+func (r *ScheduleConflictCheckRequest) ToModel() model.ScheduleConflictCheck {
+	return model.ScheduleConflictCheck{
+		ScheduleID: r.ScheduleID,
+		TrainerID:  r.TrainerID,
+		RoomID:     r.RoomID,
+		DayOfWeek:  r.DayOfWeek,
+		StartTime:  r.StartTime,
+		EndTime:    r.EndTime,
+	}
+}
+
+// ScheduleConflictListFromModel converts a list of model.ScheduleConflict to a list of ScheduleConflictResponse
+func ScheduleConflictListFromModel(models []model.ScheduleConflict) []ScheduleConflictResponse {
+	responses := make([]ScheduleConflictResponse, len(models))
+	for i, model := range models {
+		responses[i] = ScheduleConflictResponse{
+			ScheduleID:      model.ScheduleID,
+			ClassID:         model.ClassID,
+			ClassName:       model.ClassName,
+			TrainerID:       model.TrainerID,
+			RoomID:          model.RoomID,
+			DayOfWeek:       model.DayOfWeek,
+			StartTime:       model.StartTime,
+			EndTime:         model.EndTime,
+			TrainerConflict: model.TrainerConflict,
+			RoomConflict:    model.RoomConflict,
+		}
+	}
+	return responses
+}
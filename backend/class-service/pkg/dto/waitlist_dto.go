@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/class-service/internal/model"
+)
+
+// WaitlistResponse represents the response for waitlist data
+type WaitlistResponse struct {
+	WaitlistID     int        `json:"waitlist_id"`
+	ScheduleID     int        `json:"schedule_id"`
+	OccurrenceID   int        `json:"occurrence_id"`
+	MemberID       int        `json:"member_id"`
+	BookingDate    time.Time  `json:"booking_date"`
+	Status         string     `json:"status"`
+	QueuePosition  int        `json:"queue_position,omitempty"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty"`
+	BookingID      *int       `json:"booking_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	ClassName      string     `json:"class_name,omitempty"`
+	DayOfWeek      string     `json:"day_of_week,omitempty"`
+	StartTime      string     `json:"start_time,omitempty"`
+}
+
+// WaitlistJoinRequest represents the request for joining a waitlist. The
+// class occurrence is given like in a BookingCreateRequest.
+type WaitlistJoinRequest struct {
+	OccurrenceID int       `json:"occurrence_id"`
+	ScheduleID   int       `json:"schedule_id"`
+	MemberID     int       `json:"member_id" binding:"required"`
+	BookingDate  time.Time `json:"booking_date"`
+}
+
+// ToModel converts WaitlistJoinRequest to model.WaitlistRequest
+func (r *WaitlistJoinRequest) ToModel() model.WaitlistRequest {
+	return model.WaitlistRequest{
+		OccurrenceID: r.OccurrenceID,
+		ScheduleID:   r.ScheduleID,
+		MemberID:     r.MemberID,
+		BookingDate:  r.BookingDate,
+	}
+}
+
+// WaitlistResponseFromModel converts model.WaitlistResponse to WaitlistResponse
+func WaitlistResponseFromModel(model model.WaitlistResponse) WaitlistResponse {
+	response := WaitlistResponseFromEntry(model.WaitlistEntry)
+	response.QueuePosition = model.QueuePosition
+	response.ClassName = model.ClassName
+	response.DayOfWeek = model.DayOfWeek
+	response.StartTime = model.StartTime
+	return response
+}
+
+// WaitlistResponseFromEntry converts model.WaitlistEntry to WaitlistResponse
+func WaitlistResponseFromEntry(model model.WaitlistEntry) WaitlistResponse {
+	return WaitlistResponse{
+		WaitlistID:     model.WaitlistID,
+		ScheduleID:     model.ScheduleID,
+		OccurrenceID:   model.OccurrenceID,
+		MemberID:       model.MemberID,
+		BookingDate:    model.BookingDate,
+		Status:         model.Status,
+		OfferExpiresAt: model.OfferExpiresAt,
+		BookingID:      model.BookingID,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+// WaitlistResponseListFromModel converts a list of model.WaitlistResponse to a list of WaitlistResponse
+func WaitlistResponseListFromModel(models []model.WaitlistResponse) []WaitlistResponse {
+	responses := make([]WaitlistResponse, len(models))
+	for i, model := range models {
+		responses[i] = WaitlistResponseFromModel(model)
+	}
+	return responses
+}
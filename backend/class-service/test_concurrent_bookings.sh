@@ -0,0 +1,211 @@
+#!/bin/bash
+
+# Fires parallel bookings at a class occurrence with one seat left and checks
+# that exactly one of them gets the seat.
+#
+# The test books made-up members with a made-up trainer and room, so run the
+# service with the cross-service checks turned off by setting the service URLs
+# to empty values, e.g.
+#   CLASS_SERVICE_MEMBER_SERVICE_URL= CLASS_SERVICE_STAFF_SERVICE_URL= \
+#   CLASS_SERVICE_FACILITY_SERVICE_URL= go run cmd/main.go
+# or, for the container,
+#   CLASS_SERVICE_MEMBER_SERVICE_URL= CLASS_SERVICE_STAFF_SERVICE_URL= \
+#   CLASS_SERVICE_FACILITY_SERVICE_URL= docker compose up -d class-service
+# The .env file must not set these URLs, as it is read before the defaults.
+
+# Colors for output
+RED='\033[0;31m'
+GREEN='\033[0;32m'
+YELLOW='\033[0;33m'
+BLUE='\033[0;34m'
+CYAN='\033[0;36m'
+NC='\033[0m' # No Color
+
+# Service configuration
+BASE_URL="http://localhost:8005"
+API_BASE="${BASE_URL}/api/v1"
+
+# Test configuration
+CAPACITY=5
+PARALLEL_BOOKINGS=20
+
+# Test counters
+TOTAL_TESTS=0
+PASSED_TESTS=0
+FAILED_TESTS=0
+
+# Function to print test headers
+print_test_header() {
+    echo -e "\n${BLUE}========================================${NC}"
+    echo -e "${BLUE}$1${NC}"
+    echo -e "${BLUE}========================================${NC}"
+}
+
+# Function to print test results
+print_result() {
+    local test_name="$1"
+    local expected="$2"
+    local actual="$3"
+    local details="$4"
+
+    TOTAL_TESTS=$((TOTAL_TESTS + 1))
+
+    if [ "$expected" = "$actual" ]; then
+        echo -e "${GREEN}✓ PASS${NC} - $test_name (Expected: $expected, Got: $actual)"
+        PASSED_TESTS=$((PASSED_TESTS + 1))
+    else
+        echo -e "${RED}✗ FAIL${NC} - $test_name (Expected: $expected, Got: $actual)"
+        if [ -n "$details" ]; then
+            echo -e "${YELLOW}Response: $details${NC}"
+        fi
+        FAILED_TESTS=$((FAILED_TESTS + 1))
+    fi
+}
+
+# Function to POST JSON data, printing the response body followed by the
+# status code on its own line
+post_json() {
+    curl -s -w "\n%{http_code}" -X POST \
+        -H "Content-Type: application/json" \
+        -d "$2" \
+        "$1"
+}
+
+# Function to extract a numeric field from JSON response
+extract_field() {
+    echo "$1" | grep -o "\"$2\":[0-9]*" | cut -d':' -f2 | head -1
+}
+
+# Function to wait for service to be ready
+wait_for_service() {
+    echo -e "${YELLOW}Waiting for service to be ready...${NC}"
+    local max_attempts=30
+    local attempt=1
+
+    while [ $attempt -le $max_attempts ]; do
+        if curl -s "$BASE_URL/health" > /dev/null 2>&1; then
+            echo -e "${GREEN}Service is ready!${NC}"
+            return 0
+        fi
+        echo -n "."
+        sleep 2
+        attempt=$((attempt + 1))
+    done
+
+    echo -e "\n${RED}Service failed to start within timeout${NC}"
+    exit 1
+}
+
+# Function to print the summary and exit
+finish() {
+    print_test_header "TEST SUMMARY"
+    echo -e "${CYAN}Total Tests: $TOTAL_TESTS${NC}"
+    echo -e "${GREEN}Passed: $PASSED_TESTS${NC}"
+    echo -e "${RED}Failed: $FAILED_TESTS${NC}"
+
+    if [ $FAILED_TESTS -eq 0 ]; then
+        echo -e "\n${GREEN}🎉 All tests passed!${NC}"
+        exit 0
+    else
+        echo -e "\n${RED}❌ Some tests failed. Please check the output above.${NC}"
+        exit 1
+    fi
+}
+
+# Main test execution
+main() {
+    echo -e "${CYAN}Class Service Concurrent Booking Test${NC}"
+    echo -e "${CYAN}=====================================${NC}"
+
+    wait_for_service
+
+    # Member, trainer and room IDs are picked from a high range so the test
+    # does not collide with sample data or earlier runs
+    local run_id=$(( (RANDOM % 9000) + 1000 ))
+    local member_base=$(( run_id * 100 ))
+
+    print_test_header "SETUP"
+
+    class_data="{
+        \"class_name\": \"Concurrency Test $run_id\",
+        \"description\": \"Class used by the concurrent booking test\",
+        \"duration\": 60,
+        \"capacity\": $CAPACITY,
+        \"difficulty\": \"Beginner\",
+        \"is_active\": true
+    }"
+    response=$(post_json "$API_BASE/classes" "$class_data")
+    class_id=$(extract_field "$(echo "$response" | head -n -1)" "class_id")
+    print_result "Create class with capacity $CAPACITY" 201 "$(echo "$response" | tail -n1)" "$response"
+    [ -z "$class_id" ] && finish
+
+    # Schedule the class tomorrow so its next occurrence is in the future
+    day_of_week=$(date -u -d tomorrow +%A)
+    occurrence_date=$(date -u -d tomorrow +%Y-%m-%d)
+    schedule_data="{
+        \"class_id\": $class_id,
+        \"trainer_id\": $run_id,
+        \"room_id\": $run_id,
+        \"start_time\": \"20:00:00\",
+        \"end_time\": \"21:00:00\",
+        \"day_of_week\": \"$day_of_week\",
+        \"status\": \"active\"
+    }"
+    response=$(post_json "$API_BASE/schedules" "$schedule_data")
+    schedule_id=$(extract_field "$(echo "$response" | head -n -1)" "schedule_id")
+    print_result "Create schedule on $day_of_week" 201 "$(echo "$response" | tail -n1)" "$response"
+    [ -z "$schedule_id" ] && finish
+
+    response=$(curl -s "$API_BASE/occurrences?schedule_id=$schedule_id&from=$occurrence_date&to=$occurrence_date")
+    occurrence_id=$(extract_field "$response" "occurrence_id")
+    print_result "Find occurrence on $occurrence_date" "found" "${occurrence_id:+found}" "$response"
+    [ -z "$occurrence_id" ] && finish
+
+    # Fill all seats but one
+    local booked=0
+    for i in $(seq 1 $((CAPACITY - 1))); do
+        booking_data="{\"occurrence_id\": $occurrence_id, \"member_id\": $((member_base + i))}"
+        code=$(post_json "$API_BASE/bookings" "$booking_data" | tail -n1)
+        [ "$code" = "201" ] && booked=$((booked + 1))
+    done
+    print_result "Book all seats but one" $((CAPACITY - 1)) "$booked"
+
+    print_test_header "CONCURRENT BOOKING TESTS"
+
+    # Every request runs in its own background job and writes its status
+    # code to a file, so all of them race for the last seat
+    results_dir=$(mktemp -d)
+    for i in $(seq 1 $PARALLEL_BOOKINGS); do
+        booking_data="{\"occurrence_id\": $occurrence_id, \"member_id\": $((member_base + CAPACITY + i))}"
+        (post_json "$API_BASE/bookings" "$booking_data" | tail -n1 > "$results_dir/$i") &
+    done
+    wait
+
+    created=$(cat "$results_dir"/* | grep -c '^201$')
+    rejected=$(cat "$results_dir"/* | grep -c '^400$')
+    rm -rf "$results_dir"
+
+    echo -e "${CYAN}Fired $PARALLEL_BOOKINGS parallel bookings for the last seat${NC}"
+    print_result "Exactly one parallel booking succeeds" 1 "$created"
+    print_result "Other parallel bookings are rejected as full" $((PARALLEL_BOOKINGS - 1)) "$rejected"
+
+    response=$(curl -s "$API_BASE/occurrences/$occurrence_id")
+    booked_count=$(extract_field "$response" "booked_count")
+    print_result "Occurrence is booked to capacity, not beyond" "$CAPACITY" "$booked_count" "$response"
+
+    # A further booking after the race must still be refused
+    booking_data="{\"occurrence_id\": $occurrence_id, \"member_id\": $((member_base + 99))}"
+    response=$(post_json "$API_BASE/bookings" "$booking_data")
+    print_result "Booking a full occurrence is rejected" 400 "$(echo "$response" | tail -n1)" "$response"
+
+    finish
+}
+
+# Check if service URL is provided as argument
+if [ $# -eq 1 ]; then
+    BASE_URL="$1"
+    API_BASE="${BASE_URL}/api/v1"
+fi
+
+# Run the tests
+main
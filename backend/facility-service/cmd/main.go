@@ -42,7 +42,7 @@ func main() {
 		port = strconv.Itoa(cfg.ServerPort)
 	}
 
-	srv := server.NewServer(h, port)
+	srv := server.NewServer(h, port, repo.Audit())
 
 	log.Println("Starting facility service...")
 	if err := srv.Start(); err != nil {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/facility-service/internal/model"
 	"github.com/FurkanArikk/fitness-center/backend/facility-service/internal/repository"
 	"gorm.io/gorm"
@@ -46,6 +47,7 @@ func (r *attendanceRepository) Update(ctx context.Context, attendance *model.Att
 		}
 		return nil, fmt.Errorf("checking attendance existence: %w", err)
 	}
+	audit.Before(ctx, &existingAttendance)
 
 	// Update the record
 	result := r.db.WithContext(ctx).Model(&existingAttendance).Updates(map[string]interface{}{
@@ -79,6 +81,7 @@ func (r *attendanceRepository) Delete(ctx context.Context, id int) error {
 		}
 		return fmt.Errorf("checking attendance existence: %w", err)
 	}
+	audit.Before(ctx, &attendance)
 
 	// Delete the record
 	result := r.db.WithContext(ctx).Delete(&model.Attendance{}, id)
@@ -147,6 +150,7 @@ func (r *attendanceRepository) CheckOut(ctx context.Context, attendanceID int, c
 	if attendance.CheckOutTime != nil {
 		return fmt.Errorf("member has already checked out at %v", attendance.CheckOutTime.Format("15:04:05"))
 	}
+	audit.Before(ctx, &attendance)
 
 	// Update with check-out time
 	result := r.db.WithContext(ctx).Model(&model.Attendance{}).Where("attendance_id = ?", attendanceID).Update("check_out_time", checkOutTime)
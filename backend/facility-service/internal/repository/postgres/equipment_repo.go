@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/facility-service/internal/model"
 	"github.com/FurkanArikk/fitness-center/backend/facility-service/internal/repository"
 	"gorm.io/gorm"
@@ -38,6 +39,12 @@ func (r *equipmentRepository) GetByID(ctx context.Context, id int) (*model.Equip
 
 // Update updates an equipment record
 func (r *equipmentRepository) Update(ctx context.Context, equipment *model.Equipment) (*model.Equipment, error) {
+	// Keep the current state for the audit log
+	var existingEquipment model.Equipment
+	if err := r.db.WithContext(ctx).First(&existingEquipment, equipment.EquipmentID).Error; err == nil {
+		audit.Before(ctx, &existingEquipment)
+	}
+
 	if err := r.db.WithContext(ctx).Save(equipment).Error; err != nil {
 		return nil, fmt.Errorf("updating equipment: %w", err)
 	}
@@ -46,6 +53,12 @@ func (r *equipmentRepository) Update(ctx context.Context, equipment *model.Equip
 
 // Delete removes an equipment record
 func (r *equipmentRepository) Delete(ctx context.Context, id int) error {
+	// Keep the current state for the audit log
+	var existingEquipment model.Equipment
+	if err := r.db.WithContext(ctx).First(&existingEquipment, id).Error; err == nil {
+		audit.Before(ctx, &existingEquipment)
+	}
+
 	result := r.db.WithContext(ctx).Delete(&model.Equipment{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("deleting equipment: %w", result.Error)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/facility-service/internal/model"
 	"github.com/FurkanArikk/fitness-center/backend/facility-service/internal/repository"
 	"gorm.io/gorm"
@@ -54,6 +55,7 @@ func (r *facilityRepository) Update(ctx context.Context, facility *model.Facilit
 		}
 		return nil, fmt.Errorf("checking facility existence: %w", err)
 	}
+	audit.Before(ctx, &existingFacility)
 
 	// Check if name already exists for a different facility
 	if facility.Name != existingFacility.Name {
@@ -89,6 +91,12 @@ func (r *facilityRepository) Update(ctx context.Context, facility *model.Facilit
 
 // Delete removes a facility record (soft delete)
 func (r *facilityRepository) Delete(ctx context.Context, id int) error {
+	// Keep the current state for the audit log
+	var existingFacility model.Facility
+	if err := r.db.WithContext(ctx).Where("facility_id = ? AND is_deleted = ?", id, false).First(&existingFacility).Error; err == nil {
+		audit.Before(ctx, &existingFacility)
+	}
+
 	result := r.db.WithContext(ctx).Model(&model.Facility{}).Where("facility_id = ?", id).Update("is_deleted", true)
 	if result.Error != nil {
 		return fmt.Errorf("soft deleting facility: %w", result.Error)
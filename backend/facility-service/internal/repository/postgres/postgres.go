@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/facility-service/internal/repository"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -17,6 +18,7 @@ type PostgresRepository struct {
 	equipmentRepo  repository.EquipmentRepository
 	facilityRepo   repository.FacilityRepository
 	attendanceRepo repository.AttendanceRepository
+	auditRepo      audit.Store
 }
 
 // NewPostgresRepository creates a new PostgreSQL repository
@@ -47,6 +49,7 @@ func NewPostgresRepository(connectionString string) (repository.Repository, erro
 	repo.equipmentRepo = NewEquipmentRepository(db)
 	repo.facilityRepo = NewFacilityRepository(db)
 	repo.attendanceRepo = NewAttendanceRepository(db)
+	repo.auditRepo = NewAuditRepository(db)
 
 	return repo, nil
 }
@@ -75,6 +78,11 @@ func (r *PostgresRepository) Attendance() repository.AttendanceRepository {
 	return r.attendanceRepo
 }
 
+// Audit returns the audit log repository
+func (r *PostgresRepository) Audit() audit.Store {
+	return r.auditRepo
+}
+
 // Close closes the database connection
 func (r *PostgresRepository) Close() error {
 	sqlDB, err := r.db.DB()
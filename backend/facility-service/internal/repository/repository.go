@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/facility-service/internal/model"
 )
 
@@ -48,6 +49,7 @@ type Repository interface {
 	Equipment() EquipmentRepository
 	Facility() FacilityRepository
 	Attendance() AttendanceRepository
+	Audit() audit.Store
 	Ping(ctx context.Context) error // Add Ping method to check database connectivity
 	Close() error
 }
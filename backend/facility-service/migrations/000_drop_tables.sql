@@ -17,3 +17,7 @@ DROP INDEX IF EXISTS idx_attendance_check_in;
 -- Drop triggers and functions
 DROP TRIGGER IF EXISTS trg_set_attendance_date ON attendance;
 DROP FUNCTION IF EXISTS set_attendance_date();
+
+-- Drop the audit log
+DROP TABLE IF EXISTS audit_log CASCADE;
+DROP FUNCTION IF EXISTS prevent_audit_log_change();
@@ -0,0 +1,196 @@
+package identity
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// tokenIssuer is the issuer of the tokens signed by the auth service
+	tokenIssuer = "fitness-center-auth"
+	// jwksCacheTTL is how long fetched keys are used before they are fetched
+	// again. It matches the max-age the auth service publishes them with.
+	jwksCacheTTL = 5 * time.Minute
+	// jwksRetryInterval limits fetching the keys again for tokens signed
+	// with a key that is not known yet
+	jwksRetryInterval = 10 * time.Second
+)
+
+// ErrInvalidToken is returned for tokens that are malformed, expired or not
+// signed by the auth service
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// TokenClaims are the claims of an access token issued by the auth service
+type TokenClaims struct {
+	Username string   `json:"username"`
+	Roles    []string `json:"roles"`
+	ClientID string   `json:"client_id,omitempty"`
+	Scope    string   `json:"scope,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// HasRole reports whether the token carries the given role
+func (c *TokenClaims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope reports whether the token was granted the given scope
+func (c *TokenClaims) HasScope(scope string) bool {
+	for _, s := range strings.Fields(c.Scope) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Verifier checks access tokens against the public keys the auth service
+// publishes at its JWKS endpoint. Unlike the gateway's check, it cannot tell
+// whether a service client was deactivated after the token was issued, so
+// tokens stay valid until they expire.
+type Verifier struct {
+	jwksURL string
+	client  *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+// NewVerifier creates a verifier for tokens signed with the keys published at
+// jwksURL
+func NewVerifier(jwksURL string) *Verifier {
+	return &Verifier{
+		jwksURL: jwksURL,
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Verify checks the signature, issuer and expiry of a token and returns its
+// claims
+func (v *Verifier) Verify(tokenString string) (*TokenClaims, error) {
+	claims := &TokenClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, v.keyfunc,
+		jwt.WithValidMethods([]string{"RS256", "EdDSA"}),
+		jwt.WithIssuer(tokenIssuer),
+		jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return claims, nil
+}
+
+// keyfunc resolves the verification key of a token from its kid header. The
+// keys are fetched again once they are stale, or when the token names a key
+// that is not known yet, such as one published by a rotation.
+func (v *Verifier) keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("token has no key ID")
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	key, ok := v.keys[kid]
+	age := time.Since(v.fetchedAt)
+	if age > jwksCacheTTL || (!ok && age > jwksRetryInterval) {
+		keys, err := v.fetch()
+		if err != nil {
+			// Known keys are still used while the auth service is unreachable
+			if ok {
+				return key, nil
+			}
+			return nil, err
+		}
+		v.keys = keys
+		v.fetchedAt = time.Now()
+		key, ok = keys[kid]
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %s", kid)
+	}
+	return key, nil
+}
+
+// jwk is a public key of the JWKS endpoint
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+}
+
+// fetch loads the published keys by key ID
+func (v *Verifier) fetch() (map[string]interface{}, error) {
+	resp, err := v.client.Get(v.jwksURL)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching signing keys: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching signing keys: status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("error decoding signing keys: %v", err)
+	}
+
+	keys := make(map[string]interface{}, len(body.Keys))
+	for _, k := range body.Keys {
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("error decoding signing key %s: %v", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+// publicKey decodes an RSA or Ed25519 public key
+func (k jwk) publicKey() (interface{}, error) {
+	switch {
+	case k.Kty == "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case k.Kty == "OKP" && k.Crv == "Ed25519":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+}
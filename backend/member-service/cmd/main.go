@@ -53,7 +53,7 @@ func main() {
 	)
 
 	// Setup HTTP server
-	srv := server.NewServer(h, strconv.Itoa(cfg.Server.Port))
+	srv := server.NewServer(h, strconv.Itoa(cfg.Server.Port), repos.AuditRepo)
 
 	// Handle graceful shutdown
 	quit := make(chan os.Signal, 1)
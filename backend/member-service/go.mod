@@ -3,6 +3,8 @@ module github.com/FurkanArikk/fitness-center/backend/member-service
 go 1.23
 
 require (
+	github.com/FurkanArikk/fitness-center/backend/audit v0.0.0
+	github.com/FurkanArikk/fitness-center/backend/identity v0.0.0
 	github.com/gin-gonic/gin v1.10.0
 	github.com/joho/godotenv v1.5.1
 	gorm.io/driver/postgres v1.5.7
@@ -20,6 +22,7 @@ require (
 	github.com/go-playground/universal-translator v0.18.1 // indirect
 	github.com/go-playground/validator/v10 v10.20.0 // indirect
 	github.com/goccy/go-json v0.10.2 // indirect
+	github.com/golang-jwt/jwt/v5 v5.2.0 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20221227161230-091c0ba34f0a // indirect
 	github.com/jackc/pgx/v5 v5.4.3 // indirect
@@ -44,3 +47,7 @@ require (
 	google.golang.org/protobuf v1.34.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/FurkanArikk/fitness-center/backend/audit => ../audit
+
+replace github.com/FurkanArikk/fitness-center/backend/identity => ../identity
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/member-service/internal/model"
 	"github.com/FurkanArikk/fitness-center/backend/member-service/internal/repository/postgres"
 	"gorm.io/gorm"
@@ -13,6 +14,7 @@ type Repository struct {
 	BenefitRepo          model.BenefitRepository
 	MemberMembershipRepo model.MemberMembershipRepository
 	AssessmentRepo       model.FitnessAssessmentRepository
+	AuditRepo            audit.Store
 }
 
 // NewRepositories creates a new repository factory with all repositories
@@ -23,6 +25,7 @@ func NewRepositories(db *gorm.DB) *Repository {
 		BenefitRepo:          postgres.NewBenefitRepository(db),
 		MemberMembershipRepo: postgres.NewMemberMembershipRepository(db),
 		AssessmentRepo:       postgres.NewAssessmentRepository(db),
+		AuditRepo:            postgres.NewAuditRepository(db),
 	}
 }
 
@@ -50,3 +53,8 @@ func NewMemberMembershipRepository(db *gorm.DB) model.MemberMembershipRepository
 func NewAssessmentRepository(db *gorm.DB) model.FitnessAssessmentRepository {
 	return postgres.NewAssessmentRepository(db)
 }
+
+// NewAuditRepository creates a new audit log repository
+func NewAuditRepository(db *gorm.DB) audit.Store {
+	return postgres.NewAuditRepository(db)
+}
@@ -1,12 +1,13 @@
 package server
 
 import (
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/member-service/internal/handler"
 	"github.com/gin-gonic/gin"
 )
 
 // setupRoutes configures all API routes for the application
-func setupRoutes(router *gin.Engine, handler *handler.Handler) {
+func setupRoutes(router *gin.Engine, handler *handler.Handler, auditStore audit.Store) {
 	// Health check endpoint
 	router.GET("/health", handler.HealthCheck)
 
@@ -65,5 +66,8 @@ func setupRoutes(router *gin.Engine, handler *handler.Handler) {
 			memberMemberships.PUT("/:id", handler.MemberMembershipHandler.UpdateMemberMembership)
 			memberMemberships.DELETE("/:id", handler.MemberMembershipHandler.DeleteMemberMembership)
 		}
+
+		// Audit trail of changes made through this service
+		api.GET("/audit/members", audit.ListHandler(auditStore))
 	}
 }
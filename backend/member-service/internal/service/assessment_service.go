@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/member-service/internal/model"
 )
 
@@ -57,10 +58,11 @@ func (s *AssessmentServiceImpl) Update(ctx context.Context, assessment *model.Fi
 	}
 
 	// Verify the assessment exists
-	_, err := s.GetByID(ctx, assessment.ID)
+	existing, err := s.GetByID(ctx, assessment.ID)
 	if err != nil {
 		return err
 	}
+	audit.Before(ctx, existing)
 
 	return s.repo.Update(ctx, assessment)
 }
@@ -72,10 +74,11 @@ func (s *AssessmentServiceImpl) Delete(ctx context.Context, id int64) error {
 	}
 
 	// Verify the assessment exists
-	_, err := s.GetByID(ctx, id)
+	existing, err := s.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	audit.Before(ctx, existing)
 
 	return s.repo.Delete(ctx, id)
 }
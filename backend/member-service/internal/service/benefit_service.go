@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/member-service/internal/model"
 )
 
@@ -57,10 +58,11 @@ func (s *BenefitServiceImpl) Update(ctx context.Context, benefit *model.Membersh
 	}
 
 	// Verify the benefit exists
-	_, err := s.GetByID(ctx, benefit.ID)
+	existing, err := s.GetByID(ctx, benefit.ID)
 	if err != nil {
 		return err
 	}
+	audit.Before(ctx, existing)
 
 	return s.repo.Update(ctx, benefit)
 }
@@ -72,10 +74,11 @@ func (s *BenefitServiceImpl) Delete(ctx context.Context, id int64) error {
 	}
 
 	// Verify the benefit exists
-	_, err := s.GetByID(ctx, id)
+	existing, err := s.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	audit.Before(ctx, existing)
 
 	return s.repo.Delete(ctx, id)
 }
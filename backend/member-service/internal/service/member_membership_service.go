@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/member-service/internal/model"
 )
 
@@ -67,10 +68,11 @@ func (s *MemberMembershipServiceImpl) Update(ctx context.Context, memberMembersh
 	}
 
 	// Verify the member membership exists
-	_, err := s.GetByID(ctx, memberMembership.ID)
+	existing, err := s.GetByID(ctx, memberMembership.ID)
 	if err != nil {
 		return err
 	}
+	audit.Before(ctx, existing)
 
 	// Validate dates
 	if !memberMembership.EndDate.IsZero() && !memberMembership.StartDate.IsZero() {
@@ -89,10 +91,11 @@ func (s *MemberMembershipServiceImpl) Delete(ctx context.Context, id int64) erro
 	}
 
 	// Verify the member membership exists
-	_, err := s.GetByID(ctx, id)
+	existing, err := s.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	audit.Before(ctx, existing)
 
 	return s.repo.Delete(ctx, id)
 }
@@ -115,7 +118,7 @@ func (s *MemberMembershipServiceImpl) GetActiveMembership(ctx context.Context, m
 	membership, err := s.repo.GetActiveMembership(ctx, memberID)
 	if err != nil {
 		// Check if this is a "no rows" error
-		if strings.Contains(err.Error(), "sql: no rows") {
+		if strings.Contains(err.Error(), "sql: no rows") || err.Error() == "active membership not found" {
 			return nil, ErrMemberMembershipNotFound
 		}
 		return nil, err
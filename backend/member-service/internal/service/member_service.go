@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/member-service/internal/model"
 )
 
@@ -75,6 +76,7 @@ func (s *MemberServiceImpl) Update(ctx context.Context, member *model.Member) er
 	if err != nil {
 		return err
 	}
+	audit.Before(ctx, existingMember)
 
 	// Check if email is being changed and if it's already in use
 	if member.Email != existingMember.Email {
@@ -99,10 +101,11 @@ func (s *MemberServiceImpl) Delete(ctx context.Context, id int64) error {
 	}
 
 	// Verify the member exists
-	_, err := s.GetByID(ctx, id)
+	existing, err := s.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	audit.Before(ctx, existing)
 
 	return s.repo.Delete(ctx, id)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/member-service/internal/model"
 )
 
@@ -68,6 +69,7 @@ func (s *MembershipServiceImpl) Update(ctx context.Context, membership *model.Me
 	if err != nil {
 		return err
 	}
+	audit.Before(ctx, existing)
 
 	// Check if name is being changed and if it's already in use
 	if membership.MembershipName != existing.MembershipName {
@@ -87,10 +89,11 @@ func (s *MembershipServiceImpl) Delete(ctx context.Context, id int64) error {
 	}
 
 	// Verify the membership exists
-	_, err := s.GetByID(ctx, id)
+	existing, err := s.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	audit.Before(ctx, existing)
 
 	// Check if membership is in use by any members
 	inUse, err := s.repo.IsMembershipInUse(ctx, id)
@@ -139,10 +142,11 @@ func (s *MembershipServiceImpl) UpdateStatus(ctx context.Context, id int64, isAc
 	}
 
 	// Verify the membership exists
-	_, err := s.GetByID(ctx, id)
+	existing, err := s.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	audit.Before(ctx, existing)
 
 	return s.repo.UpdateStatus(ctx, id, isActive)
 }
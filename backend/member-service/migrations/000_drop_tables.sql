@@ -16,3 +16,7 @@ DROP INDEX IF EXISTS idx_assessments_date;
 DROP INDEX IF EXISTS idx_assessments_trainer_id;
 DROP INDEX IF EXISTS idx_benefits_membership_id;
 DROP INDEX IF EXISTS idx_memberships_active;
+
+-- Drop the audit log
+DROP TABLE IF EXISTS audit_log CASCADE;
+DROP FUNCTION IF EXISTS prevent_audit_log_change();
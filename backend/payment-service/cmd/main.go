@@ -37,7 +37,7 @@ func main() {
 	h.SetService(svc)
 
 	// Create and start server
-	srv := server.NewServer(h, fmt.Sprintf("%d", cfg.ServerPort))
+	srv := server.NewServer(h, fmt.Sprintf("%d", cfg.ServerPort), repo.Audit())
 
 	// Set up graceful shutdown
 	done := make(chan os.Signal, 1)
@@ -106,7 +106,12 @@ func (h *Handler) ListPayments(c *gin.Context) {
 		pageSize = 10
 	}
 
-	payments, total, err := h.svc.Payment().List(c.Request.Context(), map[string]interface{}{}, page, pageSize)
+	filter := map[string]interface{}{}
+	if invoiceNumber := c.Query("invoice_number"); invoiceNumber != "" {
+		filter["invoice_number"] = invoiceNumber
+	}
+
+	payments, total, err := h.svc.Payment().List(c.Request.Context(), filter, page, pageSize)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
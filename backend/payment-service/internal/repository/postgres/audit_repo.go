@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/jmoiron/sqlx"
+)
+
+// auditLogRow is the database row of an audit entry
+type auditLogRow struct {
+	AuditID      int64          `db:"audit_id"`
+	Actor        string         `db:"actor"`
+	ActorID      string         `db:"actor_id"`
+	Action       string         `db:"action"`
+	ResourceType string         `db:"resource_type"`
+	ResourceID   string         `db:"resource_id"`
+	BeforeState  sql.NullString `db:"before_state"`
+	AfterState   sql.NullString `db:"after_state"`
+	Changes      sql.NullString `db:"changes"`
+	Method       string         `db:"method"`
+	Path         string         `db:"path"`
+	IPAddress    string         `db:"ip_address"`
+	CreatedAt    time.Time      `db:"created_at"`
+}
+
+type auditRepository struct {
+	db *sqlx.DB
+}
+
+// NewAuditRepository creates a new audit log repository
+func NewAuditRepository(db *sqlx.DB) audit.Store {
+	return &auditRepository{
+		db: db,
+	}
+}
+
+// Record appends an entry to the audit log
+func (r *auditRepository) Record(ctx context.Context, entry *audit.Entry) error {
+	query := `
+		INSERT INTO audit_log (
+			actor, actor_id, action, resource_type, resource_id,
+			before_state, after_state, changes, method, path, ip_address, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+		) RETURNING audit_id
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		entry.Actor,
+		entry.ActorID,
+		entry.Action,
+		entry.ResourceType,
+		entry.ResourceID,
+		jsonColumn(entry.Before),
+		jsonColumn(entry.After),
+		jsonColumn(entry.Changes),
+		entry.Method,
+		entry.Path,
+		entry.IP,
+		entry.CreatedAt,
+	).Scan(&entry.ID)
+	if err != nil {
+		return fmt.Errorf("creating audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// List returns audit entries matching the filter, newest first, with the
+// total count
+func (r *auditRepository) List(ctx context.Context, filter audit.Filter) ([]audit.Entry, int, error) {
+	where := []string{}
+	args := []interface{}{}
+
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		where = append(where, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Actor != "" {
+		add("actor = $%d", filter.Actor)
+	}
+	if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+	if filter.ResourceType != "" {
+		add("resource_type = $%d", filter.ResourceType)
+	}
+	if filter.ResourceID != "" {
+		add("resource_id = $%d", filter.ResourceID)
+	}
+	if filter.From != nil {
+		add("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("created_at <= $%d", *filter.To)
+	}
+
+	whereClause := ""
+	if len(where) > 0 {
+		whereClause = "WHERE " + strings.Join(where, " AND ")
+	}
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM audit_log %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, fmt.Errorf("counting audit entries: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT audit_id, actor, actor_id, action, resource_type, resource_id,
+			before_state, after_state, changes, method, path, ip_address, created_at
+		FROM audit_log
+		%s
+		ORDER BY created_at DESC, audit_id DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, len(args)+1, len(args)+2)
+	args = append(args, filter.Limit, filter.Offset)
+
+	var rows []auditLogRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("listing audit entries: %w", err)
+	}
+
+	entries := make([]audit.Entry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, audit.Entry{
+			ID:           row.AuditID,
+			Actor:        row.Actor,
+			ActorID:      row.ActorID,
+			Action:       row.Action,
+			ResourceType: row.ResourceType,
+			ResourceID:   row.ResourceID,
+			Before:       jsonValue(row.BeforeState),
+			After:        jsonValue(row.AfterState),
+			Changes:      jsonValue(row.Changes),
+			Method:       row.Method,
+			Path:         row.Path,
+			IP:           row.IPAddress,
+			CreatedAt:    row.CreatedAt,
+		})
+	}
+
+	return entries, total, nil
+}
+
+// jsonColumn converts a JSON document to a nullable column value
+func jsonColumn(data json.RawMessage) sql.NullString {
+	if len(data) == 0 {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(data), Valid: true}
+}
+
+// jsonValue converts a nullable column value to a JSON document
+func jsonValue(s sql.NullString) json.RawMessage {
+	if !s.Valid {
+		return nil
+	}
+	return json.RawMessage(s.String)
+}
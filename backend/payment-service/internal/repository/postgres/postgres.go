@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/payment-service/internal/repository"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
@@ -16,6 +17,7 @@ type PostgresRepository struct {
 	paymentRepo     repository.PaymentRepository
 	paymentTypeRepo repository.PaymentTypeRepository
 	transactionRepo repository.TransactionRepository
+	auditRepo       audit.Store
 }
 
 // NewPostgresRepository creates a new PostgreSQL repository
@@ -38,6 +40,7 @@ func NewPostgresRepository(connectionString string) (repository.Repository, erro
 	repo.paymentRepo = NewPaymentRepository(db)
 	repo.paymentTypeRepo = NewPaymentTypeRepository(db)
 	repo.transactionRepo = NewTransactionRepository(db)
+	repo.auditRepo = NewAuditRepository(db)
 
 	return repo, nil
 }
@@ -57,6 +60,11 @@ func (r *PostgresRepository) Transaction() repository.TransactionRepository {
 	return r.transactionRepo
 }
 
+// Audit returns the audit log repository
+func (r *PostgresRepository) Audit() audit.Store {
+	return r.auditRepo
+}
+
 // Ping checks if the database connection is active
 func (r *PostgresRepository) Ping(ctx context.Context) error {
 	return r.db.PingContext(ctx)
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/payment-service/internal/model"
 )
 
@@ -11,6 +12,7 @@ type Repository interface {
 	Payment() PaymentRepository
 	PaymentType() PaymentTypeRepository
 	Transaction() TransactionRepository
+	Audit() audit.Store
 	Ping(ctx context.Context) error // Add Ping method to check database connectivity
 	Close() error
 }
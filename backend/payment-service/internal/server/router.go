@@ -1,12 +1,13 @@
 package server
 
 import (
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/payment-service/internal/handler"
 	"github.com/gin-gonic/gin"
 )
 
 // setupRoutes configures all API routes for the payment service
-func setupRoutes(router *gin.Engine, handler *handler.Handler) {
+func setupRoutes(router *gin.Engine, handler *handler.Handler, auditStore audit.Store) {
 	// Health check endpoint
 	router.GET("/health", handler.HealthCheck)
 
@@ -50,5 +51,8 @@ func setupRoutes(router *gin.Engine, handler *handler.Handler) {
 		transactions.GET("/payment/:paymentID", handler.ListTransactionsByPayment)
 		transactions.GET("/status/:status", handler.ListTransactionsByStatus)
 		transactions.POST("/process", handler.ProcessPayment)
+
+		// Audit trail of changes made through this service
+		api.GET("/audit/payments", audit.ListHandler(auditStore))
 	}
 }
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/identity"
 	"github.com/FurkanArikk/fitness-center/backend/payment-service/internal/handler"
 	"github.com/gin-gonic/gin"
 )
@@ -17,11 +19,17 @@ type Server struct {
 }
 
 // NewServer creates a new server instance
-func NewServer(h *handler.Handler, port string) *Server {
+func NewServer(h *handler.Handler, port string, auditStore audit.Store) *Server {
 	router := gin.Default()
 
+	// Read the caller identity forwarded by the gateway
+	router.Use(identity.Identify())
+
+	// Record changes in the audit log
+	router.Use(audit.Middleware(auditStore, identity.Actor))
+
 	// Setup all routes
-	setupRoutes(router, h)
+	setupRoutes(router, h, auditStore)
 
 	srv := &Server{
 		router: router,
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/payment-service/internal/model"
 	"github.com/FurkanArikk/fitness-center/backend/payment-service/internal/repository"
 )
@@ -46,11 +47,17 @@ func (s *paymentService) GetByID(ctx context.Context, id int) (*model.Payment, e
 
 // Update updates an existing payment
 func (s *paymentService) Update(ctx context.Context, payment *model.Payment) (*model.Payment, error) {
+	if existing, err := s.repo.Payment().GetByID(ctx, payment.PaymentID); err == nil {
+		audit.Before(ctx, existing)
+	}
 	return s.repo.Payment().Update(ctx, payment)
 }
 
 // Delete removes a payment
 func (s *paymentService) Delete(ctx context.Context, id int) error {
+	if existing, err := s.repo.Payment().GetByID(ctx, id); err == nil {
+		audit.Before(ctx, existing)
+	}
 	return s.repo.Payment().Delete(ctx, id)
 }
 
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/payment-service/internal/model"
 	"github.com/FurkanArikk/fitness-center/backend/payment-service/internal/repository"
 )
@@ -42,11 +43,17 @@ func (s *paymentTypeService) GetByID(ctx context.Context, id int) (*model.Paymen
 
 // Update updates an existing payment type
 func (s *paymentTypeService) Update(ctx context.Context, paymentType *model.PaymentType) (*model.PaymentType, error) {
+	if existing, err := s.repo.PaymentType().GetByID(ctx, paymentType.PaymentTypeID); err == nil {
+		audit.Before(ctx, existing)
+	}
 	return s.repo.PaymentType().Update(ctx, paymentType)
 }
 
 // Delete removes a payment type
 func (s *paymentTypeService) Delete(ctx context.Context, id int) error {
+	if existing, err := s.repo.PaymentType().GetByID(ctx, id); err == nil {
+		audit.Before(ctx, existing)
+	}
 	return s.repo.PaymentType().Delete(ctx, id)
 }
 
@@ -62,5 +69,8 @@ func (s *paymentTypeService) GetByName(ctx context.Context, name string) (*model
 
 // ToggleStatus activates or deactivates a payment type
 func (s *paymentTypeService) ToggleStatus(ctx context.Context, id int, isActive bool) error {
+	if existing, err := s.repo.PaymentType().GetByID(ctx, id); err == nil {
+		audit.Before(ctx, existing)
+	}
 	return s.repo.PaymentType().ToggleStatus(ctx, id, isActive)
 }
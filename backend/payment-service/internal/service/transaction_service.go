@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/payment-service/internal/model"
 	"github.com/FurkanArikk/fitness-center/backend/payment-service/internal/repository"
 )
@@ -49,11 +50,17 @@ func (s *transactionService) GetByID(ctx context.Context, id int) (*model.Transa
 
 // Update updates an existing transaction
 func (s *transactionService) Update(ctx context.Context, transaction *model.Transaction) (*model.Transaction, error) {
+	if existing, err := s.repo.Transaction().GetByID(ctx, transaction.TransactionID); err == nil {
+		audit.Before(ctx, existing)
+	}
 	return s.repo.Transaction().Update(ctx, transaction)
 }
 
 // Delete removes a transaction
 func (s *transactionService) Delete(ctx context.Context, id int) error {
+	if existing, err := s.repo.Transaction().GetByID(ctx, id); err == nil {
+		audit.Before(ctx, existing)
+	}
 	return s.repo.Transaction().Delete(ctx, id)
 }
 
@@ -22,3 +22,7 @@ DROP TRIGGER IF EXISTS trg_generate_invoice_number ON payments;
 DROP FUNCTION IF EXISTS generate_invoice_number();
 DROP TRIGGER IF EXISTS trg_update_payment_status ON payment_transactions;
 DROP FUNCTION IF EXISTS update_payment_status();
+
+-- Drop the audit log
+DROP TABLE IF EXISTS audit_log CASCADE;
+DROP FUNCTION IF EXISTS prevent_audit_log_change();
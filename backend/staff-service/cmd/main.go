@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"syscall"
 
+	"github.com/FurkanArikk/fitness-center/backend/identity"
 	"github.com/FurkanArikk/fitness-center/backend/staff-service/internal/config"
 	"github.com/FurkanArikk/fitness-center/backend/staff-service/internal/db"
 	"github.com/FurkanArikk/fitness-center/backend/staff-service/internal/handler"
@@ -42,7 +43,7 @@ func main() {
 	h := handler.NewHandler(database, services)
 
 	// Setup HTTP server
-	srv := server.NewServer(h, strconv.Itoa(cfg.Server.Port))
+	srv := server.NewServer(h, strconv.Itoa(cfg.Server.Port), repos.AuditRepo, identity.NewVerifier(cfg.Auth.JWKSURL))
 
 	// Handle graceful shutdown
 	quit := make(chan os.Signal, 1)
@@ -15,6 +15,7 @@ import (
 type Config struct {
 	Server   ServerConfig
 	Database DatabaseConfig
+	Auth     AuthConfig
 }
 
 // ServerConfig holds HTTP server configuration
@@ -25,6 +26,14 @@ type ServerConfig struct {
 	IdleTimeout  time.Duration
 }
 
+// AuthConfig holds the settings for verifying tokens of the auth service
+type AuthConfig struct {
+	// JWKSURL is where the auth service publishes its token verification
+	// keys. Service tokens, such as class-service's for syncing trainer
+	// ratings, are verified with them.
+	JWKSURL string
+}
+
 // DatabaseConfig holds database configuration
 type DatabaseConfig struct {
 	Host     string
@@ -80,6 +89,9 @@ func Load() (*Config, error) {
 			DBName:   getEnv("STAFF_SERVICE_DB_NAME", "fitness_staff_db"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
 		},
+		Auth: AuthConfig{
+			JWKSURL: getEnv("STAFF_SERVICE_JWKS_URL", "http://localhost:8085/.well-known/jwks.json"),
+		},
 	}
 
 	log.Printf("Server configuration: port=%d", config.Server.Port)
@@ -130,6 +130,34 @@ func (h *TrainerHandler) Update(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// UpdateRating sets a trainer's rating from the class feedback aggregated by
+// class-service
+func (h *TrainerHandler) UpdateRating(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid trainer ID"})
+		return
+	}
+
+	var req model.TrainerRatingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.UpdateRating(c.Request.Context(), id, &req)
+	if err != nil {
+		if err.Error() == "trainer not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // Delete deletes a trainer
 func (h *TrainerHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -5,7 +5,9 @@ import (
 	"time"
 )
 
-// Trainer represents a trainer who provides fitness training sessions
+// Trainer represents a trainer who provides fitness training sessions.
+// RatingCount is the number of class feedback ratings that Rating, synced by
+// class-service, is the average of; while it is zero the rating is set by hand.
 type Trainer struct {
 	TrainerID      int64     `json:"trainer_id" gorm:"column:trainer_id;primaryKey;autoIncrement"`
 	StaffID        int64     `json:"staff_id" gorm:"column:staff_id;not null"`
@@ -13,6 +15,7 @@ type Trainer struct {
 	Certification  string    `json:"certification" gorm:"column:certification;type:varchar(255)"`
 	Experience     int       `json:"experience" gorm:"column:experience;default:0"` // in years
 	Rating         float64   `json:"rating" gorm:"column:rating;type:decimal(3,2);default:0.0"`
+	RatingCount    int       `json:"rating_count" gorm:"column:rating_count;default:0"`
 	IsActive       bool      `json:"is_active" gorm:"column:is_active;default:true"`
 	CreatedAt      time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt      time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
@@ -36,6 +39,13 @@ type TrainerRequest struct {
 	IsActive       bool    `json:"is_active"`
 }
 
+// TrainerRatingRequest is used by class-service to set a trainer's rating
+// from the feedback members gave their classes
+type TrainerRatingRequest struct {
+	Rating      float64 `json:"rating" binding:"min=0,max=5"`
+	RatingCount int     `json:"rating_count" binding:"min=0"`
+}
+
 // TrainerRepository defines the methods to interact with trainer data
 type TrainerRepository interface {
 	GetAll(ctx context.Context) ([]Trainer, error)
@@ -44,6 +54,7 @@ type TrainerRepository interface {
 	GetByStaffID(ctx context.Context, staffID int64) (*Trainer, error)
 	Create(ctx context.Context, req *TrainerRequest) (*Trainer, error)
 	Update(ctx context.Context, id int64, req *TrainerRequest) (*Trainer, error)
+	UpdateRating(ctx context.Context, id int64, req *TrainerRatingRequest) (*Trainer, error)
 	Delete(ctx context.Context, id int64) error
 	GetBySpecialization(ctx context.Context, specialization string) ([]Trainer, error)
 	GetTopRated(ctx context.Context, limit int) ([]Trainer, error)
@@ -58,6 +69,8 @@ type TrainerService interface {
 	GetByStaffID(ctx context.Context, staffID int64) (*Trainer, error)
 	Create(ctx context.Context, trainer *Trainer) (*Trainer, error)
 	Update(ctx context.Context, trainer *Trainer) (*Trainer, error)
+	// UpdateRating sets a trainer's rating from class feedback
+	UpdateRating(ctx context.Context, id int64, req *TrainerRatingRequest) (*Trainer, error)
 	Delete(ctx context.Context, id int64) error
 	GetBySpecialization(ctx context.Context, specialization string) ([]Trainer, error)
 	GetTopRated(ctx context.Context, limit int) ([]Trainer, error)
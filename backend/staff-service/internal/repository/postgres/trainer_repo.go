@@ -98,7 +98,10 @@ func (r *TrainerRepository) Update(ctx context.Context, id int64, req *model.Tra
 	trainer.Specialization = req.Specialization
 	trainer.Certification = req.Certification
 	trainer.Experience = req.Experience
-	trainer.Rating = req.Rating
+	// Ratings synced from class feedback are kept
+	if trainer.RatingCount == 0 {
+		trainer.Rating = req.Rating
+	}
 
 	result = r.db.WithContext(ctx).Save(&trainer)
 	if result.Error != nil {
@@ -108,6 +111,30 @@ func (r *TrainerRepository) Update(ctx context.Context, id int64, req *model.Tra
 	return &trainer, nil
 }
 
+// UpdateRating sets the rating of a trainer from class feedback. Inactive
+// trainers are updated too, so their past classes keep counting.
+func (r *TrainerRepository) UpdateRating(ctx context.Context, id int64, req *model.TrainerRatingRequest) (*model.Trainer, error) {
+	result := r.db.WithContext(ctx).Model(&model.Trainer{}).
+		Where("trainer_id = ?", id).
+		Updates(map[string]interface{}{
+			"rating":       req.Rating,
+			"rating_count": req.RatingCount,
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("error updating trainer rating: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("trainer not found")
+	}
+
+	var trainer model.Trainer
+	if err := r.db.WithContext(ctx).Where("trainer_id = ?", id).First(&trainer).Error; err != nil {
+		return nil, fmt.Errorf("error querying trainer: %w", err)
+	}
+
+	return &trainer, nil
+}
+
 // Delete soft deletes a trainer by setting is_active to false
 func (r *TrainerRepository) Delete(ctx context.Context, id int64) error {
 	result := r.db.WithContext(ctx).Model(&model.Trainer{}).
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/staff-service/internal/model"
 	"github.com/FurkanArikk/fitness-center/backend/staff-service/internal/repository/postgres"
 	"gorm.io/gorm"
@@ -12,6 +13,7 @@ type Repository struct {
 	QualificationRepo model.QualificationRepository
 	TrainerRepo       model.TrainerRepository
 	TrainingRepo      model.PersonalTrainingRepository
+	AuditRepo         audit.Store
 }
 
 // NewRepository creates a new repository factory with all repositories
@@ -21,6 +23,7 @@ func NewRepository(db *gorm.DB) *Repository {
 		QualificationRepo: postgres.NewQualificationRepository(db),
 		TrainerRepo:       postgres.NewTrainerRepository(db),
 		TrainingRepo:      postgres.NewPersonalTrainingRepository(db),
+		AuditRepo:         postgres.NewAuditRepository(db),
 	}
 }
 
@@ -43,3 +46,8 @@ func NewTrainerRepository(db *gorm.DB) model.TrainerRepository {
 func NewPersonalTrainingRepository(db *gorm.DB) model.PersonalTrainingRepository {
 	return postgres.NewPersonalTrainingRepository(db)
 }
+
+// NewAuditRepository creates a new audit log repository
+func NewAuditRepository(db *gorm.DB) audit.Store {
+	return postgres.NewAuditRepository(db)
+}
@@ -1,12 +1,17 @@
 package server
 
 import (
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/identity"
 	"github.com/FurkanArikk/fitness-center/backend/staff-service/internal/handler"
 	"github.com/gin-gonic/gin"
 )
 
+// ratingsSyncScope is the service token scope needed to set trainer ratings
+const ratingsSyncScope = "ratings:sync"
+
 // setupRoutes configures all API routes for the application
-func setupRoutes(router *gin.Engine, handler *handler.Handler) {
+func setupRoutes(router *gin.Engine, handler *handler.Handler, auditStore audit.Store, verifier *identity.Verifier) {
 	// Health check endpoint
 	router.GET("/health", handler.HealthCheck)
 
@@ -42,6 +47,9 @@ func setupRoutes(router *gin.Engine, handler *handler.Handler) {
 			trainers.GET("/:id", handler.TrainerHandler.GetByID)
 			trainers.POST("", handler.TrainerHandler.Create)
 			trainers.PUT("/:id", handler.TrainerHandler.Update)
+			// Rating synced by class-service from class feedback, with a
+			// service token carrying the ratings:sync scope
+			trainers.PUT("/:id/rating", identity.RequireService(verifier, ratingsSyncScope), handler.TrainerHandler.UpdateRating)
 			trainers.DELETE("/:id", handler.TrainerHandler.Delete)
 			trainers.GET("/top-rated", handler.TrainerHandler.GetTopRated)
 			// Use the documented method name for specialization
@@ -61,5 +69,8 @@ func setupRoutes(router *gin.Engine, handler *handler.Handler) {
 			// Add route for trainer's sessions using GetTrainingSessions (will use query parameter)
 			trainingSessions.GET("/trainer/:id", handler.TrainingHandler.GetTrainingSessions)
 		}
+
+		// Audit trail of changes made through this service
+		api.GET("/audit/staff", audit.ListHandler(auditStore))
 	}
 }
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
+	"github.com/FurkanArikk/fitness-center/backend/identity"
 	"github.com/FurkanArikk/fitness-center/backend/staff-service/internal/handler"
 	"github.com/gin-gonic/gin"
 )
@@ -17,16 +19,18 @@ type Server struct {
 }
 
 // NewServer creates a new server instance
-func NewServer(h *handler.Handler, port string) *Server {
+func NewServer(h *handler.Handler, port string, auditStore audit.Store, verifier *identity.Verifier) *Server {
 	router := gin.Default()
 
 	// Add middleware
 	router.Use(corsMiddleware())
 	router.Use(contentTypeMiddleware())
 	router.Use(loggingMiddleware())
+	router.Use(identity.Identify())
+	router.Use(audit.Middleware(auditStore, identity.Actor))
 
 	// Set up routes using the function from router.go
-	setupRoutes(router, h)
+	setupRoutes(router, h, auditStore, verifier)
 
 	srv := &Server{
 		router: router,
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/staff-service/internal/model"
 )
 
@@ -56,6 +57,10 @@ func (s *QualificationService) Create(ctx context.Context, qualification *model.
 
 // Update modifies an existing qualification
 func (s *QualificationService) Update(ctx context.Context, qualification *model.Qualification) (*model.Qualification, error) {
+	if existing, err := s.repo.GetByID(ctx, qualification.QualificationID); err == nil {
+		audit.Before(ctx, existing)
+	}
+
 	// Convert to request for repository
 	request := &model.QualificationRequest{
 		StaffID:           qualification.StaffID,
@@ -69,6 +74,9 @@ func (s *QualificationService) Update(ctx context.Context, qualification *model.
 
 // Delete removes a qualification
 func (s *QualificationService) Delete(ctx context.Context, id int64) error {
+	if existing, err := s.repo.GetByID(ctx, id); err == nil {
+		audit.Before(ctx, existing)
+	}
 	return s.repo.Delete(ctx, id)
 }
 
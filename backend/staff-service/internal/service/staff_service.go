@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/staff-service/internal/model"
 )
 
@@ -39,12 +40,18 @@ func (s *StaffService) Create(ctx context.Context, staff *model.Staff) (*model.S
 
 // Update modifies an existing staff member
 func (s *StaffService) Update(ctx context.Context, staff *model.Staff) (*model.Staff, error) {
+	if existing, err := s.repo.GetByID(ctx, staff.StaffID); err == nil {
+		audit.Before(ctx, existing)
+	}
 	// Add any business logic/validation here
 	return s.repo.Update(ctx, staff)
 }
 
 // Delete removes a staff member
 func (s *StaffService) Delete(ctx context.Context, id int64) error {
+	if existing, err := s.repo.GetByID(ctx, id); err == nil {
+		audit.Before(ctx, existing)
+	}
 	// Add any business logic/validation here
 	return s.repo.Delete(ctx, id)
 }
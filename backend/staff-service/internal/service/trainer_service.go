@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/staff-service/internal/model"
 )
 
@@ -99,11 +100,25 @@ func (s *TrainerService) Update(ctx context.Context, trainer *model.Trainer) (*m
 		Rating:         trainer.Rating,
 		IsActive:       trainer.IsActive,
 	}
+	if existing, err := s.repo.GetByID(ctx, trainer.TrainerID); err == nil {
+		audit.Before(ctx, existing)
+	}
 	return s.repo.Update(ctx, trainer.TrainerID, request)
 }
 
+// UpdateRating sets a trainer's rating from class feedback
+func (s *TrainerService) UpdateRating(ctx context.Context, id int64, req *model.TrainerRatingRequest) (*model.Trainer, error) {
+	if existing, err := s.repo.GetByID(ctx, id); err == nil {
+		audit.Before(ctx, existing)
+	}
+	return s.repo.UpdateRating(ctx, id, req)
+}
+
 // Delete removes a trainer
 func (s *TrainerService) Delete(ctx context.Context, id int64) error {
+	if existing, err := s.repo.GetByID(ctx, id); err == nil {
+		audit.Before(ctx, existing)
+	}
 	// Add any business logic/validation here
 	return s.repo.Delete(ctx, id)
 }
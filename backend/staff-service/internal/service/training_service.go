@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/FurkanArikk/fitness-center/backend/audit"
 	"github.com/FurkanArikk/fitness-center/backend/staff-service/internal/model"
 )
 
@@ -144,6 +145,9 @@ func (s *PersonalTrainingService) Update(ctx context.Context, training *model.Pe
 		Status:      training.Status,
 		Price:       training.Price,
 	}
+	if existing, err := s.repo.GetByID(ctx, training.SessionID); err == nil {
+		audit.Before(ctx, existing)
+	}
 	return s.repo.Update(ctx, training.SessionID, request)
 }
 
@@ -158,6 +162,7 @@ func (s *PersonalTrainingService) Delete(ctx context.Context, id int64) error {
 	if training.Status == "Completed" {
 		return fmt.Errorf("cannot delete a completed training session")
 	}
+	audit.Before(ctx, training)
 
 	return s.repo.Delete(ctx, id)
 }
@@ -188,6 +193,7 @@ func (s *PersonalTrainingService) CancelSession(ctx context.Context, id int64) e
 	if training.Status == "Completed" {
 		return fmt.Errorf("cannot cancel a completed training session")
 	}
+	audit.Before(ctx, training)
 
 	// Update status to Cancelled
 	training.Status = "Cancelled"
@@ -201,8 +207,12 @@ func (s *PersonalTrainingService) CancelSession(ctx context.Context, id int64) e
 		Status:      training.Status,
 		Price:       training.Price,
 	}
-	_, err = s.repo.Update(ctx, training.SessionID, request)
-	return err
+	updated, err := s.repo.Update(ctx, training.SessionID, request)
+	if err != nil {
+		return err
+	}
+	audit.After(ctx, updated)
+	return nil
 }
 
 // CompleteSession marks a personal training session as completed
@@ -217,6 +227,7 @@ func (s *PersonalTrainingService) CompleteSession(ctx context.Context, id int64)
 	if training.Status == "Cancelled" {
 		return fmt.Errorf("cannot complete a cancelled training session")
 	}
+	audit.Before(ctx, training)
 
 	// Update status to Completed
 	training.Status = "Completed"
@@ -230,6 +241,10 @@ func (s *PersonalTrainingService) CompleteSession(ctx context.Context, id int64)
 		Status:      training.Status,
 		Price:       training.Price,
 	}
-	_, err = s.repo.Update(ctx, training.SessionID, request)
-	return err
+	updated, err := s.repo.Update(ctx, training.SessionID, request)
+	if err != nil {
+		return err
+	}
+	audit.After(ctx, updated)
+	return nil
 }
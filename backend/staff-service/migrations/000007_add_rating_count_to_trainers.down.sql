@@ -0,0 +1 @@
+ALTER TABLE trainers DROP COLUMN IF EXISTS rating_count;
@@ -0,0 +1,3 @@
+-- Number of class feedback ratings the trainer's rating is the average of.
+-- Trainers without feedback keep the rating set by hand.
+ALTER TABLE trainers ADD COLUMN IF NOT EXISTS rating_count INTEGER NOT NULL DEFAULT 0;
@@ -12,3 +12,7 @@ DROP INDEX IF EXISTS idx_training_trainer;
 DROP INDEX IF EXISTS idx_training_member;
 DROP INDEX IF EXISTS idx_training_date;
 DROP INDEX IF EXISTS idx_trainer_rating;
+
+-- Drop the audit log
+DROP TABLE IF EXISTS audit_log CASCADE;
+DROP FUNCTION IF EXISTS prevent_audit_log_change();
@@ -14,6 +14,7 @@ type TrainerResponse struct {
 	Certification  string         `json:"certification"`
 	Experience     int            `json:"experience"`
 	Rating         float64        `json:"rating"`
+	RatingCount    int            `json:"rating_count"`
 	IsActive       bool           `json:"is_active"`
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
@@ -51,6 +52,7 @@ func TrainerFromModel(t *model.Trainer) *TrainerResponse {
 		Certification:  t.Certification,
 		Experience:     t.Experience,
 		Rating:         t.Rating,
+		RatingCount:    t.RatingCount,
 		IsActive:       t.IsActive,
 		CreatedAt:      t.CreatedAt,
 		UpdatedAt:      t.UpdatedAt,
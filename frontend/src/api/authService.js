@@ -3,6 +3,18 @@ import { ENDPOINTS } from './endpoints';
 
 // Auth service for managing authentication
 class AuthService {
+  constructor() {
+    // Refresh request shared by all requests that failed while it runs
+    this.refreshPromise = null;
+
+    // Access tokens are short-lived: when a request is rejected with 401,
+    // get a new access token with the refresh token and send it again once
+    apiClient.interceptors.response.use(
+      (response) => response,
+      (error) => this.handleUnauthorized(error)
+    );
+  }
+
   // Login user
   async login(username, password) {
     try {
@@ -12,12 +24,9 @@ class AuthService {
       });
       
       if (response.data.token) {
-        // Store token in localStorage
-        localStorage.setItem('auth_token', response.data.token);
+        // Store tokens in localStorage
+        this.storeTokens(response.data);
         localStorage.setItem('username', username);
-        
-        // Set default authorization header for future requests
-        this.setAuthHeader(response.data.token);
       }
       
       return response.data;
@@ -27,9 +36,78 @@ class AuthService {
     }
   }
 
-  // Logout user
+  // Store the access and refresh tokens of a login or refresh response
+  storeTokens(data) {
+    localStorage.setItem('auth_token', data.token);
+    if (data.refresh_token) {
+      localStorage.setItem('refresh_token', data.refresh_token);
+    }
+
+    // Set default authorization header for future requests
+    this.setAuthHeader(data.token);
+  }
+
+  // Get a new access token with the stored refresh token. Refresh tokens can
+  // be used once, so concurrent callers share a single request.
+  refresh() {
+    if (!this.refreshPromise) {
+      const refreshToken = localStorage.getItem('refresh_token');
+      this.refreshPromise = (refreshToken
+        ? apiClient.post(ENDPOINTS.auth.refresh, { refresh_token: refreshToken }, { _skipAuthRefresh: true })
+        : Promise.reject(new Error('No refresh token'))
+      )
+        .then((response) => {
+          this.storeTokens(response.data);
+          return response.data.token;
+        })
+        .finally(() => {
+          this.refreshPromise = null;
+        });
+    }
+    return this.refreshPromise;
+  }
+
+  // Retry a request rejected with 401 once with a refreshed access token.
+  // When the session cannot be refreshed, the user is logged out.
+  async handleUnauthorized(error) {
+    const { config, response } = error;
+    if (!config || response?.status !== 401 || config._authRetried || config._skipAuthRefresh ||
+        config.url === ENDPOINTS.auth.login || !this.isAuthenticated()) {
+      return Promise.reject(error);
+    }
+
+    let token;
+    try {
+      token = await this.refresh();
+    } catch (refreshError) {
+      console.error('Refresh token error:', refreshError);
+      this.clearSession();
+      if (typeof window !== 'undefined' && window.location.pathname !== '/login') {
+        window.location.assign('/login');
+      }
+      return Promise.reject(error);
+    }
+
+    config._authRetried = true;
+    config.headers = { ...config.headers, Authorization: `Bearer ${token}` };
+    return apiClient(config);
+  }
+
+  // Logout user. The session is revoked on the server as well, so its
+  // refresh token cannot be used again.
   logout() {
+    if (this.isAuthenticated()) {
+      apiClient.post(ENDPOINTS.auth.logout, {}, { _skipAuthRefresh: true }).catch((error) => {
+        console.error('Logout error:', error);
+      });
+    }
+    this.clearSession();
+  }
+
+  // Remove the stored tokens and user
+  clearSession() {
     localStorage.removeItem('auth_token');
+    localStorage.removeItem('refresh_token');
     localStorage.removeItem('username');
     delete apiClient.defaults.headers.common['Authorization'];
   }
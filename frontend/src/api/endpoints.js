@@ -48,6 +48,8 @@ export const ENDPOINTS = {
   // Auth endpoints
   auth: {
     login: `/api/v1/login`,
+    refresh: `/api/v1/refresh`,
+    logout: `/api/v1/logout`,
     updatePassword: `/api/v1/admin/password`,
     listAdmins: `/api/v1/admin/list`,
     createAdmin: `/api/v1/admin/create`,